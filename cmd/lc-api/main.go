@@ -6,18 +6,24 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/rs/zerolog"
 
+	"github.com/limpidchart/lc-api/internal/auth"
 	"github.com/limpidchart/lc-api/internal/backend"
 	"github.com/limpidchart/lc-api/internal/config"
 	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/renderer"
 	"github.com/limpidchart/lc-api/internal/servergrpc"
 	"github.com/limpidchart/lc-api/internal/servergrpchc"
 	"github.com/limpidchart/lc-api/internal/serverhttp"
+	"github.com/limpidchart/lc-api/internal/storage"
 	"github.com/limpidchart/lc-api/internal/tcputils"
+	"github.com/limpidchart/lc-api/internal/tlsutils"
+	"github.com/limpidchart/lc-api/internal/webhook"
 )
 
 // Version contains lc-api version.
@@ -58,19 +64,49 @@ func main() {
 		os.Exit(1)
 	}
 
-	b, err := backend.NewBackend(ctx, cfg.Renderer)
+	b, err := backend.NewBackend(ctx, cfg, rec)
 	if err != nil {
 		cancel()
 		log.Error().Time(zerolog.TimestampFieldName, time.Now().UTC()).Err(err).Msg("Unable to create backend connections")
 		os.Exit(1)
 	}
 
+	webhookDispatcher, err := webhook.NewDispatcher(&log, b.WebhookQueue(), cfg.Webhook, rec)
+	if err != nil {
+		cancel()
+		b.Shutdown()
+		log.Error().Time(zerolog.TimestampFieldName, time.Now().UTC()).Err(err).Msg("Unable to configure webhook dispatcher")
+		os.Exit(1)
+	}
+
 	defer b.Shutdown()
 
-	startServer(ctx, &log, metric.NewServer(&log, cfg.Metrics, rec), errs)
-	startServer(ctx, &log, servergrpc.NewServer(&log, gRPCListener, b, cfg.GRPC, rec), errs)
-	startServer(ctx, &log, serverhttp.NewServer(&log, b, cfg.HTTP, rec), errs)
-	startServer(ctx, &log, servergrpchc.NewServer(&log, hcListener, b), errs)
+	if !b.Authenticator().Enabled() {
+		log.Warn().Time(zerolog.TimestampFieldName, time.Now().UTC()).Msg("Authentication is disabled, API is available without credentials")
+	}
+
+	// Webhook dispatcher is stopped after all servers that push callbacks, so their last callbacks are not lost.
+	dispatcherCtx, dispatcherCancel := context.WithCancel(context.Background())
+	dispatchers := &sync.WaitGroup{}
+	defer dispatchers.Wait()
+	defer dispatcherCancel()
+
+	// Wait for all servers to stop before backend connections are closed.
+	servers := &sync.WaitGroup{}
+	defer servers.Wait()
+
+	startServer(ctx, &log, metric.NewServer(&log, cfg.Metrics, rec), servers, errs)
+	startServer(ctx, &log, servergrpc.NewServer(&log, gRPCListener, b, cfg.GRPC, rec), servers, errs)
+	startServer(ctx, &log, serverhttp.NewServer(&log, b, cfg.HTTP, rec), servers, errs)
+	startServer(ctx, &log, servergrpchc.NewServer(&log, hcListener, b), servers, errs)
+	startServer(ctx, &log, storage.NewJanitor(&log, b.Storage(), cfg.Storage), servers, errs)
+	startServer(ctx, &log, renderer.NewWorkers(&log, b.RenderQueue(), cfg.RenderQueue), servers, errs)
+	startServer(dispatcherCtx, &log, webhookDispatcher, dispatchers, errs)
+	startServer(ctx, &log, auth.NewJWKSWatcher(&log, b.Authenticator(), cfg.Auth), servers, errs)
+	startServer(ctx, &log, tlsutils.NewWatcher(&log, cfg.TLS, map[string]*tlsutils.Certificates{
+		"servers":  b.ServerCertificates(),
+		"renderer": b.RendererCertificates(),
+	}), servers, errs)
 
 	select {
 	case <-ctx.Done():
@@ -91,16 +127,23 @@ type server interface {
 	Serve(ctx context.Context) error
 }
 
-func startServer(ctx context.Context, log *zerolog.Logger, s server, errs chan<- error) {
+func startServer(ctx context.Context, log *zerolog.Logger, s server, servers *sync.WaitGroup, errs chan<- error) {
 	log.Info().
 		Time(zerolog.TimestampFieldName, time.Now().UTC()).
 		Str("version", Version).
 		Str("address", s.Address()).
 		Msg(fmt.Sprintf("Starting %s server", s.Name()))
 
+	servers.Add(1)
+
 	go func() {
+		defer servers.Done()
+
 		if err := s.Serve(ctx); err != nil {
-			errs <- fmt.Errorf("unable to start %s server: %w", s.Name(), err)
+			select {
+			case errs <- fmt.Errorf("unable to start %s server: %w", s.Name(), err):
+			case <-ctx.Done():
+			}
 		}
 	}()
 }
@@ -0,0 +1,161 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+const (
+	// OperationCreateChart represents creation of a chart.
+	OperationCreateChart = "create_chart"
+
+	// OperationGetChart represents reading of a chart.
+	OperationGetChart = "get_chart"
+
+	// OperationGetChartImage represents reading of a chart image.
+	OperationGetChartImage = "get_chart_image"
+
+	// OperationSignChartImage represents signing of a chart image URL.
+	OperationSignChartImage = "sign_chart_image"
+
+	// OperationDeleteChart represents deletion of a chart.
+	OperationDeleteChart = "delete_chart"
+
+	// OperationListCharts represents listing of charts.
+	OperationListCharts = "list_charts"
+)
+
+const (
+	// OutcomeSuccess represents an operation that is completed.
+	OutcomeSuccess = "success"
+
+	// OutcomeFailure represents an operation that is rejected or failed.
+	OutcomeFailure = "failure"
+)
+
+const (
+	// TransportGRPC represents operations requested via gRPC API.
+	TransportGRPC = "grpc"
+
+	// TransportHTTP represents operations requested via REST API.
+	TransportHTTP = "http"
+)
+
+// ErrBadConfig contains error message about audit log configuration that can't be used.
+var ErrBadConfig = errors.New("bad audit log configuration")
+
+// Entry represents a single record of the audit log.
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Operation string    `json:"operation"`
+	Outcome   string    `json:"outcome"`
+	Transport string    `json:"transport"`
+	RequestID string    `json:"request_id"`
+	Identity  string    `json:"identity"`
+	Tenant    string    `json:"tenant"`
+	ClientIP  string    `json:"client_ip"`
+	ChartID   string    `json:"chart_id,omitempty"`
+	SpecHash  string    `json:"spec_hash,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Sink represents a destination of the audit log entries.
+// Entries are written concurrently, so implementations should be safe for concurrent use.
+type Sink interface {
+	Write(entry Entry) error
+	Close() error
+}
+
+// Logger writes chart lifecycle operations into the configured sink.
+type Logger struct {
+	sink Sink
+}
+
+// NewLogger configures a new Logger that writes entries into the provided sink.
+func NewLogger(sink Sink) *Logger {
+	return &Logger{sink: sink}
+}
+
+// NewLoggerFromConfig configures a new Logger that writes entries into the rotated file of the audit configuration.
+// Entries are discarded if the audit log path isn't configured.
+func NewLoggerFromConfig(auditCfg config.AuditConfig) (*Logger, error) {
+	if auditCfg.LogPath == "" {
+		return NewLogger(NopSink{}), nil
+	}
+
+	sink, err := NewFileSink(auditCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLogger(sink), nil
+}
+
+// Record writes the entry of the operation that is completed with the provided error into the sink.
+// Entry time is set to the current time if it's not provided.
+func (l *Logger) Record(entry Entry, opErr error) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+
+	entry.Outcome = OutcomeSuccess
+
+	if opErr != nil {
+		entry.Outcome = OutcomeFailure
+		entry.Error = opErr.Error()
+	}
+
+	if err := l.sink.Write(entry); err != nil {
+		return fmt.Errorf("unable to write audit log entry: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the sink.
+func (l *Logger) Close() error {
+	// nolint: wrapcheck
+	return l.sink.Close()
+}
+
+// SpecHash returns a canonical hash of the chart specification of the create chart request.
+// Expiration, async and callback options are not a part of the specification.
+func SpecHash(req *render.CreateChartRequest) string {
+	spec := &render.CreateChartRequest{
+		Title:   req.Title,
+		Sizes:   req.Sizes,
+		Margins: req.Margins,
+		Axes:    req.Axes,
+		Views:   req.Views,
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(spec)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// NopSink discards all entries.
+type NopSink struct{}
+
+// Write discards the entry.
+func (NopSink) Write(Entry) error {
+	return nil
+}
+
+// Close does nothing.
+func (NopSink) Close() error {
+	return nil
+}
@@ -0,0 +1,119 @@
+package audit_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/limpidchart/lc-api/internal/audit"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+type memorySink struct {
+	mu      sync.Mutex
+	entries []audit.Entry
+}
+
+func (s *memorySink) Write(entry audit.Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = append(s.entries, entry)
+
+	return nil
+}
+
+func (s *memorySink) Close() error {
+	return nil
+}
+
+func TestLogger_Record(t *testing.T) {
+	t.Parallel()
+
+	sink := &memorySink{}
+	auditLog := audit.NewLogger(sink)
+
+	assert.NoError(t, auditLog.Record(audit.Entry{Operation: audit.OperationGetChart, ChartID: "chart"}, nil))
+	assert.NoError(t, auditLog.Record(audit.Entry{Operation: audit.OperationDeleteChart, ChartID: "chart"}, errors.New("chart is not found")))
+
+	if len(sink.entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(sink.entries))
+	}
+
+	assert.Equal(t, audit.OutcomeSuccess, sink.entries[0].Outcome)
+	assert.Empty(t, sink.entries[0].Error)
+	assert.WithinDuration(t, time.Now(), sink.entries[0].Time, time.Second)
+
+	assert.Equal(t, audit.OutcomeFailure, sink.entries[1].Outcome)
+	assert.Equal(t, "chart is not found", sink.entries[1].Error)
+}
+
+func TestNewLoggerFromConfig_Err(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		auditCfg config.AuditConfig
+		expected string
+	}{
+		{
+			"zero_max_size",
+			config.AuditConfig{LogPath: "audit.ndjson", MaxSizeMegabytes: 0, MaxBackups: 1},
+			"bad audit log configuration: max size should be positive",
+		},
+		{
+			"negative_max_backups",
+			config.AuditConfig{LogPath: "audit.ndjson", MaxSizeMegabytes: 1, MaxBackups: -1},
+			"bad audit log configuration: max backups should be positive",
+		},
+		{
+			"no_backups",
+			config.AuditConfig{LogPath: "audit.ndjson", MaxSizeMegabytes: 1, MaxBackups: 0},
+			"bad audit log configuration: max backups should be positive",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			auditLog, err := audit.NewLoggerFromConfig(tc.auditCfg)
+			assert.Nil(t, auditLog)
+			assert.True(t, errors.Is(err, audit.ErrBadConfig))
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestSpecHash(t *testing.T) {
+	t.Parallel()
+
+	req := &render.CreateChartRequest{
+		Title: "Revenue",
+		Sizes: &render.ChartSizes{Width: wrapperspb.Int32(800), Height: wrapperspb.Int32(600)},
+	}
+
+	reqWithOpts := &render.CreateChartRequest{
+		Title:       "Revenue",
+		Sizes:       &render.ChartSizes{Width: wrapperspb.Int32(800), Height: wrapperspb.Int32(600)},
+		ExpiresIn:   durationpb.New(time.Hour),
+		Async:       true,
+		CallbackUrl: "https://example.com/callback",
+	}
+
+	otherReq := &render.CreateChartRequest{
+		Title: "Expenses",
+		Sizes: &render.ChartSizes{Width: wrapperspb.Int32(800), Height: wrapperspb.Int32(600)},
+	}
+
+	assert.Len(t, audit.SpecHash(req), 64)
+	assert.Equal(t, audit.SpecHash(req), audit.SpecHash(reqWithOpts))
+	assert.NotEqual(t, audit.SpecHash(req), audit.SpecHash(otherReq))
+}
@@ -0,0 +1,169 @@
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/limpidchart/lc-api/internal/config"
+)
+
+const (
+	logFilePerm = 0o640
+
+	bytesInMegabyte = 1 << 20
+)
+
+// FileSink writes entries as JSON lines into a file that is rotated once it exceeds the maximum size.
+// Rotated files are renamed with .1, .2, ... suffixes where .1 is the most recent one,
+// files older than the maximum number of backups are removed.
+type FileSink struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// NewFileSink opens the audit log file of the provided configuration for appending.
+func NewFileSink(auditCfg config.AuditConfig) (*FileSink, error) {
+	if auditCfg.MaxSizeMegabytes <= 0 {
+		return nil, fmt.Errorf("%w: max size should be positive", ErrBadConfig)
+	}
+
+	// Audit log is append-only, so the rotated file is always kept in a backup.
+	if auditCfg.MaxBackups <= 0 {
+		return nil, fmt.Errorf("%w: max backups should be positive", ErrBadConfig)
+	}
+
+	s := &FileSink{
+		path:       auditCfg.LogPath,
+		maxSize:    int64(auditCfg.MaxSizeMegabytes) * bytesInMegabyte,
+		maxBackups: auditCfg.MaxBackups,
+	}
+
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Write appends the entry to the file, the file is rotated before the write if the entry doesn't fit into it.
+// Entry is still appended to the current file if the backups can't be rotated.
+func (s *FileSink) Write(entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("unable to marshal audit log entry: %w", err)
+	}
+
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// File is reopened if it can't be reopened during the previous rotation.
+	if s.file == nil {
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+
+	var rotateErr error
+
+	if s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		if rotateErr = s.rotate(); s.file == nil {
+			return rotateErr
+		}
+	}
+
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+
+	if err != nil {
+		return fmt.Errorf("unable to write audit log file: %w", err)
+	}
+
+	return rotateErr
+}
+
+// Close closes the file.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("unable to close audit log file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, logFilePerm)
+	if err != nil {
+		return fmt.Errorf("unable to open audit log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+
+		return fmt.Errorf("unable to stat audit log file: %w", err)
+	}
+
+	s.file = file
+	s.size = info.Size()
+
+	return nil
+}
+
+// rotate reopens the file after rotation of the backups.
+// File is nil only if it can't be reopened.
+func (s *FileSink) rotate() error {
+	closeErr := s.file.Close()
+	s.file = nil
+
+	shiftErr := s.shiftBackups()
+
+	if err := s.open(); err != nil {
+		return err
+	}
+
+	if closeErr != nil {
+		return fmt.Errorf("unable to close audit log file: %w", closeErr)
+	}
+
+	return shiftErr
+}
+
+// shiftBackups renames the current file into the first backup and shifts the existing backups.
+func (s *FileSink) shiftBackups() error {
+	if err := os.Remove(s.backupPath(s.maxBackups)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unable to remove audit log backup: %w", err)
+	}
+
+	for i := s.maxBackups - 1; i > 0; i-- {
+		if err := os.Rename(s.backupPath(i), s.backupPath(i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("unable to rotate audit log backup: %w", err)
+		}
+	}
+
+	if err := os.Rename(s.path, s.backupPath(1)); err != nil {
+		return fmt.Errorf("unable to rotate audit log file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileSink) backupPath(index int) string {
+	return s.path + "." + strconv.Itoa(index)
+}
@@ -0,0 +1,96 @@
+package audit_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/audit"
+	"github.com/limpidchart/lc-api/internal/config"
+)
+
+func readEntries(t *testing.T, path string) []audit.Entry {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read audit log file: %s", err)
+	}
+
+	entries := []audit.Entry{}
+
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		entry := audit.Entry{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("unable to decode audit log entry: %s", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func TestFileSink_Write(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.ndjson")
+
+	sink, err := audit.NewFileSink(config.AuditConfig{LogPath: path, MaxSizeMegabytes: 1, MaxBackups: 1})
+	if err != nil {
+		t.Fatalf("unable to open audit log: %s", err)
+	}
+
+	assert.NoError(t, sink.Write(audit.Entry{Operation: audit.OperationCreateChart, ChartID: "first"}))
+	assert.NoError(t, sink.Write(audit.Entry{Operation: audit.OperationGetChart, ChartID: "first"}))
+	assert.NoError(t, sink.Close())
+
+	// Existing file is appended.
+	sink, err = audit.NewFileSink(config.AuditConfig{LogPath: path, MaxSizeMegabytes: 1, MaxBackups: 1})
+	if err != nil {
+		t.Fatalf("unable to reopen audit log: %s", err)
+	}
+
+	assert.NoError(t, sink.Write(audit.Entry{Operation: audit.OperationDeleteChart, ChartID: "first"}))
+	assert.NoError(t, sink.Close())
+
+	entries := readEntries(t, path)
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+
+	assert.Equal(t, audit.OperationCreateChart, entries[0].Operation)
+	assert.Equal(t, audit.OperationGetChart, entries[1].Operation)
+	assert.Equal(t, audit.OperationDeleteChart, entries[2].Operation)
+}
+
+func TestFileSink_Rotate(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.ndjson")
+
+	sink, err := audit.NewFileSink(config.AuditConfig{LogPath: path, MaxSizeMegabytes: 1, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("unable to open audit log: %s", err)
+	}
+
+	// Every entry takes more than a half of the file so every write rotates it.
+	bigErr := strings.Repeat("x", 600*1024)
+
+	for _, chartID := range []string{"first", "second", "third", "fourth"} {
+		assert.NoError(t, sink.Write(audit.Entry{Operation: audit.OperationCreateChart, ChartID: chartID, Error: bigErr}))
+	}
+
+	assert.NoError(t, sink.Close())
+
+	assert.Equal(t, "fourth", readEntries(t, path)[0].ChartID)
+	assert.Equal(t, "third", readEntries(t, path+".1")[0].ChartID)
+	assert.Equal(t, "second", readEntries(t, path+".2")[0].ChartID)
+
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	apiKeysCommentPrefix  = "#"
+	apiKeysScopeSeparator = ","
+
+	apiKeysFieldsWithoutScopes = 2
+	apiKeysFieldsWithScopes    = 3
+)
+
+// ErrBadAPIKeysFile contains error message about API keys file that can't be parsed.
+var ErrBadAPIKeysFile = errors.New("bad API keys file")
+
+// APIKeys represents a set of API keys identities keyed by hashed API keys.
+type APIKeys struct {
+	identities map[string]Identity
+}
+
+// LoadAPIKeys reads API keys from the file.
+// Every line of the file contains an identity, hex SHA-256 hash of its API key and optional comma separated scopes
+// separated by whitespace, identities without scopes are granted all scopes.
+// Empty lines and lines that start with "#" are ignored.
+func LoadAPIKeys(path string) (*APIKeys, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open API keys file: %w", err)
+	}
+	defer f.Close()
+
+	keys := &APIKeys{
+		identities: make(map[string]Identity),
+	}
+
+	scanner := bufio.NewScanner(f)
+
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, apiKeysCommentPrefix) {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != apiKeysFieldsWithoutScopes && len(fields) != apiKeysFieldsWithScopes {
+			return nil, fmt.Errorf("%w: line %d should contain an identity, an API key hash and optional scopes", ErrBadAPIKeysFile, lineNum)
+		}
+
+		identity, hash := fields[0], strings.ToLower(fields[1])
+
+		if decoded, err := hex.DecodeString(hash); err != nil || len(decoded) != sha256.Size {
+			return nil, fmt.Errorf("%w: line %d contains API key hash that is not a hex SHA-256 hash", ErrBadAPIKeysFile, lineNum)
+		}
+
+		if _, ok := keys.identities[hash]; ok {
+			return nil, fmt.Errorf("%w: line %d contains duplicated API key hash", ErrBadAPIKeysFile, lineNum)
+		}
+
+		scopes := AllScopes()
+
+		if len(fields) == apiKeysFieldsWithScopes {
+			scopes = strings.Split(fields[2], apiKeysScopeSeparator)
+
+			for _, scope := range scopes {
+				if !containsString(AllScopes(), scope) {
+					return nil, fmt.Errorf("%w: line %d contains unknown scope %q", ErrBadAPIKeysFile, lineNum, scope)
+				}
+			}
+		}
+
+		keys.identities[hash] = Identity{Subject: identity, Scopes: scopes}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read API keys file: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Identify returns identity of the API key.
+func (k *APIKeys) Identify(apiKey string) (*Identity, error) {
+	identity, ok := k.identities[HashAPIKey(apiKey)]
+	if !ok {
+		return nil, ErrBadAPIKey
+	}
+
+	return &identity, nil
+}
+
+// HashAPIKey returns hex SHA-256 hash of the API key as it's kept in the API keys file.
+func HashAPIKey(apiKey string) string {
+	hash := sha256.Sum256([]byte(apiKey))
+
+	return hex.EncodeToString(hash[:])
+}
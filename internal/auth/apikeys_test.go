@@ -0,0 +1,88 @@
+package auth_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+)
+
+func TestLoadAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "api-keys")
+	content := "# reporting service\n\nreporting " + auth.HashAPIKey("reporting-key") + "\n  billing   " + auth.HashAPIKey("billing-key") + "  charts:read,charts:list\n"
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("unable to write API keys file: %s", err)
+	}
+
+	apiKeys, err := auth.LoadAPIKeys(path)
+	if err != nil {
+		t.Fatalf("unable to load API keys: %s", err)
+	}
+
+	identity, err := apiKeys.Identify("reporting-key")
+	assert.NoError(t, err)
+	assert.Equal(t, &auth.Identity{Subject: "reporting", Scopes: auth.AllScopes()}, identity)
+
+	identity, err = apiKeys.Identify("billing-key")
+	assert.NoError(t, err)
+	assert.Equal(t, &auth.Identity{Subject: "billing", Scopes: []string{auth.ScopeChartsRead, auth.ScopeChartsList}}, identity)
+
+	identity, err = apiKeys.Identify(auth.HashAPIKey("billing-key"))
+	assert.Nil(t, identity)
+	assert.True(t, errors.Is(err, auth.ErrBadAPIKey))
+}
+
+func TestLoadAPIKeys_Err(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			"no_hash",
+			"reporting\n",
+			"bad API keys file: line 1 should contain an identity, an API key hash and optional scopes",
+		},
+		{
+			"unknown_scope",
+			"reporting " + auth.HashAPIKey("key") + " charts:read,charts:write\n",
+			"bad API keys file: line 1 contains unknown scope \"charts:write\"",
+		},
+		{
+			"plain_key",
+			"# keys\nreporting reporting-key\n",
+			"bad API keys file: line 2 contains API key hash that is not a hex SHA-256 hash",
+		},
+		{
+			"duplicate",
+			"reporting " + auth.HashAPIKey("key") + "\nbilling " + auth.HashAPIKey("key") + "\n",
+			"bad API keys file: line 2 contains duplicated API key hash",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "api-keys")
+			if err := os.WriteFile(path, []byte(tc.content), 0o600); err != nil {
+				t.Fatalf("unable to write API keys file: %s", err)
+			}
+
+			apiKeys, err := auth.LoadAPIKeys(path)
+			assert.Nil(t, apiKeys)
+			assert.True(t, errors.Is(err, auth.ErrBadAPIKeysFile))
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
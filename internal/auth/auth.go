@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/limpidchart/lc-api/internal/config"
+)
+
+const bearerScheme = "Bearer "
+
+var (
+	// ErrMissingCredentials contains error message about request without credentials.
+	ErrMissingCredentials = errors.New("credentials are not provided")
+
+	// ErrBadAPIKey contains error message about API key that is not known.
+	ErrBadAPIKey = errors.New("API key is not valid")
+)
+
+// Identity represents an authenticated caller.
+type Identity struct {
+	// Subject is the name of the caller.
+	Subject string
+
+	// Tenant is the tenant of the caller, it's set only for bearer tokens with tenant claim.
+	Tenant string
+
+	// Scopes are the scopes granted to the caller.
+	Scopes []string
+}
+
+// Credentials represents credentials provided with a request.
+// API key is checked if both API key and bearer token are provided.
+type Credentials struct {
+	APIKey      string
+	BearerToken string
+}
+
+// Authenticator checks request credentials.
+// Authentication is disabled if no credentials sources are configured.
+type Authenticator struct {
+	apiKeys      *APIKeys
+	jwks         *JWKS
+	jwtValidator *JWTValidator
+}
+
+// NewAuthenticator configures a new Authenticator.
+func NewAuthenticator(authCfg config.AuthConfig) (*Authenticator, error) {
+	a := &Authenticator{}
+
+	if authCfg.APIKeysPath != "" {
+		apiKeys, err := LoadAPIKeys(authCfg.APIKeysPath)
+		if err != nil {
+			return nil, err
+		}
+
+		a.apiKeys = apiKeys
+	}
+
+	if authCfg.JWKSPath != "" {
+		jwks, err := LoadJWKS(authCfg.JWKSPath)
+		if err != nil {
+			return nil, err
+		}
+
+		a.jwks = jwks
+		a.jwtValidator = NewJWTValidator(jwks, authCfg.JWTIssuer, authCfg.JWTAudience, time.Duration(authCfg.JWTLeewaySeconds)*time.Second)
+	}
+
+	return a, nil
+}
+
+// Enabled reports if requests should be authenticated.
+func (a *Authenticator) Enabled() bool {
+	return a.apiKeys != nil || a.jwtValidator != nil
+}
+
+// JWKS returns configured JWKS or nil if bearer tokens are not accepted.
+func (a *Authenticator) JWKS() *JWKS {
+	return a.jwks
+}
+
+// BearerToken returns token from the Authorization header value or an empty string if it has another scheme.
+func BearerToken(authorization string) string {
+	if len(authorization) <= len(bearerScheme) || !strings.EqualFold(authorization[:len(bearerScheme)], bearerScheme) {
+		return ""
+	}
+
+	return strings.TrimSpace(authorization[len(bearerScheme):])
+}
+
+// Authenticate returns identity of the request credentials.
+// Credentials of the sources that are not configured are ignored.
+func (a *Authenticator) Authenticate(creds Credentials) (*Identity, error) {
+	switch {
+	case creds.APIKey != "" && a.apiKeys != nil:
+		return a.apiKeys.Identify(creds.APIKey)
+	case creds.BearerToken != "" && a.jwtValidator != nil:
+		return a.jwtValidator.Validate(creds.BearerToken)
+	default:
+		return nil, ErrMissingCredentials
+	}
+}
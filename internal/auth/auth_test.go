@@ -0,0 +1,83 @@
+package auth_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/testutils"
+)
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	authenticator, err := auth.NewAuthenticator(config.AuthConfig{
+		APIKeysPath: testutils.APIKeysFile(t, map[string]string{"reporting": "reporting-key"}),
+	})
+	if err != nil {
+		t.Fatalf("unable to configure authenticator: %s", err)
+	}
+
+	assert.True(t, authenticator.Enabled())
+
+	tt := []struct {
+		name             string
+		creds            auth.Credentials
+		expectedIdentity *auth.Identity
+		expectedErr      error
+	}{
+		{
+			"valid_api_key",
+			auth.Credentials{APIKey: "reporting-key"},
+			&auth.Identity{Subject: "reporting", Scopes: auth.AllScopes()},
+			nil,
+		},
+		{
+			"bad_api_key",
+			auth.Credentials{APIKey: "billing-key"},
+			nil,
+			auth.ErrBadAPIKey,
+		},
+		{
+			"no_credentials",
+			auth.Credentials{},
+			nil,
+			auth.ErrMissingCredentials,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			identity, err := authenticator.Authenticate(tc.creds)
+			assert.Equal(t, tc.expectedIdentity, identity)
+			assert.True(t, errors.Is(err, tc.expectedErr))
+		})
+	}
+}
+
+func TestNewAuthenticator_Disabled(t *testing.T) {
+	t.Parallel()
+
+	authenticator, err := auth.NewAuthenticator(config.AuthConfig{})
+	assert.NoError(t, err)
+	assert.False(t, authenticator.Enabled())
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Parallel()
+
+	reader := &auth.Identity{Subject: "reporting", Scopes: []string{auth.ScopeChartsRead, auth.ScopeChartsList}}
+
+	assert.NoError(t, auth.Authorize(reader, auth.ScopeChartsRead))
+	assert.NoError(t, auth.Authorize(nil, auth.ScopeChartsCreate))
+
+	err := auth.Authorize(reader, auth.ScopeChartsCreate)
+	assert.True(t, errors.Is(err, auth.ErrMissingScope))
+	assert.EqualError(t, err, "caller doesn't have the required scope: charts:create")
+}
@@ -0,0 +1,238 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	jwkKeyTypeRSA = "RSA"
+	jwkKeyTypeEC  = "EC"
+	jwkKeyTypeOKP = "OKP"
+
+	jwkCurveP256    = "P-256"
+	jwkCurveEd25519 = "Ed25519"
+
+	jwkUseSignature = "sig"
+
+	rsaMinModulusBits = 2048
+)
+
+// ErrBadJWKSFile contains error message about JWKS file that can't be parsed.
+var ErrBadJWKSFile = errors.New("bad JWKS file")
+
+// JWKS represents a set of public keys from a JSON Web Key Set file.
+// Keys are re-read by Reload once the file is changed.
+type JWKS struct {
+	path string
+
+	mu      sync.RWMutex
+	keys    []jwk
+	modTime time.Time
+	size    int64
+}
+
+// jwk represents a public key that can verify signatures of a single algorithm.
+type jwk struct {
+	kid string
+	alg string
+	key crypto.PublicKey
+}
+
+type jwksJSON struct {
+	Keys []jwkJSON `json:"keys"`
+}
+
+type jwkJSON struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// LoadJWKS reads public keys from the JWKS file.
+// Keys that can't verify signatures of the supported algorithms are skipped.
+func LoadJWKS(path string) (*JWKS, error) {
+	jwks := &JWKS{path: path}
+
+	if _, err := jwks.Reload(); err != nil {
+		return nil, err
+	}
+
+	return jwks, nil
+}
+
+// Reload re-reads the JWKS file if its modification time or size is changed and reports if keys are reloaded.
+// Previous keys are kept if the file can't be read or parsed.
+func (j *JWKS) Reload() (bool, error) {
+	info, err := os.Stat(j.path)
+	if err != nil {
+		return false, fmt.Errorf("unable to stat JWKS file: %w", err)
+	}
+
+	j.mu.RLock()
+	unchanged := info.ModTime().Equal(j.modTime) && info.Size() == j.size
+	j.mu.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	raw, err := os.ReadFile(j.path)
+	if err != nil {
+		return false, fmt.Errorf("unable to read JWKS file: %w", err)
+	}
+
+	keys, err := parseJWKS(raw)
+	if err != nil {
+		return false, err
+	}
+
+	j.mu.Lock()
+	j.keys = keys
+	j.modTime = info.ModTime()
+	j.size = info.Size()
+	j.mu.Unlock()
+
+	return true, nil
+}
+
+// lookup returns keys that can verify signatures of the algorithm.
+// Only the key with the provided key ID is returned if it's set.
+func (j *JWKS) lookup(kid, alg string) []jwk {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	res := make([]jwk, 0, 1)
+
+	for _, key := range j.keys {
+		if key.alg != alg || (kid != "" && key.kid != kid) {
+			continue
+		}
+
+		res = append(res, key)
+	}
+
+	return res
+}
+
+func parseJWKS(raw []byte) ([]jwk, error) {
+	set := jwksJSON{}
+	if err := json.Unmarshal(raw, &set); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrBadJWKSFile, err)
+	}
+
+	keys := make([]jwk, 0, len(set.Keys))
+
+	for i, keyJSON := range set.Keys {
+		if keyJSON.Use != "" && keyJSON.Use != jwkUseSignature {
+			continue
+		}
+
+		key, err := parseJWK(keyJSON)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %d: %s", ErrBadJWKSFile, i, err)
+		}
+
+		if key == nil || (keyJSON.Alg != "" && keyJSON.Alg != key.alg) {
+			continue
+		}
+
+		keys = append(keys, *key)
+	}
+
+	return keys, nil
+}
+
+// parseJWK returns a nil key if its type or curve is not supported.
+func parseJWK(keyJSON jwkJSON) (*jwk, error) {
+	switch {
+	case keyJSON.Kty == jwkKeyTypeRSA:
+		key, err := parseRSAKey(keyJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		return &jwk{kid: keyJSON.Kid, alg: AlgRS256, key: key}, nil
+	case keyJSON.Kty == jwkKeyTypeEC && keyJSON.Crv == jwkCurveP256:
+		key, err := parseP256Key(keyJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		return &jwk{kid: keyJSON.Kid, alg: AlgES256, key: key}, nil
+	case keyJSON.Kty == jwkKeyTypeOKP && keyJSON.Crv == jwkCurveEd25519:
+		x, err := base64.RawURLEncoding.DecodeString(keyJSON.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("x parameter of Ed25519 key should have 32 bytes")
+		}
+
+		return &jwk{kid: keyJSON.Kid, alg: AlgEdDSA, key: ed25519.PublicKey(x)}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func parseRSAKey(keyJSON jwkJSON) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(keyJSON.N)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode RSA key modulus: %w", err)
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(keyJSON.E)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode RSA key exponent: %w", err)
+	}
+
+	modulus := new(big.Int).SetBytes(n)
+	if modulus.BitLen() < rsaMinModulusBits {
+		return nil, fmt.Errorf("RSA key should have at least %d bits modulus", rsaMinModulusBits)
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("RSA key exponent is not valid")
+	}
+
+	return &rsa.PublicKey{N: modulus, E: int(exponent.Int64())}, nil
+}
+
+func parseP256Key(keyJSON jwkJSON) (*ecdsa.PublicKey, error) {
+	x, err := base64.RawURLEncoding.DecodeString(keyJSON.X)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode EC key x coordinate: %w", err)
+	}
+
+	y, err := base64.RawURLEncoding.DecodeString(keyJSON.Y)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode EC key y coordinate: %w", err)
+	}
+
+	key := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}
+
+	if !key.Curve.IsOnCurve(key.X, key.Y) {
+		return nil, errors.New("EC key point is not on P-256 curve")
+	}
+
+	return key, nil
+}
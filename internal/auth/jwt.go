@@ -0,0 +1,241 @@
+package auth
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	// AlgRS256 represents RSASSA-PKCS1-v1_5 using SHA-256 JWT signature algorithm.
+	AlgRS256 = "RS256"
+
+	// AlgES256 represents ECDSA using P-256 and SHA-256 JWT signature algorithm.
+	AlgES256 = "ES256"
+
+	// AlgEdDSA represents Ed25519 JWT signature algorithm.
+	AlgEdDSA = "EdDSA"
+)
+
+const (
+	jwtParts       = 3
+	es256CoordSize = 32
+)
+
+var (
+	// ErrBadToken contains error message about bearer token that is not a well-formed JWT.
+	ErrBadToken = errors.New("bearer token is malformed")
+
+	// ErrUnsupportedAlg contains error message about JWT that is signed with an unsupported algorithm.
+	ErrUnsupportedAlg = errors.New("bearer token signature algorithm is not supported")
+
+	// ErrBadSignature contains error message about JWT signature that can't be verified by any known key.
+	ErrBadSignature = errors.New("bearer token signature is not valid")
+
+	// ErrTokenExpired contains error message about expired JWT or JWT without expiration.
+	ErrTokenExpired = errors.New("bearer token is expired")
+
+	// ErrTokenNotValidYet contains error message about JWT that is used before its nbf claim.
+	ErrTokenNotValidYet = errors.New("bearer token is not valid yet")
+
+	// ErrBadIssuer contains error message about JWT that is issued by an unexpected issuer.
+	ErrBadIssuer = errors.New("bearer token issuer is not valid")
+
+	// ErrBadAudience contains error message about JWT that is issued for another audience.
+	ErrBadAudience = errors.New("bearer token audience is not valid")
+)
+
+// JWTValidator validates JWT signatures against JWKS and checks registered claims.
+type JWTValidator struct {
+	jwks     *JWKS
+	issuer   string
+	audience string
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Subject   string      `json:"sub"`
+	Issuer    string      `json:"iss"`
+	Audience  jwtAudience `json:"aud"`
+	ExpiresAt *int64      `json:"exp"`
+	NotBefore *int64      `json:"nbf"`
+	Tenant    string      `json:"tenant"`
+	Scope     string      `json:"scope"`
+	Scp       []string    `json:"scp"`
+}
+
+// jwtAudience represents aud claim that can be either a string or an array of strings.
+type jwtAudience []string
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (a *jwtAudience) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		return json.Unmarshal(data, (*[]string)(a))
+	}
+
+	var aud string
+	if err := json.Unmarshal(data, &aud); err != nil {
+		return fmt.Errorf("unable to unmarshal aud claim: %w", err)
+	}
+
+	*a = jwtAudience{aud}
+
+	return nil
+}
+
+// NewJWTValidator returns a new JWTValidator.
+// Issuer and audience are checked only if they're not empty, leeway is added to exp and nbf checks.
+func NewJWTValidator(jwks *JWKS, issuer, audience string, leeway time.Duration) *JWTValidator {
+	return &JWTValidator{
+		jwks:     jwks,
+		issuer:   issuer,
+		audience: audience,
+		leeway:   leeway,
+		now:      time.Now,
+	}
+}
+
+// Validate verifies the compact serialized JWT and returns identity from its claims.
+func (v *JWTValidator) Validate(token string) (*Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != jwtParts {
+		return nil, ErrBadToken
+	}
+
+	header := jwtHeader{}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to decode signature", ErrBadToken)
+	}
+
+	if err := v.verifySignature(header, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	claims := jwtClaims{}
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, err
+	}
+
+	if err := v.checkClaims(claims); err != nil {
+		return nil, err
+	}
+
+	return &Identity{
+		Subject: claims.Subject,
+		Tenant:  claims.Tenant,
+		Scopes:  claimsScopes(claims),
+	}, nil
+}
+
+func (v *JWTValidator) verifySignature(header jwtHeader, signed, signature []byte) error {
+	switch header.Alg {
+	case AlgRS256, AlgES256, AlgEdDSA:
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedAlg, header.Alg)
+	}
+
+	digest := sha256.Sum256(signed)
+
+	for _, key := range v.jwks.lookup(header.Kid, header.Alg) {
+		if verify(key.key, signed, digest[:], signature) {
+			return nil
+		}
+	}
+
+	return ErrBadSignature
+}
+
+func verify(key crypto.PublicKey, signed, digest, signature []byte) bool {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature) == nil
+	case *ecdsa.PublicKey:
+		if len(signature) != 2*es256CoordSize {
+			return false
+		}
+
+		r := new(big.Int).SetBytes(signature[:es256CoordSize])
+		s := new(big.Int).SetBytes(signature[es256CoordSize:])
+
+		return ecdsa.Verify(key, digest, r, s)
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, signed, signature)
+	default:
+		return false
+	}
+}
+
+func (v *JWTValidator) checkClaims(claims jwtClaims) error {
+	now := v.now()
+
+	if claims.ExpiresAt == nil || !now.Before(time.Unix(*claims.ExpiresAt, 0).Add(v.leeway)) {
+		return ErrTokenExpired
+	}
+
+	if claims.NotBefore != nil && now.Add(v.leeway).Before(time.Unix(*claims.NotBefore, 0)) {
+		return ErrTokenNotValidYet
+	}
+
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return ErrBadIssuer
+	}
+
+	if v.audience != "" && !containsString(claims.Audience, v.audience) {
+		return ErrBadAudience
+	}
+
+	return nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return fmt.Errorf("%w: unable to decode base64: %s", ErrBadToken, err)
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("%w: unable to decode JSON: %s", ErrBadToken, err)
+	}
+
+	return nil
+}
+
+// claimsScopes returns scopes from the space delimited scope claim or from the scp claim array.
+func claimsScopes(claims jwtClaims) []string {
+	if claims.Scope != "" {
+		return strings.Fields(claims.Scope)
+	}
+
+	return claims.Scp
+}
+
+func containsString(vals []string, val string) bool {
+	for _, v := range vals {
+		if v == val {
+			return true
+		}
+	}
+
+	return false
+}
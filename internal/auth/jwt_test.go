@@ -0,0 +1,161 @@
+package auth_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+	"github.com/limpidchart/lc-api/internal/testutils"
+)
+
+func TestJWTValidator_Validate(t *testing.T) {
+	t.Parallel()
+
+	keys := testutils.NewJWTKeys(t)
+
+	jwks, err := auth.LoadJWKS(keys.JWKSFile(t))
+	if err != nil {
+		t.Fatalf("unable to load JWKS: %s", err)
+	}
+
+	validator := auth.NewJWTValidator(jwks, "https://auth.example.com", "lc-api", time.Second)
+	now := time.Now().Unix()
+
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		res := map[string]interface{}{
+			"sub":    "reporting",
+			"iss":    "https://auth.example.com",
+			"aud":    "lc-api",
+			"exp":    now + 60,
+			"tenant": "acme",
+			"scope":  "charts:read charts:write",
+		}
+
+		for k, v := range overrides {
+			if v == nil {
+				delete(res, k)
+
+				continue
+			}
+
+			res[k] = v
+		}
+
+		return res
+	}
+
+	expectedIdentity := &auth.Identity{
+		Subject: "reporting",
+		Tenant:  "acme",
+		Scopes:  []string{"charts:read", "charts:write"},
+	}
+
+	tamper := func(token string) string {
+		parts := strings.Split(token, ".")
+		parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin","exp":9999999999}`))
+
+		return strings.Join(parts, ".")
+	}
+
+	tt := []struct {
+		name             string
+		token            string
+		expectedIdentity *auth.Identity
+		expectedErr      error
+	}{
+		{"rs256", keys.Token(t, auth.AlgRS256, claims(nil)), expectedIdentity, nil},
+		{"es256", keys.Token(t, auth.AlgES256, claims(nil)), expectedIdentity, nil},
+		{"eddsa", keys.Token(t, auth.AlgEdDSA, claims(nil)), expectedIdentity, nil},
+		{
+			"aud_array_and_scp",
+			keys.Token(t, auth.AlgRS256, claims(map[string]interface{}{
+				"aud":   []string{"billing", "lc-api"},
+				"scope": nil,
+				"scp":   []string{"charts:read"},
+			})),
+			&auth.Identity{Subject: "reporting", Tenant: "acme", Scopes: []string{"charts:read"}},
+			nil,
+		},
+		{"expired", keys.Token(t, auth.AlgRS256, claims(map[string]interface{}{"exp": now - 60})), nil, auth.ErrTokenExpired},
+		{"no_exp", keys.Token(t, auth.AlgRS256, claims(map[string]interface{}{"exp": nil})), nil, auth.ErrTokenExpired},
+		{"not_valid_yet", keys.Token(t, auth.AlgRS256, claims(map[string]interface{}{"nbf": now + 60})), nil, auth.ErrTokenNotValidYet},
+		{"bad_issuer", keys.Token(t, auth.AlgES256, claims(map[string]interface{}{"iss": "https://evil.example.com"})), nil, auth.ErrBadIssuer},
+		{"bad_audience", keys.Token(t, auth.AlgEdDSA, claims(map[string]interface{}{"aud": "billing"})), nil, auth.ErrBadAudience},
+		{"tampered_claims", tamper(keys.Token(t, auth.AlgRS256, claims(nil))), nil, auth.ErrBadSignature},
+		{"signed_by_another_key", testutils.NewJWTKeys(t).Token(t, auth.AlgES256, claims(nil)), nil, auth.ErrBadSignature},
+		{
+			"alg_none",
+			strings.Join([]string{
+				base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)),
+				base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin","exp":9999999999}`)),
+				"",
+			}, "."),
+			nil,
+			auth.ErrUnsupportedAlg,
+		},
+		{"not_jwt", "reporting-key", nil, auth.ErrBadToken},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			identity, err := validator.Validate(tc.token)
+			assert.Equal(t, tc.expectedIdentity, identity)
+			assert.True(t, errors.Is(err, tc.expectedErr), err)
+		})
+	}
+}
+
+func TestJWKS_Reload(t *testing.T) {
+	t.Parallel()
+
+	oldKeys := testutils.NewJWTKeys(t)
+	newKeys := testutils.NewJWTKeys(t)
+	path := oldKeys.JWKSFile(t)
+
+	jwks, err := auth.LoadJWKS(path)
+	if err != nil {
+		t.Fatalf("unable to load JWKS: %s", err)
+	}
+
+	validator := auth.NewJWTValidator(jwks, "", "", 0)
+	claims := map[string]interface{}{"sub": "reporting", "exp": time.Now().Add(time.Minute).Unix()}
+
+	reloaded, err := jwks.Reload()
+	assert.NoError(t, err)
+	assert.False(t, reloaded)
+
+	newKeys.WriteJWKSFile(t, path)
+
+	// Keys of the same types have the same JWKS size, so make sure the modification time is changed.
+	modTime := time.Now().Add(time.Second)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("unable to change JWKS file modification time: %s", err)
+	}
+
+	reloaded, err = jwks.Reload()
+	assert.NoError(t, err)
+	assert.True(t, reloaded)
+
+	_, err = validator.Validate(oldKeys.Token(t, auth.AlgEdDSA, claims))
+	assert.True(t, errors.Is(err, auth.ErrBadSignature))
+
+	identity, err := validator.Validate(newKeys.Token(t, auth.AlgEdDSA, claims))
+	assert.NoError(t, err)
+	assert.Equal(t, "reporting", identity.Subject)
+}
+
+func TestLoadJWKS_BadFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := auth.LoadJWKS(testutils.APIKeysFile(t, map[string]string{"reporting": "reporting-key"}))
+	assert.True(t, errors.Is(err, auth.ErrBadJWKSFile))
+}
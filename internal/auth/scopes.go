@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// ScopeChartsCreate allows to create charts.
+	ScopeChartsCreate = "charts:create"
+
+	// ScopeChartsRead allows to get charts and their images by ID.
+	ScopeChartsRead = "charts:read"
+
+	// ScopeChartsList allows to list charts.
+	ScopeChartsList = "charts:list"
+
+	// ScopeChartsDelete allows to delete charts.
+	ScopeChartsDelete = "charts:delete"
+)
+
+// ReasonMissingScope is a machine-readable reason of the requests rejected because of the missing scope.
+const ReasonMissingScope = "MISSING_SCOPE"
+
+// ErrMissingScope contains error message about caller that is not allowed to perform the operation.
+var ErrMissingScope = errors.New("caller doesn't have the required scope")
+
+// AllScopes returns all known scopes.
+func AllScopes() []string {
+	return []string{ScopeChartsCreate, ScopeChartsRead, ScopeChartsList, ScopeChartsDelete}
+}
+
+// HasScope reports if the identity is granted the scope.
+func (i *Identity) HasScope(scope string) bool {
+	return containsString(i.Scopes, scope)
+}
+
+// Authorize checks that the identity is granted the scope.
+// Nil identity is allowed to do anything since it's only used when authentication is disabled.
+func Authorize(identity *Identity, scope string) error {
+	if identity == nil || identity.HasScope(scope) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrMissingScope, scope)
+}
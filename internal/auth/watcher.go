@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/limpidchart/lc-api/internal/config"
+)
+
+const jwksWatcherName = "JWKS watcher"
+
+// JWKSWatcher periodically re-reads the JWKS file of the authenticator.
+type JWKSWatcher struct {
+	log      *zerolog.Logger
+	jwks     *JWKS
+	interval time.Duration
+}
+
+// NewJWKSWatcher configures a new JWKSWatcher.
+func NewJWKSWatcher(log *zerolog.Logger, authenticator *Authenticator, authCfg config.AuthConfig) *JWKSWatcher {
+	return &JWKSWatcher{
+		log:      log,
+		jwks:     authenticator.JWKS(),
+		interval: time.Duration(authCfg.JWKSReloadIntervalSeconds) * time.Second,
+	}
+}
+
+// Serve reloads JWKS until the provided context is done.
+// It only waits for the context if bearer tokens are not accepted or the reload interval is not positive.
+func (w *JWKSWatcher) Serve(ctx context.Context) error {
+	if w.jwks == nil || w.interval <= 0 {
+		<-ctx.Done()
+
+		return nil
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info().
+				Time(zerolog.TimestampFieldName, time.Now().UTC()).
+				Msg("Stopping JWKS watcher")
+
+			return nil
+		case <-ticker.C:
+			w.reload()
+		}
+	}
+}
+
+// Address returns an empty string since JWKSWatcher doesn't listen on any address.
+func (w *JWKSWatcher) Address() string {
+	return ""
+}
+
+// Name returns watcher name.
+func (w *JWKSWatcher) Name() string {
+	return jwksWatcherName
+}
+
+func (w *JWKSWatcher) reload() {
+	reloaded, err := w.jwks.Reload()
+	if err != nil {
+		w.log.Error().Time(zerolog.TimestampFieldName, time.Now().UTC()).Err(err).Msg("Unable to reload JWKS")
+
+		return
+	}
+
+	if reloaded {
+		w.log.Info().Time(zerolog.TimestampFieldName, time.Now().UTC()).Msg("Reloaded JWKS")
+	}
+}
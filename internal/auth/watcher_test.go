@@ -0,0 +1,55 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/testutils"
+)
+
+func TestJWKSWatcher_ReloadDisabled(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		interval int
+	}{
+		{"zero_interval", 0},
+		{"negative_interval", -1},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			authCfg := config.AuthConfig{
+				JWKSPath:                  testutils.NewJWTKeys(t).JWKSFile(t),
+				JWKSReloadIntervalSeconds: tc.interval,
+			}
+
+			authenticator, err := auth.NewAuthenticator(authCfg)
+			if err != nil {
+				t.Fatalf("unable to configure authenticator: %s", err)
+			}
+
+			log := zerolog.Nop()
+			watcher := auth.NewJWKSWatcher(&log, authenticator, authCfg)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			served := make(chan error)
+
+			go func() {
+				served <- watcher.Serve(ctx)
+			}()
+
+			cancel()
+			assert.NoError(t, <-served)
+		})
+	}
+}
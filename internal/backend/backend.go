@@ -5,60 +5,263 @@ import (
 	"fmt"
 	"time"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/connectivity"
-
+	"github.com/limpidchart/lc-api/internal/audit"
+	"github.com/limpidchart/lc-api/internal/auth"
+	"github.com/limpidchart/lc-api/internal/clientip"
 	"github.com/limpidchart/lc-api/internal/config"
-	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/cost"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/ratelimit"
+	"github.com/limpidchart/lc-api/internal/rendercache"
 	"github.com/limpidchart/lc-api/internal/renderer"
+	"github.com/limpidchart/lc-api/internal/signedurl"
+	"github.com/limpidchart/lc-api/internal/storage"
+	"github.com/limpidchart/lc-api/internal/tenant"
+	"github.com/limpidchart/lc-api/internal/tlsutils"
+	"github.com/limpidchart/lc-api/internal/webhook"
 )
 
 // ConnSupervisor represents an entity that contains all needed backend connections,
 // can report their health at can close them.
 type ConnSupervisor interface {
 	Shutdown()
-	RendererClient() render.ChartRendererClient
-	RendererRequestTimeout() time.Duration
-	IsHealthy() bool
+	Renderers() *renderer.Pools
+	IsHealthy() renderer.Health
+	Storage() storage.Storage
+	ChartTTL() time.Duration
+	RenderCache() *rendercache.Cache
+	RenderCoalescer() *renderer.Coalescer
+	RenderQueue() *renderer.Queue
+	RenderBatcher() *renderer.Batcher
+	WebhookQueue() *webhook.Queue
+	Authenticator() *auth.Authenticator
+	Tenants() *tenant.Registry
+	RateLimiter() *ratelimit.Limiter
+	Costs() *cost.Accountant
+	ServerCertificates() *tlsutils.Certificates
+	RendererCertificates() *tlsutils.Certificates
+	SignedURLs() *signedurl.Signer
+	ClientIPs() *clientip.Resolver
+	Audit() *audit.Logger
 }
 
 // Backend contains all backend connections needed for lc-api.
 type Backend struct {
-	rendererConn       *grpc.ClientConn
-	rendererClient     render.ChartRendererClient
-	rendererReqTimeout time.Duration
+	renderers       *renderer.Pools
+	storage         storage.Storage
+	chartTTL        time.Duration
+	renderCache     *rendercache.Cache
+	renderCoalescer *renderer.Coalescer
+	renderQueue     *renderer.Queue
+	renderBatcher   *renderer.Batcher
+	webhookQueue    *webhook.Queue
+	authenticator   *auth.Authenticator
+	tenants         *tenant.Registry
+	rateLimiter     *ratelimit.Limiter
+	costs           *cost.Accountant
+	serverCerts     *tlsutils.Certificates
+	rendererCerts   *tlsutils.Certificates
+	signedURLs      *signedurl.Signer
+	clientIPs       *clientip.Resolver
+	audit           *audit.Logger
 }
 
 // NewBackend configures a new Backend.
-func NewBackend(ctx context.Context, rendererCfg config.RendererConfig) (*Backend, error) {
-	rendererConn, err := renderer.NewConn(ctx, rendererCfg)
+func NewBackend(ctx context.Context, cfg config.Config, pRec metric.PromRecorder) (*Backend, error) {
+	authenticator, err := auth.NewAuthenticator(cfg.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure authentication: %w", err)
+	}
+
+	tenants, err := tenant.NewRegistry(cfg.Tenant)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure tenants: %w", err)
+	}
+
+	rateLimiter, err := ratelimit.NewLimiter(cfg.RateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure rate limiting: %w", err)
+	}
+
+	signedURLs, err := signedurl.NewSigner(cfg.SignedURL)
 	if err != nil {
+		return nil, fmt.Errorf("unable to configure signed URLs: %w", err)
+	}
+
+	clientIPs, err := clientip.NewResolver(cfg.ClientIP)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure client IPs: %w", err)
+	}
+
+	serverCerts, err := loadServerCertificates(cfg.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure servers TLS: %w", err)
+	}
+
+	rendererCerts, err := loadRendererCertificates(cfg.Renderer)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure lc-renderer TLS: %w", err)
+	}
+
+	chartStorage, err := storage.New(cfg.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure charts storage: %w", err)
+	}
+
+	auditLog, err := audit.NewLoggerFromConfig(cfg.Audit)
+	if err != nil {
+		chartStorage.Close()
+
+		return nil, fmt.Errorf("unable to configure audit log: %w", err)
+	}
+
+	renderers, err := renderer.NewPools(ctx, cfg.Renderer, rendererCerts, pRec)
+	if err != nil {
+		chartStorage.Close()
+		auditLog.Close()
+
 		return nil, fmt.Errorf("unable to connect to lc-renderer: %w", err)
 	}
 
 	return &Backend{
-		rendererConn:       rendererConn,
-		rendererClient:     render.NewChartRendererClient(rendererConn),
-		rendererReqTimeout: time.Duration(rendererCfg.RequestTimeoutSeconds) * time.Second,
+		renderers:       renderers,
+		storage:         chartStorage,
+		chartTTL:        time.Duration(cfg.Storage.ChartTTLSeconds) * time.Second,
+		renderCache:     rendercache.New(cfg.RenderCache, pRec),
+		renderCoalescer: renderer.NewCoalescer(),
+		renderQueue:     renderer.NewQueue(cfg.RenderQueue),
+		renderBatcher:   renderer.NewBatcher(cfg.Batch),
+		webhookQueue:    webhook.NewQueue(cfg.Webhook, pRec),
+		authenticator:   authenticator,
+		tenants:         tenants,
+		rateLimiter:     rateLimiter,
+		costs:           cost.NewAccountant(cfg.Cost, pRec),
+		serverCerts:     serverCerts,
+		rendererCerts:   rendererCerts,
+		signedURLs:      signedURLs,
+		clientIPs:       clientIPs,
+		audit:           auditLog,
 	}, nil
 }
 
 // Shutdown closes all backend connections.
 func (b *Backend) Shutdown() {
-	b.rendererConn.Close()
+	b.renderers.Close()
+	b.storage.Close()
+	b.audit.Close()
+}
+
+// Renderers returns configured lc-renderer pools.
+func (b *Backend) Renderers() *renderer.Pools {
+	return b.renderers
+}
+
+// IsHealthy checks all lc-renderer pools and reports their health.
+// Backend is healthy if at least one pool is healthy.
+func (b *Backend) IsHealthy() renderer.Health {
+	return b.renderers.Health()
 }
 
-// RendererClient returns configured render.ChartRendererClient.
-func (b *Backend) RendererClient() render.ChartRendererClient {
-	return b.rendererClient
+// Storage returns configured charts storage.
+func (b *Backend) Storage() storage.Storage {
+	return b.storage
 }
 
-// RendererRequestTimeout returns configured timeout for renderer requests.
-func (b *Backend) RendererRequestTimeout() time.Duration {
-	return b.rendererReqTimeout
+// ChartTTL returns configured default chart time to live.
+func (b *Backend) ChartTTL() time.Duration {
+	return b.chartTTL
 }
 
-// IsHealthy checks all backend connection and reports if Backend is healthy.
-func (b *Backend) IsHealthy() bool {
-	return b.rendererConn.GetState() == connectivity.Ready || b.rendererConn.GetState() == connectivity.Idle
+// RenderCache returns configured render result cache.
+func (b *Backend) RenderCache() *rendercache.Cache {
+	return b.renderCache
+}
+
+// RenderCoalescer returns configured coalescer of concurrent identical render requests.
+func (b *Backend) RenderCoalescer() *renderer.Coalescer {
+	return b.renderCoalescer
+}
+
+// RenderQueue returns configured queue of asynchronous render jobs.
+func (b *Backend) RenderQueue() *renderer.Queue {
+	return b.renderQueue
+}
+
+// RenderBatcher returns configured batch charts creator.
+func (b *Backend) RenderBatcher() *renderer.Batcher {
+	return b.renderBatcher
+}
+
+// WebhookQueue returns configured queue of chart callbacks deliveries.
+func (b *Backend) WebhookQueue() *webhook.Queue {
+	return b.webhookQueue
+}
+
+// Authenticator returns configured requests authenticator.
+func (b *Backend) Authenticator() *auth.Authenticator {
+	return b.authenticator
+}
+
+// Tenants returns configured tenants registry.
+func (b *Backend) Tenants() *tenant.Registry {
+	return b.tenants
+}
+
+// RateLimiter returns configured requests rate limiter.
+func (b *Backend) RateLimiter() *ratelimit.Limiter {
+	return b.rateLimiter
+}
+
+// Costs returns configured render cost accountant.
+func (b *Backend) Costs() *cost.Accountant {
+	return b.costs
+}
+
+// ServerCertificates returns configured certificates of the gRPC, gRPC health check and HTTP servers.
+// It returns nil if servers don't use TLS.
+func (b *Backend) ServerCertificates() *tlsutils.Certificates {
+	return b.serverCerts
+}
+
+// RendererCertificates returns configured certificates of the lc-renderer connection.
+// It returns nil if lc-renderer connection doesn't use TLS.
+func (b *Backend) RendererCertificates() *tlsutils.Certificates {
+	return b.rendererCerts
+}
+
+// SignedURLs returns configured signer of the chart image URLs.
+func (b *Backend) SignedURLs() *signedurl.Signer {
+	return b.signedURLs
+}
+
+// ClientIPs returns configured resolver of the requests client IPs.
+func (b *Backend) ClientIPs() *clientip.Resolver {
+	return b.clientIPs
+}
+
+// Audit returns configured audit log of chart operations.
+func (b *Backend) Audit() *audit.Logger {
+	return b.audit
+}
+
+func loadServerCertificates(tlsCfg config.TLSConfig) (*tlsutils.Certificates, error) {
+	if tlsCfg.CertPath == "" && tlsCfg.KeyPath == "" {
+		if tlsCfg.ClientCAPath != "" {
+			return nil, fmt.Errorf("%w: client CA bundle requires server certificate", tlsutils.ErrBadCertificates)
+		}
+
+		return nil, nil
+	}
+
+	// nolint: wrapcheck
+	return tlsutils.LoadCertificates(tlsCfg.CertPath, tlsCfg.KeyPath, tlsCfg.ClientCAPath)
+}
+
+func loadRendererCertificates(rendererCfg config.RendererConfig) (*tlsutils.Certificates, error) {
+	if !rendererCfg.TLS {
+		return nil, nil
+	}
+
+	// nolint: wrapcheck
+	return tlsutils.LoadCertificates(rendererCfg.TLSCertPath, rendererCfg.TLSKeyPath, rendererCfg.TLSCAPath)
 }
@@ -2,6 +2,7 @@ package backend_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -9,7 +10,10 @@ import (
 
 	"github.com/limpidchart/lc-api/internal/backend"
 	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/renderer"
 	"github.com/limpidchart/lc-api/internal/testutils"
+	"github.com/limpidchart/lc-api/internal/tlsutils"
 )
 
 func TestBackend(t *testing.T) {
@@ -41,9 +45,28 @@ func TestBackend(t *testing.T) {
 		RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
 	}
 
-	b, err := backend.NewBackend(context.Background(), rendererCfg)
+	b, err := backend.NewBackend(context.Background(), config.Config{
+		Renderer:  rendererCfg,
+		Storage:   config.StorageConfig{Kind: config.StorageKindMemory},
+		RateLimit: config.RateLimitConfig{Key: config.RateLimitKeyIP},
+	}, metric.NewEmptyRecorder())
 	assert.NoError(t, err)
-	assert.NotEmpty(t, b.RendererClient())
-	assert.True(t, b.IsHealthy())
-	assert.Equal(t, rendererCfg.RequestTimeoutSeconds, int(b.RendererRequestTimeout().Seconds()))
+	assert.NotEmpty(t, b.Renderers().Pool(renderer.DefaultPool).Client())
+	assert.Equal(t, renderer.Health{renderer.DefaultPool: true}, b.IsHealthy())
+	assert.Equal(t, rendererCfg.RequestTimeoutSeconds, int(b.Renderers().Pool(renderer.DefaultPool).RequestTimeout().Seconds()))
+}
+
+func TestBackend_TLSErr(t *testing.T) {
+	t.Parallel()
+
+	ca := testutils.NewTestingCA(t, "lc")
+
+	b, err := backend.NewBackend(context.Background(), config.Config{
+		Storage:   config.StorageConfig{Kind: config.StorageKindMemory},
+		RateLimit: config.RateLimitConfig{Key: config.RateLimitKeyIP},
+		TLS:       config.TLSConfig{ClientCAPath: ca.CertPath},
+	}, metric.NewEmptyRecorder())
+	assert.Nil(t, b)
+	assert.True(t, errors.Is(err, tlsutils.ErrBadCertificates))
+	assert.EqualError(t, err, "unable to configure servers TLS: bad TLS certificates: client CA bundle requires server certificate")
 }
@@ -3,29 +3,130 @@ package backend
 import (
 	"time"
 
-	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/audit"
+	"github.com/limpidchart/lc-api/internal/auth"
+	"github.com/limpidchart/lc-api/internal/clientip"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/cost"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/ratelimit"
+	"github.com/limpidchart/lc-api/internal/rendercache"
+	"github.com/limpidchart/lc-api/internal/renderer"
+	"github.com/limpidchart/lc-api/internal/signedurl"
+	"github.com/limpidchart/lc-api/internal/storage"
+	"github.com/limpidchart/lc-api/internal/tenant"
+	"github.com/limpidchart/lc-api/internal/tlsutils"
+	"github.com/limpidchart/lc-api/internal/webhook"
 )
 
 // EmptyBackend represents a backend.Backend that doesn't have real connections.
 type EmptyBackend struct {
-	healthy bool
+	healthy         bool
+	storage         *storage.Memory
+	renderCache     *rendercache.Cache
+	renderCoalescer *renderer.Coalescer
+	renderQueue     *renderer.Queue
+	renderBatcher   *renderer.Batcher
+	webhookQueue    *webhook.Queue
+	authenticator   *auth.Authenticator
+	tenants         *tenant.Registry
+	rateLimiter     *ratelimit.Limiter
+	costs           *cost.Accountant
+	signedURLs      *signedurl.Signer
+	clientIPs       *clientip.Resolver
+	audit           *audit.Logger
 }
 
 // NewEmptyBackend returns a new EmptyBackend.
 func NewEmptyBackend(healthy bool) *EmptyBackend {
-	return &EmptyBackend{healthy}
+	return &EmptyBackend{
+		healthy:         healthy,
+		storage:         storage.NewMemory(),
+		renderCache:     rendercache.New(config.RenderCacheConfig{Size: 0, TTLSeconds: 0}, metric.NewEmptyRecorder()),
+		renderCoalescer: renderer.NewCoalescer(),
+		renderQueue:     renderer.NewQueue(config.RenderQueueConfig{Workers: 0, Size: 0}),
+		renderBatcher:   renderer.NewBatcher(config.BatchConfig{Parallelism: 1, MaxSize: 1}),
+		webhookQueue:    webhook.NewQueue(config.WebhookConfig{}, metric.NewEmptyRecorder()),
+		authenticator:   &auth.Authenticator{},
+		tenants:         &tenant.Registry{},
+		rateLimiter:     &ratelimit.Limiter{},
+		costs:           cost.NewAccountant(config.CostConfig{}, metric.NewEmptyRecorder()),
+		signedURLs:      &signedurl.Signer{},
+		clientIPs:       &clientip.Resolver{},
+		audit:           audit.NewLogger(audit.NopSink{}),
+	}
 }
 
 func (b *EmptyBackend) Shutdown() {}
 
-func (b *EmptyBackend) RendererClient() render.ChartRendererClient {
+func (b *EmptyBackend) Renderers() *renderer.Pools {
 	return nil
 }
 
-func (b *EmptyBackend) RendererRequestTimeout() time.Duration {
-	return time.Second
+func (b *EmptyBackend) IsHealthy() renderer.Health {
+	return renderer.Health{renderer.DefaultPool: b.healthy}
 }
 
-func (b *EmptyBackend) IsHealthy() bool {
-	return b.healthy
+func (b *EmptyBackend) Storage() storage.Storage {
+	return b.storage
+}
+
+func (b *EmptyBackend) ChartTTL() time.Duration {
+	return 0
+}
+
+func (b *EmptyBackend) RenderCache() *rendercache.Cache {
+	return b.renderCache
+}
+
+func (b *EmptyBackend) RenderCoalescer() *renderer.Coalescer {
+	return b.renderCoalescer
+}
+
+func (b *EmptyBackend) RenderQueue() *renderer.Queue {
+	return b.renderQueue
+}
+
+func (b *EmptyBackend) RenderBatcher() *renderer.Batcher {
+	return b.renderBatcher
+}
+
+func (b *EmptyBackend) WebhookQueue() *webhook.Queue {
+	return b.webhookQueue
+}
+
+func (b *EmptyBackend) Authenticator() *auth.Authenticator {
+	return b.authenticator
+}
+
+func (b *EmptyBackend) Tenants() *tenant.Registry {
+	return b.tenants
+}
+
+func (b *EmptyBackend) RateLimiter() *ratelimit.Limiter {
+	return b.rateLimiter
+}
+
+func (b *EmptyBackend) Costs() *cost.Accountant {
+	return b.costs
+}
+
+func (b *EmptyBackend) ServerCertificates() *tlsutils.Certificates {
+	return nil
+}
+
+func (b *EmptyBackend) RendererCertificates() *tlsutils.Certificates {
+	return nil
+}
+
+func (b *EmptyBackend) SignedURLs() *signedurl.Signer {
+	return b.signedURLs
+}
+
+func (b *EmptyBackend) ClientIPs() *clientip.Resolver {
+	return b.clientIPs
+}
+
+func (b *EmptyBackend) Audit() *audit.Logger {
+	return b.audit
 }
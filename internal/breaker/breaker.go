@@ -0,0 +1,257 @@
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/limpidchart/lc-api/internal/config"
+)
+
+// State represents a circuit breaker state, its value is exported by the renderer_breaker_state metric.
+type State int
+
+const (
+	// StateClosed represents a breaker that lets all requests through.
+	StateClosed State = iota
+
+	// StateHalfOpen represents a breaker that lets a limited number of trial requests through.
+	StateHalfOpen
+
+	// StateOpen represents a breaker that rejects all requests.
+	StateOpen
+)
+
+const maxPercent = 100
+
+var (
+	// ErrBadConfig contains error message about circuit breaker configuration that can't be used.
+	ErrBadConfig = errors.New("bad circuit breaker configuration")
+
+	// ErrOpen contains error message about request that is rejected by the open circuit breaker.
+	ErrOpen = errors.New("circuit breaker is open")
+)
+
+// String returns state name.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// Breaker trips once the share of failed or slow requests of a counting window reaches the configured thresholds.
+// Open breaker rejects requests until the open timeout passes, then it lets the trial requests through.
+// Breaker is closed once all trial requests succeed and opened again once any of them fails or is slow.
+type Breaker struct {
+	window           time.Duration
+	minRequests      int
+	errorPercent     int
+	slowCall         time.Duration
+	slowPercent      int
+	openTimeout      time.Duration
+	halfOpenRequests int
+	onStateChange    func(State)
+
+	mu          sync.Mutex
+	state       State
+	generation  uint64
+	windowStart time.Time
+	openedAt    time.Time
+	requests    int
+	failures    int
+	slow        int
+	trials      int
+	successes   int
+}
+
+// NewBreaker configures a new closed Breaker from the breaker fields of the renderer configuration.
+// Breaker never trips if both error and slow call percents are zero.
+// onStateChange is called with every new state while the breaker is locked, so it must not call the breaker.
+func NewBreaker(rendererCfg config.RendererConfig, onStateChange func(State)) (*Breaker, error) {
+	switch {
+	case rendererCfg.BreakerErrorPercent < 0 || rendererCfg.BreakerErrorPercent > maxPercent:
+		return nil, fmt.Errorf("%w: error percent should be between 0 and %d", ErrBadConfig, maxPercent)
+	case rendererCfg.BreakerSlowPercent < 0 || rendererCfg.BreakerSlowPercent > maxPercent:
+		return nil, fmt.Errorf("%w: slow call percent should be between 0 and %d", ErrBadConfig, maxPercent)
+	case rendererCfg.BreakerErrorPercent == 0 && rendererCfg.BreakerSlowPercent == 0:
+		// Disabled breaker doesn't need the rest of its configuration.
+	case rendererCfg.BreakerWindowSeconds <= 0:
+		return nil, fmt.Errorf("%w: window should be positive", ErrBadConfig)
+	case rendererCfg.BreakerMinRequests < 0:
+		return nil, fmt.Errorf("%w: min requests should not be negative", ErrBadConfig)
+	case rendererCfg.BreakerSlowPercent > 0 && rendererCfg.BreakerSlowCallMilliseconds <= 0:
+		return nil, fmt.Errorf("%w: slow call duration should be positive", ErrBadConfig)
+	case rendererCfg.BreakerOpenTimeoutSeconds <= 0:
+		return nil, fmt.Errorf("%w: open timeout should be positive", ErrBadConfig)
+	case rendererCfg.BreakerHalfOpenRequests <= 0:
+		return nil, fmt.Errorf("%w: half-open requests should be positive", ErrBadConfig)
+	}
+
+	if onStateChange == nil {
+		onStateChange = func(State) {}
+	}
+
+	b := &Breaker{
+		window:           time.Duration(rendererCfg.BreakerWindowSeconds) * time.Second,
+		minRequests:      rendererCfg.BreakerMinRequests,
+		errorPercent:     rendererCfg.BreakerErrorPercent,
+		slowCall:         time.Duration(rendererCfg.BreakerSlowCallMilliseconds) * time.Millisecond,
+		slowPercent:      rendererCfg.BreakerSlowPercent,
+		openTimeout:      time.Duration(rendererCfg.BreakerOpenTimeoutSeconds) * time.Second,
+		halfOpenRequests: rendererCfg.BreakerHalfOpenRequests,
+		onStateChange:    onStateChange,
+		windowStart:      time.Now(),
+	}
+
+	onStateChange(StateClosed)
+
+	return b, nil
+}
+
+// State returns the current breaker state.
+// Open breaker becomes half-open once its open timeout passes.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.currentState(time.Now())
+}
+
+// Allow reports if a request can be sent and returns the breaker generation that should be passed to Record.
+// It returns ErrOpen if the breaker is open or all trial requests of the half-open breaker are sent.
+func (b *Breaker) Allow() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.currentState(time.Now()) {
+	case StateOpen:
+		return b.generation, ErrOpen
+	case StateHalfOpen:
+		if b.trials+b.successes >= b.halfOpenRequests {
+			return b.generation, ErrOpen
+		}
+
+		b.trials++
+	case StateClosed:
+	}
+
+	return b.generation, nil
+}
+
+// Record counts the result of the request that is allowed in the provided generation.
+// Requests that are allowed before the latest state change and cancelled requests are not counted.
+func (b *Breaker) Record(generation uint64, err error, latency time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	state := b.currentState(now)
+
+	if generation != b.generation {
+		return
+	}
+
+	cancelled := status.Code(err) == codes.Canceled
+	failed := !cancelled && isFailure(err)
+	slow := !cancelled && b.slowPercent > 0 && latency >= b.slowCall
+
+	switch state {
+	case StateHalfOpen:
+		b.trials--
+
+		switch {
+		case failed || slow:
+			b.setState(StateOpen, now)
+		case !cancelled:
+			if b.successes++; b.successes >= b.halfOpenRequests {
+				b.setState(StateClosed, now)
+			}
+		}
+	case StateClosed:
+		if cancelled {
+			return
+		}
+
+		if now.Sub(b.windowStart) >= b.window {
+			b.resetCounts(now)
+		}
+
+		b.requests++
+
+		if failed {
+			b.failures++
+		}
+
+		if slow {
+			b.slow++
+		}
+
+		if b.shouldTrip() {
+			b.setState(StateOpen, now)
+		}
+	case StateOpen:
+	}
+}
+
+// currentState moves the open breaker into the half-open state once its open timeout passes.
+func (b *Breaker) currentState(now time.Time) State {
+	if b.state == StateOpen && now.Sub(b.openedAt) >= b.openTimeout {
+		b.setState(StateHalfOpen, now)
+	}
+
+	return b.state
+}
+
+func (b *Breaker) shouldTrip() bool {
+	if b.requests < b.minRequests {
+		return false
+	}
+
+	if b.errorPercent > 0 && b.failures*maxPercent >= b.errorPercent*b.requests {
+		return true
+	}
+
+	return b.slowPercent > 0 && b.slow*maxPercent >= b.slowPercent*b.requests
+}
+
+func (b *Breaker) setState(state State, now time.Time) {
+	b.state = state
+	b.generation++
+	b.trials = 0
+	b.successes = 0
+	b.resetCounts(now)
+
+	if state == StateOpen {
+		b.openedAt = now
+	}
+
+	b.onStateChange(state)
+}
+
+func (b *Breaker) resetCounts(now time.Time) {
+	b.windowStart = now
+	b.requests = 0
+	b.failures = 0
+	b.slow = 0
+}
+
+// isFailure reports if the error means that lc-renderer is degraded, rejected requests are not failures.
+func isFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Unknown, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
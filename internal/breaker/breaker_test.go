@@ -0,0 +1,304 @@
+package breaker_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/limpidchart/lc-api/internal/breaker"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+var errUnavailable = status.Error(codes.Unavailable, "connection reset by peer")
+
+type stateRecorder struct {
+	mu     sync.Mutex
+	states []breaker.State
+}
+
+func (r *stateRecorder) record(state breaker.State) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.states = append(r.states, state)
+}
+
+func (r *stateRecorder) recorded() []breaker.State {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]breaker.State(nil), r.states...)
+}
+
+func newTestingBreaker(t *testing.T, rendererCfg config.RendererConfig) (*breaker.Breaker, *stateRecorder) {
+	t.Helper()
+
+	states := &stateRecorder{}
+
+	b, err := breaker.NewBreaker(rendererCfg, states.record)
+	if err != nil {
+		t.Fatalf("unable to configure circuit breaker: %s", err)
+	}
+
+	return b, states
+}
+
+func breakerConfig() config.RendererConfig {
+	return config.RendererConfig{
+		BreakerWindowSeconds:        60,
+		BreakerMinRequests:          4,
+		BreakerErrorPercent:         50,
+		BreakerSlowCallMilliseconds: 100,
+		BreakerSlowPercent:          50,
+		BreakerOpenTimeoutSeconds:   1,
+		BreakerHalfOpenRequests:     2,
+	}
+}
+
+func record(t *testing.T, b *breaker.Breaker, err error, latency time.Duration) {
+	t.Helper()
+
+	generation, allowErr := b.Allow()
+	if allowErr != nil {
+		t.Fatalf("request is not allowed: %s", allowErr)
+	}
+
+	b.Record(generation, err, latency)
+}
+
+func TestBreaker_Trip(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		errs      []error
+		latencies []time.Duration
+		expected  breaker.State
+	}{
+		{
+			name:      "errors",
+			errs:      []error{nil, errUnavailable, nil, errUnavailable},
+			latencies: []time.Duration{0, 0, 0, 0},
+			expected:  breaker.StateOpen,
+		},
+		{
+			name:      "slow_calls",
+			errs:      []error{nil, nil, nil, nil},
+			latencies: []time.Duration{0, time.Second, 0, time.Second},
+			expected:  breaker.StateOpen,
+		},
+		{
+			name:      "below_min_requests",
+			errs:      []error{errUnavailable, errUnavailable, errUnavailable},
+			latencies: []time.Duration{0, 0, 0},
+			expected:  breaker.StateClosed,
+		},
+		{
+			name:      "below_thresholds",
+			errs:      []error{nil, errUnavailable, nil, nil, nil},
+			latencies: []time.Duration{0, 0, time.Second, 0, 0},
+			expected:  breaker.StateClosed,
+		},
+		{
+			name: "not_failures",
+			errs: []error{
+				status.Error(codes.InvalidArgument, "bad chart"),
+				status.Error(codes.InvalidArgument, "bad chart"),
+				status.Error(codes.Canceled, context.Canceled.Error()),
+				status.Error(codes.Canceled, context.Canceled.Error()),
+				nil,
+			},
+			latencies: []time.Duration{0, 0, 0, 0, 0},
+			expected:  breaker.StateClosed,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, _ := newTestingBreaker(t, breakerConfig())
+
+			for i, err := range tc.errs {
+				record(t, b, err, tc.latencies[i])
+			}
+
+			assert.Equal(t, tc.expected, b.State())
+		})
+	}
+}
+
+func TestBreaker_HalfOpen(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name           string
+		trialErr       error
+		expectedStates []breaker.State
+	}{
+		{
+			name:     "trials_succeed",
+			trialErr: nil,
+			expectedStates: []breaker.State{
+				breaker.StateClosed, breaker.StateOpen, breaker.StateHalfOpen, breaker.StateClosed,
+			},
+		},
+		{
+			name:     "trial_fails",
+			trialErr: errUnavailable,
+			expectedStates: []breaker.State{
+				breaker.StateClosed, breaker.StateOpen, breaker.StateHalfOpen, breaker.StateOpen,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, states := newTestingBreaker(t, breakerConfig())
+
+			// Request that is allowed before the breaker is opened is not counted.
+			staleGeneration, err := b.Allow()
+			assert.NoError(t, err)
+
+			for i := 0; i < 4; i++ {
+				record(t, b, errUnavailable, 0)
+			}
+
+			_, err = b.Allow()
+			assert.True(t, errors.Is(err, breaker.ErrOpen))
+
+			time.Sleep(time.Second)
+
+			assert.Equal(t, breaker.StateHalfOpen, b.State())
+
+			firstTrial, err := b.Allow()
+			assert.NoError(t, err)
+
+			secondTrial, err := b.Allow()
+			assert.NoError(t, err)
+
+			// Only the configured number of trial requests is allowed.
+			_, err = b.Allow()
+			assert.True(t, errors.Is(err, breaker.ErrOpen))
+
+			b.Record(staleGeneration, errUnavailable, 0)
+			assert.Equal(t, breaker.StateHalfOpen, b.State())
+
+			b.Record(firstTrial, nil, 0)
+			b.Record(secondTrial, tc.trialErr, 0)
+
+			assert.Equal(t, tc.expectedStates, states.recorded())
+		})
+	}
+}
+
+func TestNewBreaker_BadConfig(t *testing.T) {
+	t.Parallel()
+
+	withCfg := func(change func(rendererCfg *config.RendererConfig)) config.RendererConfig {
+		rendererCfg := breakerConfig()
+		change(&rendererCfg)
+
+		return rendererCfg
+	}
+
+	tt := []struct {
+		name        string
+		rendererCfg config.RendererConfig
+		expected    string
+	}{
+		{
+			"error_percent_too_big",
+			withCfg(func(c *config.RendererConfig) { c.BreakerErrorPercent = 101 }),
+			"bad circuit breaker configuration: error percent should be between 0 and 100",
+		},
+		{
+			"negative_slow_percent",
+			withCfg(func(c *config.RendererConfig) { c.BreakerSlowPercent = -1 }),
+			"bad circuit breaker configuration: slow call percent should be between 0 and 100",
+		},
+		{
+			"zero_window",
+			withCfg(func(c *config.RendererConfig) { c.BreakerWindowSeconds = 0 }),
+			"bad circuit breaker configuration: window should be positive",
+		},
+		{
+			"negative_min_requests",
+			withCfg(func(c *config.RendererConfig) { c.BreakerMinRequests = -1 }),
+			"bad circuit breaker configuration: min requests should not be negative",
+		},
+		{
+			"zero_slow_call",
+			withCfg(func(c *config.RendererConfig) { c.BreakerSlowCallMilliseconds = 0 }),
+			"bad circuit breaker configuration: slow call duration should be positive",
+		},
+		{
+			"zero_open_timeout",
+			withCfg(func(c *config.RendererConfig) { c.BreakerOpenTimeoutSeconds = 0 }),
+			"bad circuit breaker configuration: open timeout should be positive",
+		},
+		{
+			"zero_half_open_requests",
+			withCfg(func(c *config.RendererConfig) { c.BreakerHalfOpenRequests = 0 }),
+			"bad circuit breaker configuration: half-open requests should be positive",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := breaker.NewBreaker(tc.rendererCfg, nil)
+			assert.Nil(t, b)
+			assert.True(t, errors.Is(err, breaker.ErrBadConfig))
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+type failingRendererClient struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (c *failingRendererClient) RenderChart(context.Context, *render.RenderChartRequest, ...grpc.CallOption) (*render.RenderChartReply, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls++
+
+	return nil, errUnavailable
+}
+
+func TestClient_RenderChart(t *testing.T) {
+	t.Parallel()
+
+	b, _ := newTestingBreaker(t, breakerConfig())
+	rendererClient := &failingRendererClient{}
+	client := breaker.NewClient(rendererClient, b)
+
+	for i := 0; i < 4; i++ {
+		_, err := client.RenderChart(context.Background(), &render.RenderChartRequest{})
+		assert.Equal(t, errUnavailable, err)
+		assert.False(t, breaker.IsOpenErr(err))
+	}
+
+	// Open breaker fails fast without calling lc-renderer.
+	_, err := client.RenderChart(context.Background(), &render.RenderChartRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.True(t, breaker.IsOpenErr(err))
+	assert.Equal(t, 4, rendererClient.calls)
+}
@@ -0,0 +1,52 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+// errOpenStatus is returned by Client instead of ErrOpen, so it's handled like other lc-renderer gRPC errors.
+// nolint: gochecknoglobals
+var errOpenStatus = status.Error(codes.Unavailable, ErrOpen.Error())
+
+// Client implements render.ChartRendererClient and guards the wrapped client with a Breaker.
+type Client struct {
+	client  render.ChartRendererClient
+	breaker *Breaker
+}
+
+// NewClient returns a new Client.
+func NewClient(client render.ChartRendererClient, breaker *Breaker) *Client {
+	return &Client{
+		client:  client,
+		breaker: breaker,
+	}
+}
+
+// RenderChart implements render.ChartRendererClient.RenderChart.
+// It fails fast with codes.Unavailable status while the breaker is open.
+func (c *Client) RenderChart(ctx context.Context, in *render.RenderChartRequest, opts ...grpc.CallOption) (*render.RenderChartReply, error) {
+	generation, err := c.breaker.Allow()
+	if err != nil {
+		return nil, errOpenStatus
+	}
+
+	start := time.Now()
+	reply, err := c.client.RenderChart(ctx, in, opts...)
+	c.breaker.Record(generation, err, time.Since(start))
+
+	// nolint: wrapcheck
+	return reply, err
+}
+
+// IsOpenErr reports if the request is rejected by the open breaker.
+func IsOpenErr(err error) bool {
+	return errors.Is(err, errOpenStatus)
+}
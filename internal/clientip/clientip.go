@@ -0,0 +1,179 @@
+package clientip
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/limpidchart/lc-api/internal/config"
+)
+
+const (
+	cidrsSeparator = ","
+	bitsInByte     = 8
+)
+
+var (
+	// ErrBadConfig contains error message about client IP configuration that can't be used.
+	ErrBadConfig = errors.New("bad client IP configuration")
+
+	// ErrIPDenied contains error message about client IP that is not allowed to use the API.
+	ErrIPDenied = errors.New("client IP is not allowed")
+)
+
+// Resolver resolves client IPs of the requests and checks them against the allow and deny lists.
+// Forwarded headers are honored only from the trusted proxies.
+type Resolver struct {
+	trustedProxies []*net.IPNet
+	allow          []*net.IPNet
+	deny           []*net.IPNet
+}
+
+// NewResolver configures a new Resolver.
+// Trusted proxies, allow and deny lists are provided as comma separated CIDRs or single IPs.
+func NewResolver(clientIPCfg config.ClientIPConfig) (*Resolver, error) {
+	trustedProxies, err := ParseCIDRs(clientIPCfg.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("%w: trusted proxies: %s", ErrBadConfig, err)
+	}
+
+	allow, err := ParseCIDRs(clientIPCfg.Allow)
+	if err != nil {
+		return nil, fmt.Errorf("%w: allow list: %s", ErrBadConfig, err)
+	}
+
+	deny, err := ParseCIDRs(clientIPCfg.Deny)
+	if err != nil {
+		return nil, fmt.Errorf("%w: deny list: %s", ErrBadConfig, err)
+	}
+
+	return &Resolver{
+		trustedProxies: trustedProxies,
+		allow:          allow,
+		deny:           deny,
+	}, nil
+}
+
+// Resolve returns client IP of the request from the peer address and the forwarded headers.
+// Headers are used only if the peer is a trusted proxy, X-Forwarded-For is walked from the nearest hop
+// and the first IP that is not a trusted proxy is the client IP. X-Real-IP is used if X-Forwarded-For is empty.
+func (r *Resolver) Resolve(peerAddr, xRealIP, xForwardedFor string) string {
+	clientIP := hostIP(peerAddr)
+
+	if !r.isTrustedProxy(clientIP) {
+		return clientIP
+	}
+
+	if strings.TrimSpace(xForwardedFor) == "" {
+		if ip := net.ParseIP(strings.TrimSpace(xRealIP)); ip != nil {
+			return ip.String()
+		}
+
+		return clientIP
+	}
+
+	hops := strings.Split(xForwardedFor, ",")
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			// Proxies don't add bad values, so the rest of the header is set by the client.
+			return clientIP
+		}
+
+		clientIP = ip.String()
+
+		if !r.isTrustedProxy(clientIP) {
+			return clientIP
+		}
+	}
+
+	return clientIP
+}
+
+// Check checks that the client IP is not in the deny list and is in the allow list if it's configured.
+func (r *Resolver) Check(clientIP string) error {
+	ip := net.ParseIP(clientIP)
+
+	if containsIP(r.deny, ip) {
+		return fmt.Errorf("%w: %s is denied", ErrIPDenied, clientIP)
+	}
+
+	if len(r.allow) > 0 && !containsIP(r.allow, ip) {
+		return fmt.Errorf("%w: %s is not in the allow list", ErrIPDenied, clientIP)
+	}
+
+	return nil
+}
+
+// IsTrustedProxy reports if the peer address belongs to a trusted proxy.
+func (r *Resolver) IsTrustedProxy(peerAddr string) bool {
+	return r.isTrustedProxy(hostIP(peerAddr))
+}
+
+func (r *Resolver) isTrustedProxy(clientIP string) bool {
+	return containsIP(r.trustedProxies, net.ParseIP(clientIP))
+}
+
+// hostIP returns host of the address without port.
+func hostIP(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	return host
+}
+
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, ipNet := range nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseCIDRs parses comma separated CIDRs or single IPs.
+func ParseCIDRs(rawCIDRs string) ([]*net.IPNet, error) {
+	nets := []*net.IPNet{}
+
+	if strings.TrimSpace(rawCIDRs) == "" {
+		return nets, nil
+	}
+
+	for _, rawCIDR := range strings.Split(rawCIDRs, cidrsSeparator) {
+		rawCIDR = strings.TrimSpace(rawCIDR)
+
+		if !strings.Contains(rawCIDR, "/") {
+			ip := net.ParseIP(rawCIDR)
+			if ip == nil {
+				return nil, fmt.Errorf("unable to parse %q as IP or CIDR", rawCIDR)
+			}
+
+			bits := bitsInByte * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, bitsInByte*net.IPv4len
+			}
+
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(rawCIDR)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %q as IP or CIDR", rawCIDR)
+		}
+
+		nets = append(nets, ipNet)
+	}
+
+	return nets, nil
+}
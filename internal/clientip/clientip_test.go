@@ -0,0 +1,216 @@
+package clientip_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/clientip"
+	"github.com/limpidchart/lc-api/internal/config"
+)
+
+func newResolver(t *testing.T, clientIPCfg config.ClientIPConfig) *clientip.Resolver {
+	t.Helper()
+
+	resolver, err := clientip.NewResolver(clientIPCfg)
+	if err != nil {
+		t.Fatalf("unable to configure client IP resolver: %s", err)
+	}
+
+	return resolver
+}
+
+func TestNewResolver_Err(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name        string
+		clientIPCfg config.ClientIPConfig
+		expected    string
+	}{
+		{
+			"bad_trusted_proxy",
+			config.ClientIPConfig{TrustedProxies: "10.0.0.0/8,proxy"},
+			`bad client IP configuration: trusted proxies: unable to parse "proxy" as IP or CIDR`,
+		},
+		{
+			"bad_allow_cidr",
+			config.ClientIPConfig{Allow: "10.0.0.0/33"},
+			`bad client IP configuration: allow list: unable to parse "10.0.0.0/33" as IP or CIDR`,
+		},
+		{
+			"bad_deny_ip",
+			config.ClientIPConfig{Deny: "10.0.0.256"},
+			`bad client IP configuration: deny list: unable to parse "10.0.0.256" as IP or CIDR`,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			resolver, err := clientip.NewResolver(tc.clientIPCfg)
+			assert.Nil(t, resolver)
+			assert.True(t, errors.Is(err, clientip.ErrBadConfig))
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	t.Parallel()
+
+	resolver := newResolver(t, config.ClientIPConfig{TrustedProxies: "10.0.0.0/8, fd00::/8"})
+
+	tt := []struct {
+		name          string
+		peerAddr      string
+		xRealIP       string
+		xForwardedFor string
+		expected      string
+	}{
+		{
+			"no_headers",
+			"192.0.2.1:50000",
+			"",
+			"",
+			"192.0.2.1",
+		},
+		{
+			"untrusted_peer_headers",
+			"192.0.2.1:50000",
+			"198.51.100.1",
+			"198.51.100.2",
+			"192.0.2.1",
+		},
+		{
+			"trusted_peer_real_ip",
+			"10.0.0.1:50000",
+			"198.51.100.1",
+			"",
+			"198.51.100.1",
+		},
+		{
+			"trusted_peer_forwarded_for",
+			"10.0.0.1:50000",
+			"198.51.100.1",
+			"198.51.100.2",
+			"198.51.100.2",
+		},
+		{
+			"spoofed_forwarded_for",
+			"10.0.0.1:50000",
+			"",
+			"203.0.113.1, 198.51.100.2, 10.0.0.2",
+			"198.51.100.2",
+		},
+		{
+			"bad_forwarded_for",
+			"10.0.0.1:50000",
+			"",
+			"unknown, 10.0.0.2",
+			"10.0.0.2",
+		},
+		{
+			"only_trusted_proxies",
+			"10.0.0.1:50000",
+			"",
+			"10.0.0.3, 10.0.0.2",
+			"10.0.0.3",
+		},
+		{
+			"ipv6",
+			"[fd00::1]:50000",
+			"",
+			"2001:db8::1",
+			"2001:db8::1",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, resolver.Resolve(tc.peerAddr, tc.xRealIP, tc.xForwardedFor))
+		})
+	}
+}
+
+func TestResolver_Check(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name        string
+		clientIPCfg config.ClientIPConfig
+		clientIP    string
+		expected    string
+	}{
+		{
+			"no_lists",
+			config.ClientIPConfig{},
+			"192.0.2.1",
+			"",
+		},
+		{
+			"allowed",
+			config.ClientIPConfig{Allow: "192.0.2.0/24"},
+			"192.0.2.1",
+			"",
+		},
+		{
+			"not_allowed",
+			config.ClientIPConfig{Allow: "192.0.2.0/24"},
+			"198.51.100.1",
+			"client IP is not allowed: 198.51.100.1 is not in the allow list",
+		},
+		{
+			"denied",
+			config.ClientIPConfig{Deny: "198.51.100.1"},
+			"198.51.100.1",
+			"client IP is not allowed: 198.51.100.1 is denied",
+		},
+		{
+			"deny_overrides_allow",
+			config.ClientIPConfig{Allow: "192.0.2.0/24", Deny: "192.0.2.128/25"},
+			"192.0.2.200",
+			"client IP is not allowed: 192.0.2.200 is denied",
+		},
+		{
+			"unknown_ip_with_allow_list",
+			config.ClientIPConfig{Allow: "192.0.2.0/24"},
+			"unknown",
+			"client IP is not allowed: unknown is not in the allow list",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := newResolver(t, tc.clientIPCfg).Check(tc.clientIP)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.True(t, errors.Is(err, clientip.ErrIPDenied))
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestResolver_IsTrustedProxy(t *testing.T) {
+	t.Parallel()
+
+	resolver := newResolver(t, config.ClientIPConfig{TrustedProxies: "10.0.0.0/8"})
+
+	assert.True(t, resolver.IsTrustedProxy("10.0.0.1:1234"))
+	assert.True(t, resolver.IsTrustedProxy("10.0.0.1"))
+	assert.False(t, resolver.IsTrustedProxy("192.0.2.1:1234"))
+	assert.False(t, resolver.IsTrustedProxy(""))
+}
@@ -0,0 +1,265 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/limpidchart/lc-api/internal/breaker"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+)
+
+const maxPercent = 100
+
+var (
+	// ErrBadConfig contains error message about concurrency limit configuration that can't be used.
+	ErrBadConfig = errors.New("bad concurrency limit configuration")
+
+	// ErrLimitExceeded contains error message about request that is shed by the concurrency limit.
+	ErrLimitExceeded = errors.New("lc-renderer concurrency limit is exceeded")
+)
+
+// LimitError represents a request that is shed by the concurrency limit.
+// It wraps ErrLimitExceeded and contains the time after which the request can be retried.
+type LimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LimitError) Error() string {
+	return ErrLimitExceeded.Error()
+}
+
+// RetryAfterSeconds rounds RetryAfter up to whole seconds.
+func (e *LimitError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+// Unwrap returns ErrLimitExceeded.
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// Limiter limits the number of concurrent lc-renderer requests with an adaptive AIMD limit.
+// Limit grows by one after a limit worth of successful requests that are faster than the latency threshold
+// and is multiplied by the backoff ratio after every slow or overloaded request.
+// Requests over the limit wait in a short bounded queue and are shed once it's full or their wait times out.
+type Limiter struct {
+	enabled          bool
+	minLimit         float64
+	maxLimit         float64
+	latencyThreshold time.Duration
+	backoffRatio     float64
+	queueSize        int
+	queueTimeout     time.Duration
+	retryAfter       time.Duration
+
+	limitGauge    prometheus.Gauge
+	inFlightGauge prometheus.Gauge
+	queuedGauge   prometheus.Gauge
+
+	mu       sync.Mutex
+	limit    float64
+	inFlight int
+	queue    []chan struct{}
+}
+
+// NewLimiter configures a new Limiter of the lc-renderer pool.
+// Limiter is disabled if the initial limit is zero.
+func NewLimiter(rendererCfg config.RendererConfig, pRec metric.PromRecorder, pool string) (*Limiter, error) {
+	if rendererCfg.LimitInitial == 0 {
+		return &Limiter{}, nil
+	}
+
+	switch {
+	case rendererCfg.LimitMin <= 0:
+		return nil, fmt.Errorf("%w: min limit should be positive", ErrBadConfig)
+	case rendererCfg.LimitInitial < rendererCfg.LimitMin || rendererCfg.LimitInitial > rendererCfg.LimitMax:
+		return nil, fmt.Errorf("%w: initial limit should be between min and max limits", ErrBadConfig)
+	case rendererCfg.LimitLatencyMilliseconds <= 0:
+		return nil, fmt.Errorf("%w: latency threshold should be positive", ErrBadConfig)
+	case rendererCfg.LimitBackoffPercent <= 0 || rendererCfg.LimitBackoffPercent >= maxPercent:
+		return nil, fmt.Errorf("%w: backoff percent should be between 1 and %d", ErrBadConfig, maxPercent-1)
+	case rendererCfg.LimitQueueSize < 0 || rendererCfg.LimitQueueTimeoutMilliseconds < 0:
+		return nil, fmt.Errorf("%w: queue size and timeout should not be negative", ErrBadConfig)
+	case rendererCfg.LimitRetryAfterSeconds <= 0:
+		return nil, fmt.Errorf("%w: retry after should be positive", ErrBadConfig)
+	}
+
+	l := &Limiter{
+		enabled:          true,
+		minLimit:         float64(rendererCfg.LimitMin),
+		maxLimit:         float64(rendererCfg.LimitMax),
+		latencyThreshold: time.Duration(rendererCfg.LimitLatencyMilliseconds) * time.Millisecond,
+		backoffRatio:     float64(rendererCfg.LimitBackoffPercent) / maxPercent,
+		queueSize:        rendererCfg.LimitQueueSize,
+		queueTimeout:     time.Duration(rendererCfg.LimitQueueTimeoutMilliseconds) * time.Millisecond,
+		retryAfter:       time.Duration(rendererCfg.LimitRetryAfterSeconds) * time.Second,
+		limitGauge:       pRec.RendererConcurrencyLimit().WithLabelValues(pool),
+		inFlightGauge:    pRec.RendererInFlight().WithLabelValues(pool),
+		queuedGauge:      pRec.RendererQueued().WithLabelValues(pool),
+		limit:            float64(rendererCfg.LimitInitial),
+	}
+
+	l.updateGauges()
+
+	return l, nil
+}
+
+// Limit returns the current concurrency limit, it's zero if the limiter is disabled.
+func (l *Limiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return int(l.limit)
+}
+
+// Acquire takes a slot for a request, it waits in the queue if all slots are taken.
+// It returns *LimitError if the queue is full or the wait times out and gRPC status error like the ones
+// returned by the gRPC client if the context is done. Every acquired slot should be released by Release.
+func (l *Limiter) Acquire(ctx context.Context) error {
+	if !l.enabled {
+		return nil
+	}
+
+	l.mu.Lock()
+
+	if l.inFlight < int(l.limit) && len(l.queue) == 0 {
+		l.inFlight++
+		l.updateGauges()
+		l.mu.Unlock()
+
+		return nil
+	}
+
+	if len(l.queue) >= l.queueSize {
+		l.mu.Unlock()
+
+		return &LimitError{RetryAfter: l.retryAfter}
+	}
+
+	// Slot is handed over to the waiter by closing its channel.
+	ready := make(chan struct{})
+	l.queue = append(l.queue, ready)
+	l.updateGauges()
+	l.mu.Unlock()
+
+	waitTimer := time.NewTimer(l.queueTimeout)
+	defer waitTimer.Stop()
+
+	select {
+	case <-ready:
+		return nil
+	case <-waitTimer.C:
+		if l.leaveQueue(ready) {
+			return &LimitError{RetryAfter: l.retryAfter}
+		}
+
+		return nil
+	case <-ctx.Done():
+		if !l.leaveQueue(ready) {
+			l.release()
+		}
+
+		return contextErr(ctx)
+	}
+}
+
+// Release frees the slot of the finished request and adapts the limit to its result.
+// Requests that are slower than the latency threshold or failed because lc-renderer is overloaded decrease the limit,
+// successful requests increase it while the limit is utilized, other failed requests don't change it.
+// Cancelled requests don't change the limit however slow they are: losing hedged requests and requests of the callers
+// that went away are cancelled after they have been running for a while.
+// Requests rejected by the open circuit breaker don't reach lc-renderer, so they don't change the limit either.
+func (l *Limiter) Release(latency time.Duration, err error) {
+	if !l.enabled {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch {
+	case breaker.IsOpenErr(err), isCanceledErr(err):
+	case latency > l.latencyThreshold || isOverloadErr(err):
+		l.limit = math.Max(l.minLimit, l.limit*l.backoffRatio)
+	case err == nil && float64(l.inFlight)*2 >= l.limit:
+		l.limit = math.Min(l.maxLimit, l.limit+1/l.limit)
+	}
+
+	l.inFlight--
+	l.dequeue()
+	l.updateGauges()
+}
+
+// release frees the slot without changing the limit.
+func (l *Limiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+	l.dequeue()
+	l.updateGauges()
+}
+
+// dequeue hands over free slots to the queued requests in their arrival order.
+func (l *Limiter) dequeue() {
+	for len(l.queue) > 0 && l.inFlight < int(l.limit) {
+		close(l.queue[0])
+		l.queue = l.queue[1:]
+		l.inFlight++
+	}
+}
+
+// leaveQueue removes the waiter from the queue.
+// It returns false if the waiter is already handed over a slot.
+func (l *Limiter) leaveQueue(ready chan struct{}) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, waiter := range l.queue {
+		if waiter == ready {
+			l.queue = append(l.queue[:i], l.queue[i+1:]...)
+			l.updateGauges()
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l *Limiter) updateGauges() {
+	l.limitGauge.Set(float64(int(l.limit)))
+	l.inFlightGauge.Set(float64(l.inFlight))
+	l.queuedGauge.Set(float64(len(l.queue)))
+}
+
+func isOverloadErr(err error) bool {
+	switch status.Code(err) {
+	case codes.DeadlineExceeded, codes.ResourceExhausted, codes.Unavailable:
+		return true
+	default:
+		return false
+	}
+}
+
+func isCanceledErr(err error) bool {
+	return status.Code(err) == codes.Canceled || errors.Is(err, context.Canceled)
+}
+
+// contextErr converts the context error into gRPC status error like the ones returned by the gRPC client.
+func contextErr(ctx context.Context) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, context.DeadlineExceeded.Error())
+	}
+
+	return status.Error(codes.Canceled, context.Canceled.Error())
+}
@@ -0,0 +1,327 @@
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/limpidchart/lc-api/internal/breaker"
+	"github.com/limpidchart/lc-api/internal/concurrency"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+const testingPool = "default"
+
+func limiterConfig() config.RendererConfig {
+	return config.RendererConfig{
+		LimitInitial:                  2,
+		LimitMin:                      1,
+		LimitMax:                      4,
+		LimitLatencyMilliseconds:      100,
+		LimitBackoffPercent:           50,
+		LimitQueueSize:                1,
+		LimitQueueTimeoutMilliseconds: 100,
+		LimitRetryAfterSeconds:        1,
+	}
+}
+
+func newTestingLimiter(t *testing.T, rendererCfg config.RendererConfig) (*concurrency.Limiter, *metric.EmptyRecorder) {
+	t.Helper()
+
+	pRec := metric.NewEmptyRecorder()
+
+	l, err := concurrency.NewLimiter(rendererCfg, pRec, testingPool)
+	if err != nil {
+		t.Fatalf("unable to configure concurrency limiter: %s", err)
+	}
+
+	return l, pRec
+}
+
+func acquire(t *testing.T, l *concurrency.Limiter) {
+	t.Helper()
+
+	if err := l.Acquire(context.Background()); err != nil {
+		t.Fatalf("slot is not acquired: %s", err)
+	}
+}
+
+func TestLimiter_Shed(t *testing.T) {
+	t.Parallel()
+
+	l, pRec := newTestingLimiter(t, limiterConfig())
+
+	acquire(t, l)
+	acquire(t, l)
+
+	queued := make(chan error, 1)
+
+	go func() {
+		queued <- l.Acquire(context.Background())
+	}()
+
+	for testutil.ToFloat64(pRec.RendererQueued().WithLabelValues(testingPool)) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Request is shed right away once the queue is full.
+	err := l.Acquire(context.Background())
+
+	var limitErr *concurrency.LimitError
+	if assert.True(t, errors.As(err, &limitErr)) {
+		assert.Equal(t, time.Second, limitErr.RetryAfter)
+		assert.Equal(t, 1, limitErr.RetryAfterSeconds())
+	}
+
+	assert.True(t, errors.Is(err, concurrency.ErrLimitExceeded))
+
+	// Queued request is shed once its wait times out.
+	assert.True(t, errors.Is(<-queued, concurrency.ErrLimitExceeded))
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(pRec.RendererConcurrencyLimit().WithLabelValues(testingPool)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(pRec.RendererInFlight().WithLabelValues(testingPool)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(pRec.RendererQueued().WithLabelValues(testingPool)))
+}
+
+func TestLimiter_Queue(t *testing.T) {
+	t.Parallel()
+
+	rendererCfg := limiterConfig()
+	rendererCfg.LimitQueueTimeoutMilliseconds = 5000
+
+	l, pRec := newTestingLimiter(t, rendererCfg)
+
+	acquire(t, l)
+	acquire(t, l)
+
+	queued := make(chan error, 1)
+
+	go func() {
+		queued <- l.Acquire(context.Background())
+	}()
+
+	for testutil.ToFloat64(pRec.RendererQueued().WithLabelValues(testingPool)) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Released slot is handed over to the queued request.
+	l.Release(time.Millisecond, nil)
+
+	assert.NoError(t, <-queued)
+	assert.Equal(t, float64(2), testutil.ToFloat64(pRec.RendererInFlight().WithLabelValues(testingPool)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(pRec.RendererQueued().WithLabelValues(testingPool)))
+}
+
+func TestLimiter_QueueCancel(t *testing.T) {
+	t.Parallel()
+
+	rendererCfg := limiterConfig()
+	rendererCfg.LimitQueueTimeoutMilliseconds = 5000
+
+	l, pRec := newTestingLimiter(t, rendererCfg)
+
+	acquire(t, l)
+	acquire(t, l)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queued := make(chan error, 1)
+
+	go func() {
+		queued <- l.Acquire(ctx)
+	}()
+
+	for testutil.ToFloat64(pRec.RendererQueued().WithLabelValues(testingPool)) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+
+	assert.Equal(t, codes.Canceled, status.Code(<-queued))
+	assert.Equal(t, float64(2), testutil.ToFloat64(pRec.RendererInFlight().WithLabelValues(testingPool)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(pRec.RendererQueued().WithLabelValues(testingPool)))
+}
+
+// openBreakerErr returns the error of the request rejected by the open circuit breaker.
+func openBreakerErr(t *testing.T) error {
+	t.Helper()
+
+	b, err := breaker.NewBreaker(config.RendererConfig{
+		BreakerWindowSeconds:      60,
+		BreakerMinRequests:        1,
+		BreakerErrorPercent:       50,
+		BreakerOpenTimeoutSeconds: 60,
+		BreakerHalfOpenRequests:   1,
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to configure circuit breaker: %s", err)
+	}
+
+	generation, _ := b.Allow()
+	b.Record(generation, status.Error(codes.Unavailable, "lc-renderer is down"), time.Millisecond)
+
+	_, err = breaker.NewClient(nil, b).RenderChart(context.Background(), &render.RenderChartRequest{})
+	if !breaker.IsOpenErr(err) {
+		t.Fatalf("circuit breaker is not open: %s", err)
+	}
+
+	return err
+}
+
+func TestLimiter_Adapt(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		latency  time.Duration
+		err      error
+		expected int
+	}{
+		{
+			name:     "increased",
+			latency:  time.Millisecond,
+			err:      nil,
+			expected: 3,
+		},
+		{
+			name:     "slow",
+			latency:  time.Second,
+			err:      nil,
+			expected: 1,
+		},
+		{
+			name:     "overloaded",
+			latency:  time.Millisecond,
+			err:      status.Error(codes.ResourceExhausted, "too many renders"),
+			expected: 1,
+		},
+		{
+			name:     "not_overloaded",
+			latency:  time.Millisecond,
+			err:      status.Error(codes.InvalidArgument, "bad chart"),
+			expected: 2,
+		},
+		{
+			name:     "slow_cancelled",
+			latency:  time.Second,
+			err:      status.Error(codes.Canceled, context.Canceled.Error()),
+			expected: 2,
+		},
+		{
+			name:     "slow_context_cancelled",
+			latency:  time.Second,
+			err:      fmt.Errorf("unable to render chart: %w", context.Canceled),
+			expected: 2,
+		},
+		{
+			name:     "breaker_open",
+			latency:  time.Millisecond,
+			err:      openBreakerErr(t),
+			expected: 2,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rendererCfg := limiterConfig()
+			rendererCfg.LimitQueueSize = 0
+
+			l, pRec := newTestingLimiter(t, rendererCfg)
+
+			acquire(t, l)
+			acquire(t, l)
+
+			// Every released slot is taken again if the limit allows it, so the limit stays utilized.
+			for i := 0; i < 3; i++ {
+				l.Release(tc.latency, tc.err)
+				_ = l.Acquire(context.Background())
+			}
+
+			assert.Equal(t, tc.expected, l.Limit())
+			assert.Equal(t, float64(tc.expected), testutil.ToFloat64(pRec.RendererConcurrencyLimit().WithLabelValues(testingPool)))
+		})
+	}
+}
+
+func TestLimiter_Disabled(t *testing.T) {
+	t.Parallel()
+
+	l, _ := newTestingLimiter(t, config.RendererConfig{})
+
+	for i := 0; i < 100; i++ {
+		acquire(t, l)
+	}
+
+	assert.Equal(t, 0, l.Limit())
+}
+
+func TestNewLimiter_BadConfig(t *testing.T) {
+	t.Parallel()
+
+	withCfg := func(change func(rendererCfg *config.RendererConfig)) config.RendererConfig {
+		rendererCfg := limiterConfig()
+		change(&rendererCfg)
+
+		return rendererCfg
+	}
+
+	tt := []struct {
+		name        string
+		rendererCfg config.RendererConfig
+		expected    string
+	}{
+		{
+			"zero_min",
+			withCfg(func(c *config.RendererConfig) { c.LimitMin = 0 }),
+			"bad concurrency limit configuration: min limit should be positive",
+		},
+		{
+			"initial_over_max",
+			withCfg(func(c *config.RendererConfig) { c.LimitInitial = 5 }),
+			"bad concurrency limit configuration: initial limit should be between min and max limits",
+		},
+		{
+			"zero_latency",
+			withCfg(func(c *config.RendererConfig) { c.LimitLatencyMilliseconds = 0 }),
+			"bad concurrency limit configuration: latency threshold should be positive",
+		},
+		{
+			"backoff_percent_too_big",
+			withCfg(func(c *config.RendererConfig) { c.LimitBackoffPercent = 100 }),
+			"bad concurrency limit configuration: backoff percent should be between 1 and 99",
+		},
+		{
+			"negative_queue_size",
+			withCfg(func(c *config.RendererConfig) { c.LimitQueueSize = -1 }),
+			"bad concurrency limit configuration: queue size and timeout should not be negative",
+		},
+		{
+			"zero_retry_after",
+			withCfg(func(c *config.RendererConfig) { c.LimitRetryAfterSeconds = 0 }),
+			"bad concurrency limit configuration: retry after should be positive",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l, err := concurrency.NewLimiter(tc.rendererCfg, metric.NewEmptyRecorder(), testingPool)
+			assert.Nil(t, l)
+			assert.True(t, errors.Is(err, concurrency.ErrBadConfig))
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
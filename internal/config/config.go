@@ -6,9 +6,43 @@ import (
 )
 
 const (
-	lcRendererAddressDefault         = "dns:///localhost:54020"
-	lcRendererConnTimeoutSecsDefault = 5
-	lcRendererReqTimeoutSecsDefault  = 30
+	lcRendererAddressDefault                 = "dns:///localhost:54020"
+	lcRendererConnTimeoutSecsDefault         = 5
+	lcRendererReqTimeoutSecsDefault          = 30
+	lcRendererTLSDefault                     = false
+	lcRendererTLSCAPathDefault               = ""
+	lcRendererTLSCertPathDefault             = ""
+	lcRendererTLSKeyPathDefault              = ""
+	lcRendererTLSServerNameDefault           = ""
+	lcRendererPoolsPathDefault               = ""
+	lcRendererRetryMaxAttemptsDefault        = 3
+	lcRendererRetryInitialBackoffMsDefault   = 50
+	lcRendererRetryMaxBackoffMsDefault       = 1000
+	lcRendererRetryCodesDefault              = "UNAVAILABLE"
+	lcRendererRetryBudgetPercentDefault      = 20
+	lcRendererRetryBudgetBurstDefault        = 10
+	lcRendererHedgePercentileDefault         = 0
+	lcRendererBreakerWindowSecsDefault       = 10
+	lcRendererBreakerMinRequestsDefault      = 20
+	lcRendererBreakerErrorPercentDefault     = 50
+	lcRendererBreakerSlowCallMsDefault       = 10000
+	lcRendererBreakerSlowPercentDefault      = 50
+	lcRendererBreakerOpenTimeoutSecsDefault  = 30
+	lcRendererBreakerHalfOpenRequestsDefault = 3
+	lcRendererProbeIntervalSecsDefault       = 5
+	lcRendererProbeTimeoutSecsDefault        = 2
+	lcRendererProbeKindDefault               = RendererProbeRender
+	lcRendererProbeHealthServiceDefault      = ""
+	lcRendererProbeHealthyThresholdDefault   = 2
+	lcRendererProbeUnhealthyThresholdDefault = 3
+	lcRendererLimitInitialDefault            = 20
+	lcRendererLimitMinDefault                = 2
+	lcRendererLimitMaxDefault                = 200
+	lcRendererLimitLatencyMsDefault          = 2000
+	lcRendererLimitBackoffPercentDefault     = 90
+	lcRendererLimitQueueSizeDefault          = 50
+	lcRendererLimitQueueTimeoutMsDefault     = 500
+	lcRendererLimitRetryAfterSecsDefault     = 1
 
 	gRPCAddressDefault             = "0.0.0.0:54010"
 	gRPCShutdownTimeoutSecsDefault = 5
@@ -26,12 +60,108 @@ const (
 	metricsReadTimeoutSecsDefault     = 5
 	metricsWriteTimeoutSecsDefault    = 10
 	metricsIdleTimeoutSecsDefault     = 120
+
+	renderCacheSizeDefault    = 1000
+	renderCacheTTLSecsDefault = 3600
+
+	renderQueueWorkersDefault = 4
+	renderQueueSizeDefault    = 100
+
+	batchParallelismDefault = 8
+	batchMaxSizeDefault     = 100
+
+	webhookSecretDefault             = ""
+	webhookWorkersDefault            = 4
+	webhookQueueSizeDefault          = 100
+	webhookMaxAttemptsDefault        = 5
+	webhookInitialBackoffSecsDefault = 1
+	webhookMaxBackoffSecsDefault     = 60
+	webhookRequestTimeoutSecsDefault = 10
+	webhookDeadLetterPathDefault     = "./webhooks-dead-letter.ndjson"
+	webhookDenyDefault               = "0.0.0.0/8,10.0.0.0/8,100.64.0.0/10,127.0.0.0/8,169.254.0.0/16,172.16.0.0/12,192.168.0.0/16,::/128,::1/128,fc00::/7,fe80::/10"
+
+	authAPIKeysPathDefault            = ""
+	authJWKSPathDefault               = ""
+	authJWKSReloadIntervalSecsDefault = 10
+	authJWTIssuerDefault              = ""
+	authJWTAudienceDefault            = ""
+	authJWTLeewaySecsDefault          = 30
+
+	tenantTrustedHeaderDefault = ""
+	tenantOverridesPathDefault = ""
+
+	rateLimitKeyDefault        = RateLimitKeyIP
+	rateLimitPerMinuteDefault  = 0
+	rateLimitBurstDefault      = 10
+	rateLimitOperationsDefault = ""
+
+	costMaxRequestDefault       = 0
+	costTenantBudgetDefault     = 0
+	costBudgetWindowSecsDefault = 3600
+
+	tlsCertPathDefault           = ""
+	tlsKeyPathDefault            = ""
+	tlsClientCAPathDefault       = ""
+	tlsReloadIntervalSecsDefault = 10
+
+	signedURLKeysDefault       = ""
+	signedURLTTLSecsDefault    = 86400
+	signedURLMaxTTLSecsDefault = 604800
+	signedURLBaseDefault       = ""
+
+	clientIPTrustedProxiesDefault = ""
+	clientIPAllowDefault          = ""
+	clientIPDenyDefault           = ""
+
+	auditLogPathDefault       = ""
+	auditLogMaxSizeMBDefault  = 100
+	auditLogMaxBackupsDefault = 10
+
+	storageKindDefault                 = StorageKindMemory
+	storageDirDefault                  = "./charts"
+	storagePurgeGracePeriodSecsDefault = 86400
+	storagePurgeIntervalSecsDefault    = 60
+	storageChartTTLSecsDefault         = 2592000
 )
 
 const (
-	lcRendererAddressEnv         = "LC_API_RENDERER_ADDRESS"
-	lcRendererConnTimeoutSecsEnv = "LC_API_RENDERER_CONN_TIMEOUT"
-	lcRendererReqTimeoutSecsEnv  = "LC_API_RENDERER_REQUEST_TIMEOUT"
+	lcRendererAddressEnv                 = "LC_API_RENDERER_ADDRESS"
+	lcRendererConnTimeoutSecsEnv         = "LC_API_RENDERER_CONN_TIMEOUT"
+	lcRendererReqTimeoutSecsEnv          = "LC_API_RENDERER_REQUEST_TIMEOUT"
+	lcRendererTLSEnv                     = "LC_API_RENDERER_TLS"
+	lcRendererTLSCAPathEnv               = "LC_API_RENDERER_TLS_CA_PATH"
+	lcRendererTLSCertPathEnv             = "LC_API_RENDERER_TLS_CERT_PATH"
+	lcRendererTLSKeyPathEnv              = "LC_API_RENDERER_TLS_KEY_PATH"
+	lcRendererTLSServerNameEnv           = "LC_API_RENDERER_TLS_SERVER_NAME"
+	lcRendererPoolsPathEnv               = "LC_API_RENDERER_POOLS_PATH"
+	lcRendererRetryMaxAttemptsEnv        = "LC_API_RENDERER_RETRY_MAX_ATTEMPTS"
+	lcRendererRetryInitialBackoffMsEnv   = "LC_API_RENDERER_RETRY_INITIAL_BACKOFF_MS"
+	lcRendererRetryMaxBackoffMsEnv       = "LC_API_RENDERER_RETRY_MAX_BACKOFF_MS"
+	lcRendererRetryCodesEnv              = "LC_API_RENDERER_RETRY_CODES"
+	lcRendererRetryBudgetPercentEnv      = "LC_API_RENDERER_RETRY_BUDGET_PERCENT"
+	lcRendererRetryBudgetBurstEnv        = "LC_API_RENDERER_RETRY_BUDGET_BURST"
+	lcRendererHedgePercentileEnv         = "LC_API_RENDERER_HEDGE_PERCENTILE"
+	lcRendererBreakerWindowSecsEnv       = "LC_API_RENDERER_BREAKER_WINDOW"
+	lcRendererBreakerMinRequestsEnv      = "LC_API_RENDERER_BREAKER_MIN_REQUESTS"
+	lcRendererBreakerErrorPercentEnv     = "LC_API_RENDERER_BREAKER_ERROR_PERCENT"
+	lcRendererBreakerSlowCallMsEnv       = "LC_API_RENDERER_BREAKER_SLOW_CALL_MS"
+	lcRendererBreakerSlowPercentEnv      = "LC_API_RENDERER_BREAKER_SLOW_PERCENT"
+	lcRendererBreakerOpenTimeoutSecsEnv  = "LC_API_RENDERER_BREAKER_OPEN_TIMEOUT"
+	lcRendererBreakerHalfOpenRequestsEnv = "LC_API_RENDERER_BREAKER_HALF_OPEN_REQUESTS"
+	lcRendererProbeIntervalSecsEnv       = "LC_API_RENDERER_PROBE_INTERVAL"
+	lcRendererProbeTimeoutSecsEnv        = "LC_API_RENDERER_PROBE_TIMEOUT"
+	lcRendererProbeKindEnv               = "LC_API_RENDERER_PROBE_KIND"
+	lcRendererProbeHealthServiceEnv      = "LC_API_RENDERER_PROBE_HEALTH_SERVICE"
+	lcRendererProbeHealthyThresholdEnv   = "LC_API_RENDERER_PROBE_HEALTHY_THRESHOLD"
+	lcRendererProbeUnhealthyThresholdEnv = "LC_API_RENDERER_PROBE_UNHEALTHY_THRESHOLD"
+	lcRendererLimitInitialEnv            = "LC_API_RENDERER_LIMIT_INITIAL"
+	lcRendererLimitMinEnv                = "LC_API_RENDERER_LIMIT_MIN"
+	lcRendererLimitMaxEnv                = "LC_API_RENDERER_LIMIT_MAX"
+	lcRendererLimitLatencyMsEnv          = "LC_API_RENDERER_LIMIT_LATENCY_MS"
+	lcRendererLimitBackoffPercentEnv     = "LC_API_RENDERER_LIMIT_BACKOFF_PERCENT"
+	lcRendererLimitQueueSizeEnv          = "LC_API_RENDERER_LIMIT_QUEUE_SIZE"
+	lcRendererLimitQueueTimeoutMsEnv     = "LC_API_RENDERER_LIMIT_QUEUE_TIMEOUT_MS"
+	lcRendererLimitRetryAfterSecsEnv     = "LC_API_RENDERER_LIMIT_RETRY_AFTER"
 
 	gRPCAddressEnv             = "LC_API_GRPC_ADDRESS"
 	gRPCShutdownTimeoutSecsEnv = "LC_API_GRPC_SHUTDOWN_TIMEOUT"
@@ -49,6 +179,98 @@ const (
 	metricsReadTimeoutSecsEnv     = "LC_METRICS_READ_TIMEOUT"
 	metricsWriteTimeoutSecsEnv    = "LC_METRICS_WRITE_TIMEOUT"
 	metricsIdleTimeoutSecsEnv     = "LC_METRICS_IDLE_TIMEOUT"
+
+	renderCacheSizeEnv    = "LC_API_RENDER_CACHE_SIZE"
+	renderCacheTTLSecsEnv = "LC_API_RENDER_CACHE_TTL"
+
+	renderQueueWorkersEnv = "LC_API_RENDER_QUEUE_WORKERS"
+	renderQueueSizeEnv    = "LC_API_RENDER_QUEUE_SIZE"
+
+	batchParallelismEnv = "LC_API_BATCH_PARALLELISM"
+	batchMaxSizeEnv     = "LC_API_BATCH_MAX_SIZE"
+
+	webhookSecretEnv             = "LC_API_WEBHOOK_SECRET"
+	webhookWorkersEnv            = "LC_API_WEBHOOK_WORKERS"
+	webhookQueueSizeEnv          = "LC_API_WEBHOOK_QUEUE_SIZE"
+	webhookMaxAttemptsEnv        = "LC_API_WEBHOOK_MAX_ATTEMPTS"
+	webhookInitialBackoffSecsEnv = "LC_API_WEBHOOK_INITIAL_BACKOFF"
+	webhookMaxBackoffSecsEnv     = "LC_API_WEBHOOK_MAX_BACKOFF"
+	webhookRequestTimeoutSecsEnv = "LC_API_WEBHOOK_REQUEST_TIMEOUT"
+	webhookDeadLetterPathEnv     = "LC_API_WEBHOOK_DEAD_LETTER_PATH"
+	webhookDenyEnv               = "LC_API_WEBHOOK_DENY"
+
+	authAPIKeysPathEnv            = "LC_API_AUTH_API_KEYS_PATH"
+	authJWKSPathEnv               = "LC_API_AUTH_JWKS_PATH"
+	authJWKSReloadIntervalSecsEnv = "LC_API_AUTH_JWKS_RELOAD_INTERVAL"
+	authJWTIssuerEnv              = "LC_API_AUTH_JWT_ISSUER"
+	authJWTAudienceEnv            = "LC_API_AUTH_JWT_AUDIENCE"
+	authJWTLeewaySecsEnv          = "LC_API_AUTH_JWT_LEEWAY"
+
+	tenantTrustedHeaderEnv = "LC_API_TENANT_TRUSTED_HEADER"
+	tenantOverridesPathEnv = "LC_API_TENANT_OVERRIDES_PATH"
+
+	rateLimitKeyEnv        = "LC_API_RATE_LIMIT_KEY"
+	rateLimitPerMinuteEnv  = "LC_API_RATE_LIMIT_PER_MINUTE"
+	rateLimitBurstEnv      = "LC_API_RATE_LIMIT_BURST"
+	rateLimitOperationsEnv = "LC_API_RATE_LIMIT_OPERATIONS"
+
+	costMaxRequestEnv       = "LC_API_COST_MAX_REQUEST"
+	costTenantBudgetEnv     = "LC_API_COST_TENANT_BUDGET"
+	costBudgetWindowSecsEnv = "LC_API_COST_BUDGET_WINDOW"
+
+	tlsCertPathEnv           = "LC_API_TLS_CERT_PATH"
+	tlsKeyPathEnv            = "LC_API_TLS_KEY_PATH"
+	tlsClientCAPathEnv       = "LC_API_TLS_CLIENT_CA_PATH"
+	tlsReloadIntervalSecsEnv = "LC_API_TLS_RELOAD_INTERVAL"
+
+	signedURLKeysEnv       = "LC_API_SIGNED_URL_KEYS"
+	signedURLTTLSecsEnv    = "LC_API_SIGNED_URL_TTL"
+	signedURLMaxTTLSecsEnv = "LC_API_SIGNED_URL_MAX_TTL"
+	signedURLBaseEnv       = "LC_API_SIGNED_URL_BASE"
+
+	clientIPTrustedProxiesEnv = "LC_API_CLIENT_IP_TRUSTED_PROXIES"
+	clientIPAllowEnv          = "LC_API_CLIENT_IP_ALLOW"
+	clientIPDenyEnv           = "LC_API_CLIENT_IP_DENY"
+
+	auditLogPathEnv       = "LC_API_AUDIT_LOG_PATH"
+	auditLogMaxSizeMBEnv  = "LC_API_AUDIT_LOG_MAX_SIZE"
+	auditLogMaxBackupsEnv = "LC_API_AUDIT_LOG_MAX_BACKUPS"
+
+	storageKindEnv                 = "LC_API_STORAGE_KIND"
+	storageDirEnv                  = "LC_API_STORAGE_DIR"
+	storagePurgeGracePeriodSecsEnv = "LC_API_STORAGE_PURGE_GRACE_PERIOD"
+	storagePurgeIntervalSecsEnv    = "LC_API_STORAGE_PURGE_INTERVAL"
+	storageChartTTLSecsEnv         = "LC_API_STORAGE_CHART_TTL"
+)
+
+const (
+	// StorageKindMemory represents a storage that keeps charts in memory.
+	StorageKindMemory = "memory"
+
+	// StorageKindDisk represents a storage that keeps charts on disk.
+	StorageKindDisk = "disk"
+)
+
+const (
+	// RateLimitKeyAPIKey represents rate limiting by identity of the API key or bearer token.
+	RateLimitKeyAPIKey = "api_key"
+
+	// RateLimitKeyTenant represents rate limiting by tenant.
+	RateLimitKeyTenant = "tenant"
+
+	// RateLimitKeyIP represents rate limiting by client IP.
+	RateLimitKeyIP = "ip"
+)
+
+const (
+	// RendererProbeHealth represents probing of lc-renderer with grpc.health.v1 health check.
+	RendererProbeHealth = "health"
+
+	// RendererProbeRender represents probing of lc-renderer with a canary chart rendering.
+	RendererProbeRender = "render"
+
+	// RendererProbeBoth represents probing of lc-renderer with both health check and canary chart rendering.
+	RendererProbeBoth = "both"
 )
 
 // Config represents application config.
@@ -58,13 +280,60 @@ type Config struct {
 	GRPCHealthCheck GRPCHealthCheckConfig
 	HTTP            HTTPConfig
 	Metrics         MetricsConfig
+	Storage         StorageConfig
+	RenderCache     RenderCacheConfig
+	RenderQueue     RenderQueueConfig
+	Batch           BatchConfig
+	Webhook         WebhookConfig
+	Auth            AuthConfig
+	Tenant          TenantConfig
+	RateLimit       RateLimitConfig
+	Cost            CostConfig
+	TLS             TLSConfig
+	SignedURL       SignedURLConfig
+	ClientIP        ClientIPConfig
+	Audit           AuditConfig
 }
 
 // RendererConfig contains lc-renderer related configuration.
 type RendererConfig struct {
-	Address               string
-	ConnTimeoutSeconds    int
-	RequestTimeoutSeconds int
+	Address                         string
+	ConnTimeoutSeconds              int
+	RequestTimeoutSeconds           int
+	TLS                             bool
+	TLSCAPath                       string
+	TLSCertPath                     string
+	TLSKeyPath                      string
+	TLSServerName                   string
+	PoolsPath                       string
+	RetryMaxAttempts                int
+	RetryInitialBackoffMilliseconds int
+	RetryMaxBackoffMilliseconds     int
+	RetryCodes                      string
+	RetryBudgetPercent              int
+	RetryBudgetBurst                int
+	HedgePercentile                 int
+	BreakerWindowSeconds            int
+	BreakerMinRequests              int
+	BreakerErrorPercent             int
+	BreakerSlowCallMilliseconds     int
+	BreakerSlowPercent              int
+	BreakerOpenTimeoutSeconds       int
+	BreakerHalfOpenRequests         int
+	ProbeIntervalSeconds            int
+	ProbeTimeoutSeconds             int
+	ProbeKind                       string
+	ProbeHealthService              string
+	ProbeHealthyThreshold           int
+	ProbeUnhealthyThreshold         int
+	LimitInitial                    int
+	LimitMin                        int
+	LimitMax                        int
+	LimitLatencyMilliseconds        int
+	LimitBackoffPercent             int
+	LimitQueueSize                  int
+	LimitQueueTimeoutMilliseconds   int
+	LimitRetryAfterSeconds          int
 }
 
 // GRPCConfig contains lc-api gRPC related configuration.
@@ -96,13 +365,148 @@ type MetricsConfig struct {
 	IdleTimeoutSeconds     int
 }
 
+// StorageConfig contains lc-api charts storage related configuration.
+type StorageConfig struct {
+	Kind                    string
+	Dir                     string
+	PurgeGracePeriodSeconds int
+	PurgeIntervalSeconds    int
+	ChartTTLSeconds         int
+}
+
+// RenderCacheConfig contains lc-api render result cache related configuration.
+type RenderCacheConfig struct {
+	Size       int
+	TTLSeconds int
+}
+
+// RenderQueueConfig contains lc-api asynchronous render queue related configuration.
+type RenderQueueConfig struct {
+	Workers int
+	Size    int
+}
+
+// BatchConfig contains lc-api batch charts creation related configuration.
+type BatchConfig struct {
+	Parallelism int
+	MaxSize     int
+}
+
+// WebhookConfig contains lc-api chart callbacks related configuration.
+type WebhookConfig struct {
+	Secret                string
+	Workers               int
+	QueueSize             int
+	MaxAttempts           int
+	InitialBackoffSeconds int
+	MaxBackoffSeconds     int
+	RequestTimeoutSeconds int
+	DeadLetterPath        string
+	Deny                  string
+}
+
+// AuthConfig contains lc-api authentication related configuration.
+type AuthConfig struct {
+	APIKeysPath               string
+	JWKSPath                  string
+	JWKSReloadIntervalSeconds int
+	JWTIssuer                 string
+	JWTAudience               string
+	JWTLeewaySeconds          int
+}
+
+// TenantConfig contains lc-api multi-tenancy related configuration.
+type TenantConfig struct {
+	TrustedHeader string
+	OverridesPath string
+}
+
+// RateLimitConfig contains lc-api requests rate limiting related configuration.
+type RateLimitConfig struct {
+	Key        string
+	PerMinute  int
+	Burst      int
+	Operations string
+}
+
+// CostConfig contains lc-api render cost accounting related configuration.
+type CostConfig struct {
+	MaxRequestCost      int
+	TenantBudget        int
+	BudgetWindowSeconds int
+}
+
+// TLSConfig contains lc-api gRPC, gRPC health check and HTTP servers TLS related configuration.
+type TLSConfig struct {
+	CertPath              string
+	KeyPath               string
+	ClientCAPath          string
+	ReloadIntervalSeconds int
+}
+
+// SignedURLConfig contains lc-api signed chart image URLs related configuration.
+type SignedURLConfig struct {
+	Keys              string
+	DefaultTTLSeconds int
+	MaxTTLSeconds     int
+	BaseURL           string
+}
+
+// ClientIPConfig contains lc-api client IP resolution and filtering related configuration.
+type ClientIPConfig struct {
+	TrustedProxies string
+	Allow          string
+	Deny           string
+}
+
+// AuditConfig contains lc-api audit log related configuration.
+type AuditConfig struct {
+	LogPath          string
+	MaxSizeMegabytes int
+	MaxBackups       int
+}
+
 // NewFromEnv creates a new Config from environment variables.
 func NewFromEnv() Config {
 	return Config{
 		Renderer: RendererConfig{
-			Address:               stringValFromEnvOrDefault(lcRendererAddressEnv, lcRendererAddressDefault),
-			ConnTimeoutSeconds:    intValFromEnvOrDefault(lcRendererConnTimeoutSecsEnv, lcRendererConnTimeoutSecsDefault),
-			RequestTimeoutSeconds: intValFromEnvOrDefault(lcRendererReqTimeoutSecsEnv, lcRendererReqTimeoutSecsDefault),
+			Address:                         stringValFromEnvOrDefault(lcRendererAddressEnv, lcRendererAddressDefault),
+			ConnTimeoutSeconds:              intValFromEnvOrDefault(lcRendererConnTimeoutSecsEnv, lcRendererConnTimeoutSecsDefault),
+			RequestTimeoutSeconds:           intValFromEnvOrDefault(lcRendererReqTimeoutSecsEnv, lcRendererReqTimeoutSecsDefault),
+			TLS:                             boolValFromEnvOrDefault(lcRendererTLSEnv, lcRendererTLSDefault),
+			TLSCAPath:                       stringValFromEnvOrDefault(lcRendererTLSCAPathEnv, lcRendererTLSCAPathDefault),
+			TLSCertPath:                     stringValFromEnvOrDefault(lcRendererTLSCertPathEnv, lcRendererTLSCertPathDefault),
+			TLSKeyPath:                      stringValFromEnvOrDefault(lcRendererTLSKeyPathEnv, lcRendererTLSKeyPathDefault),
+			TLSServerName:                   stringValFromEnvOrDefault(lcRendererTLSServerNameEnv, lcRendererTLSServerNameDefault),
+			PoolsPath:                       stringValFromEnvOrDefault(lcRendererPoolsPathEnv, lcRendererPoolsPathDefault),
+			RetryMaxAttempts:                intValFromEnvOrDefault(lcRendererRetryMaxAttemptsEnv, lcRendererRetryMaxAttemptsDefault),
+			RetryInitialBackoffMilliseconds: intValFromEnvOrDefault(lcRendererRetryInitialBackoffMsEnv, lcRendererRetryInitialBackoffMsDefault),
+			RetryMaxBackoffMilliseconds:     intValFromEnvOrDefault(lcRendererRetryMaxBackoffMsEnv, lcRendererRetryMaxBackoffMsDefault),
+			RetryCodes:                      stringValFromEnvOrDefault(lcRendererRetryCodesEnv, lcRendererRetryCodesDefault),
+			RetryBudgetPercent:              intValFromEnvOrDefault(lcRendererRetryBudgetPercentEnv, lcRendererRetryBudgetPercentDefault),
+			RetryBudgetBurst:                intValFromEnvOrDefault(lcRendererRetryBudgetBurstEnv, lcRendererRetryBudgetBurstDefault),
+			HedgePercentile:                 intValFromEnvOrDefault(lcRendererHedgePercentileEnv, lcRendererHedgePercentileDefault),
+			BreakerWindowSeconds:            intValFromEnvOrDefault(lcRendererBreakerWindowSecsEnv, lcRendererBreakerWindowSecsDefault),
+			BreakerMinRequests:              intValFromEnvOrDefault(lcRendererBreakerMinRequestsEnv, lcRendererBreakerMinRequestsDefault),
+			BreakerErrorPercent:             intValFromEnvOrDefault(lcRendererBreakerErrorPercentEnv, lcRendererBreakerErrorPercentDefault),
+			BreakerSlowCallMilliseconds:     intValFromEnvOrDefault(lcRendererBreakerSlowCallMsEnv, lcRendererBreakerSlowCallMsDefault),
+			BreakerSlowPercent:              intValFromEnvOrDefault(lcRendererBreakerSlowPercentEnv, lcRendererBreakerSlowPercentDefault),
+			BreakerOpenTimeoutSeconds:       intValFromEnvOrDefault(lcRendererBreakerOpenTimeoutSecsEnv, lcRendererBreakerOpenTimeoutSecsDefault),
+			BreakerHalfOpenRequests:         intValFromEnvOrDefault(lcRendererBreakerHalfOpenRequestsEnv, lcRendererBreakerHalfOpenRequestsDefault),
+			ProbeIntervalSeconds:            intValFromEnvOrDefault(lcRendererProbeIntervalSecsEnv, lcRendererProbeIntervalSecsDefault),
+			ProbeTimeoutSeconds:             intValFromEnvOrDefault(lcRendererProbeTimeoutSecsEnv, lcRendererProbeTimeoutSecsDefault),
+			ProbeKind:                       stringValFromEnvOrDefault(lcRendererProbeKindEnv, lcRendererProbeKindDefault),
+			ProbeHealthService:              stringValFromEnvOrDefault(lcRendererProbeHealthServiceEnv, lcRendererProbeHealthServiceDefault),
+			ProbeHealthyThreshold:           intValFromEnvOrDefault(lcRendererProbeHealthyThresholdEnv, lcRendererProbeHealthyThresholdDefault),
+			ProbeUnhealthyThreshold:         intValFromEnvOrDefault(lcRendererProbeUnhealthyThresholdEnv, lcRendererProbeUnhealthyThresholdDefault),
+			LimitInitial:                    intValFromEnvOrDefault(lcRendererLimitInitialEnv, lcRendererLimitInitialDefault),
+			LimitMin:                        intValFromEnvOrDefault(lcRendererLimitMinEnv, lcRendererLimitMinDefault),
+			LimitMax:                        intValFromEnvOrDefault(lcRendererLimitMaxEnv, lcRendererLimitMaxDefault),
+			LimitLatencyMilliseconds:        intValFromEnvOrDefault(lcRendererLimitLatencyMsEnv, lcRendererLimitLatencyMsDefault),
+			LimitBackoffPercent:             intValFromEnvOrDefault(lcRendererLimitBackoffPercentEnv, lcRendererLimitBackoffPercentDefault),
+			LimitQueueSize:                  intValFromEnvOrDefault(lcRendererLimitQueueSizeEnv, lcRendererLimitQueueSizeDefault),
+			LimitQueueTimeoutMilliseconds:   intValFromEnvOrDefault(lcRendererLimitQueueTimeoutMsEnv, lcRendererLimitQueueTimeoutMsDefault),
+			LimitRetryAfterSeconds:          intValFromEnvOrDefault(lcRendererLimitRetryAfterSecsEnv, lcRendererLimitRetryAfterSecsDefault),
 		},
 		GRPC: GRPCConfig{
 			Address:                stringValFromEnvOrDefault(gRPCAddressEnv, gRPCAddressDefault),
@@ -125,6 +529,81 @@ func NewFromEnv() Config {
 			WriteTimeoutSeconds:    intValFromEnvOrDefault(metricsWriteTimeoutSecsEnv, metricsWriteTimeoutSecsDefault),
 			IdleTimeoutSeconds:     intValFromEnvOrDefault(metricsIdleTimeoutSecsEnv, metricsIdleTimeoutSecsDefault),
 		},
+		Storage: StorageConfig{
+			Kind:                    stringValFromEnvOrDefault(storageKindEnv, storageKindDefault),
+			Dir:                     stringValFromEnvOrDefault(storageDirEnv, storageDirDefault),
+			PurgeGracePeriodSeconds: intValFromEnvOrDefault(storagePurgeGracePeriodSecsEnv, storagePurgeGracePeriodSecsDefault),
+			PurgeIntervalSeconds:    intValFromEnvOrDefault(storagePurgeIntervalSecsEnv, storagePurgeIntervalSecsDefault),
+			ChartTTLSeconds:         intValFromEnvOrDefault(storageChartTTLSecsEnv, storageChartTTLSecsDefault),
+		},
+		RenderCache: RenderCacheConfig{
+			Size:       intValFromEnvOrDefault(renderCacheSizeEnv, renderCacheSizeDefault),
+			TTLSeconds: intValFromEnvOrDefault(renderCacheTTLSecsEnv, renderCacheTTLSecsDefault),
+		},
+		RenderQueue: RenderQueueConfig{
+			Workers: intValFromEnvOrDefault(renderQueueWorkersEnv, renderQueueWorkersDefault),
+			Size:    intValFromEnvOrDefault(renderQueueSizeEnv, renderQueueSizeDefault),
+		},
+		Batch: BatchConfig{
+			Parallelism: intValFromEnvOrDefault(batchParallelismEnv, batchParallelismDefault),
+			MaxSize:     intValFromEnvOrDefault(batchMaxSizeEnv, batchMaxSizeDefault),
+		},
+		Webhook: WebhookConfig{
+			Secret:                stringValFromEnvOrDefault(webhookSecretEnv, webhookSecretDefault),
+			Workers:               intValFromEnvOrDefault(webhookWorkersEnv, webhookWorkersDefault),
+			QueueSize:             intValFromEnvOrDefault(webhookQueueSizeEnv, webhookQueueSizeDefault),
+			MaxAttempts:           intValFromEnvOrDefault(webhookMaxAttemptsEnv, webhookMaxAttemptsDefault),
+			InitialBackoffSeconds: intValFromEnvOrDefault(webhookInitialBackoffSecsEnv, webhookInitialBackoffSecsDefault),
+			MaxBackoffSeconds:     intValFromEnvOrDefault(webhookMaxBackoffSecsEnv, webhookMaxBackoffSecsDefault),
+			RequestTimeoutSeconds: intValFromEnvOrDefault(webhookRequestTimeoutSecsEnv, webhookRequestTimeoutSecsDefault),
+			DeadLetterPath:        stringValFromEnvOrDefault(webhookDeadLetterPathEnv, webhookDeadLetterPathDefault),
+			Deny:                  stringValFromEnvOrDefault(webhookDenyEnv, webhookDenyDefault),
+		},
+		Auth: AuthConfig{
+			APIKeysPath:               stringValFromEnvOrDefault(authAPIKeysPathEnv, authAPIKeysPathDefault),
+			JWKSPath:                  stringValFromEnvOrDefault(authJWKSPathEnv, authJWKSPathDefault),
+			JWKSReloadIntervalSeconds: intValFromEnvOrDefault(authJWKSReloadIntervalSecsEnv, authJWKSReloadIntervalSecsDefault),
+			JWTIssuer:                 stringValFromEnvOrDefault(authJWTIssuerEnv, authJWTIssuerDefault),
+			JWTAudience:               stringValFromEnvOrDefault(authJWTAudienceEnv, authJWTAudienceDefault),
+			JWTLeewaySeconds:          intValFromEnvOrDefault(authJWTLeewaySecsEnv, authJWTLeewaySecsDefault),
+		},
+		Tenant: TenantConfig{
+			TrustedHeader: stringValFromEnvOrDefault(tenantTrustedHeaderEnv, tenantTrustedHeaderDefault),
+			OverridesPath: stringValFromEnvOrDefault(tenantOverridesPathEnv, tenantOverridesPathDefault),
+		},
+		RateLimit: RateLimitConfig{
+			Key:        stringValFromEnvOrDefault(rateLimitKeyEnv, rateLimitKeyDefault),
+			PerMinute:  intValFromEnvOrDefault(rateLimitPerMinuteEnv, rateLimitPerMinuteDefault),
+			Burst:      intValFromEnvOrDefault(rateLimitBurstEnv, rateLimitBurstDefault),
+			Operations: stringValFromEnvOrDefault(rateLimitOperationsEnv, rateLimitOperationsDefault),
+		},
+		Cost: CostConfig{
+			MaxRequestCost:      intValFromEnvOrDefault(costMaxRequestEnv, costMaxRequestDefault),
+			TenantBudget:        intValFromEnvOrDefault(costTenantBudgetEnv, costTenantBudgetDefault),
+			BudgetWindowSeconds: intValFromEnvOrDefault(costBudgetWindowSecsEnv, costBudgetWindowSecsDefault),
+		},
+		TLS: TLSConfig{
+			CertPath:              stringValFromEnvOrDefault(tlsCertPathEnv, tlsCertPathDefault),
+			KeyPath:               stringValFromEnvOrDefault(tlsKeyPathEnv, tlsKeyPathDefault),
+			ClientCAPath:          stringValFromEnvOrDefault(tlsClientCAPathEnv, tlsClientCAPathDefault),
+			ReloadIntervalSeconds: intValFromEnvOrDefault(tlsReloadIntervalSecsEnv, tlsReloadIntervalSecsDefault),
+		},
+		SignedURL: SignedURLConfig{
+			Keys:              stringValFromEnvOrDefault(signedURLKeysEnv, signedURLKeysDefault),
+			DefaultTTLSeconds: intValFromEnvOrDefault(signedURLTTLSecsEnv, signedURLTTLSecsDefault),
+			MaxTTLSeconds:     intValFromEnvOrDefault(signedURLMaxTTLSecsEnv, signedURLMaxTTLSecsDefault),
+			BaseURL:           stringValFromEnvOrDefault(signedURLBaseEnv, signedURLBaseDefault),
+		},
+		ClientIP: ClientIPConfig{
+			TrustedProxies: stringValFromEnvOrDefault(clientIPTrustedProxiesEnv, clientIPTrustedProxiesDefault),
+			Allow:          stringValFromEnvOrDefault(clientIPAllowEnv, clientIPAllowDefault),
+			Deny:           stringValFromEnvOrDefault(clientIPDenyEnv, clientIPDenyDefault),
+		},
+		Audit: AuditConfig{
+			LogPath:          stringValFromEnvOrDefault(auditLogPathEnv, auditLogPathDefault),
+			MaxSizeMegabytes: intValFromEnvOrDefault(auditLogMaxSizeMBEnv, auditLogMaxSizeMBDefault),
+			MaxBackups:       intValFromEnvOrDefault(auditLogMaxBackupsEnv, auditLogMaxBackupsDefault),
+		},
 	}
 }
 
@@ -150,3 +629,17 @@ func intValFromEnvOrDefault(param string, def int) int {
 
 	return val
 }
+
+func boolValFromEnvOrDefault(param string, def bool) bool {
+	valRaw := os.Getenv(param)
+	if valRaw == "" {
+		return def
+	}
+
+	val, err := strconv.ParseBool(valRaw)
+	if err != nil {
+		return def
+	}
+
+	return val
+}
@@ -24,6 +24,40 @@ func TestNewFromEnv(t *testing.T) {
 				setEnvVar(t, "LC_API_RENDERER_ADDRESS", "localhost:63020"),
 				setEnvVar(t, "LC_API_RENDERER_CONN_TIMEOUT", "44"),
 				setEnvVar(t, "LC_API_RENDERER_REQUEST_TIMEOUT", "120"),
+				setEnvVar(t, "LC_API_RENDERER_TLS", "true"),
+				setEnvVar(t, "LC_API_RENDERER_TLS_CA_PATH", "/etc/lc-api/renderer-ca.pem"),
+				setEnvVar(t, "LC_API_RENDERER_TLS_CERT_PATH", "/etc/lc-api/renderer-client.pem"),
+				setEnvVar(t, "LC_API_RENDERER_TLS_KEY_PATH", "/etc/lc-api/renderer-client-key.pem"),
+				setEnvVar(t, "LC_API_RENDERER_TLS_SERVER_NAME", "lc-renderer"),
+				setEnvVar(t, "LC_API_RENDERER_POOLS_PATH", "/etc/lc-api/renderer-pools.json"),
+				setEnvVar(t, "LC_API_RENDERER_RETRY_MAX_ATTEMPTS", "5"),
+				setEnvVar(t, "LC_API_RENDERER_RETRY_INITIAL_BACKOFF_MS", "100"),
+				setEnvVar(t, "LC_API_RENDERER_RETRY_MAX_BACKOFF_MS", "2000"),
+				setEnvVar(t, "LC_API_RENDERER_RETRY_CODES", "UNAVAILABLE,RESOURCE_EXHAUSTED"),
+				setEnvVar(t, "LC_API_RENDERER_RETRY_BUDGET_PERCENT", "10"),
+				setEnvVar(t, "LC_API_RENDERER_RETRY_BUDGET_BURST", "20"),
+				setEnvVar(t, "LC_API_RENDERER_HEDGE_PERCENTILE", "95"),
+				setEnvVar(t, "LC_API_RENDERER_BREAKER_WINDOW", "30"),
+				setEnvVar(t, "LC_API_RENDERER_BREAKER_MIN_REQUESTS", "50"),
+				setEnvVar(t, "LC_API_RENDERER_BREAKER_ERROR_PERCENT", "40"),
+				setEnvVar(t, "LC_API_RENDERER_BREAKER_SLOW_CALL_MS", "5000"),
+				setEnvVar(t, "LC_API_RENDERER_BREAKER_SLOW_PERCENT", "80"),
+				setEnvVar(t, "LC_API_RENDERER_BREAKER_OPEN_TIMEOUT", "60"),
+				setEnvVar(t, "LC_API_RENDERER_BREAKER_HALF_OPEN_REQUESTS", "5"),
+				setEnvVar(t, "LC_API_RENDERER_PROBE_INTERVAL", "10"),
+				setEnvVar(t, "LC_API_RENDERER_PROBE_TIMEOUT", "3"),
+				setEnvVar(t, "LC_API_RENDERER_PROBE_KIND", "both"),
+				setEnvVar(t, "LC_API_RENDERER_PROBE_HEALTH_SERVICE", "lc-renderer"),
+				setEnvVar(t, "LC_API_RENDERER_PROBE_HEALTHY_THRESHOLD", "3"),
+				setEnvVar(t, "LC_API_RENDERER_PROBE_UNHEALTHY_THRESHOLD", "5"),
+				setEnvVar(t, "LC_API_RENDERER_LIMIT_INITIAL", "10"),
+				setEnvVar(t, "LC_API_RENDERER_LIMIT_MIN", "4"),
+				setEnvVar(t, "LC_API_RENDERER_LIMIT_MAX", "100"),
+				setEnvVar(t, "LC_API_RENDERER_LIMIT_LATENCY_MS", "1000"),
+				setEnvVar(t, "LC_API_RENDERER_LIMIT_BACKOFF_PERCENT", "80"),
+				setEnvVar(t, "LC_API_RENDERER_LIMIT_QUEUE_SIZE", "20"),
+				setEnvVar(t, "LC_API_RENDERER_LIMIT_QUEUE_TIMEOUT_MS", "200"),
+				setEnvVar(t, "LC_API_RENDERER_LIMIT_RETRY_AFTER", "2"),
 				setEnvVar(t, "LC_API_GRPC_ADDRESS", "localhost:63010"),
 				setEnvVar(t, "LC_API_GRPC_SHUTDOWN_TIMEOUT", "10"),
 				setEnvVar(t, "LC_API_GRPC_HEALTH_CHECK_ADDRESS", "localhost:63011"),
@@ -37,11 +71,94 @@ func TestNewFromEnv(t *testing.T) {
 				setEnvVar(t, "LC_METRICS_READ_TIMEOUT", "51"),
 				setEnvVar(t, "LC_METRICS_WRITE_TIMEOUT", "101"),
 				setEnvVar(t, "LC_METRICS_IDLE_TIMEOUT", "1201"),
+				setEnvVar(t, "LC_API_STORAGE_KIND", "disk"),
+				setEnvVar(t, "LC_API_STORAGE_DIR", "/tmp/lc-api-charts"),
+				setEnvVar(t, "LC_API_STORAGE_PURGE_GRACE_PERIOD", "3600"),
+				setEnvVar(t, "LC_API_STORAGE_PURGE_INTERVAL", "10"),
+				setEnvVar(t, "LC_API_STORAGE_CHART_TTL", "3600"),
+				setEnvVar(t, "LC_API_RENDER_CACHE_SIZE", "10"),
+				setEnvVar(t, "LC_API_RENDER_CACHE_TTL", "60"),
+				setEnvVar(t, "LC_API_RENDER_QUEUE_WORKERS", "2"),
+				setEnvVar(t, "LC_API_RENDER_QUEUE_SIZE", "20"),
+				setEnvVar(t, "LC_API_WEBHOOK_SECRET", "webhook-secret"),
+				setEnvVar(t, "LC_API_WEBHOOK_WORKERS", "2"),
+				setEnvVar(t, "LC_API_WEBHOOK_QUEUE_SIZE", "20"),
+				setEnvVar(t, "LC_API_WEBHOOK_MAX_ATTEMPTS", "3"),
+				setEnvVar(t, "LC_API_WEBHOOK_INITIAL_BACKOFF", "2"),
+				setEnvVar(t, "LC_API_WEBHOOK_MAX_BACKOFF", "30"),
+				setEnvVar(t, "LC_API_WEBHOOK_REQUEST_TIMEOUT", "5"),
+				setEnvVar(t, "LC_API_WEBHOOK_DEAD_LETTER_PATH", "/tmp/dead-letter.ndjson"),
+				setEnvVar(t, "LC_API_WEBHOOK_DENY", "10.0.0.0/8"),
+				setEnvVar(t, "LC_API_BATCH_PARALLELISM", "16"),
+				setEnvVar(t, "LC_API_BATCH_MAX_SIZE", "500"),
+				setEnvVar(t, "LC_API_AUTH_API_KEYS_PATH", "/etc/lc-api/api-keys"),
+				setEnvVar(t, "LC_API_AUTH_JWKS_PATH", "/etc/lc-api/jwks.json"),
+				setEnvVar(t, "LC_API_AUTH_JWKS_RELOAD_INTERVAL", "5"),
+				setEnvVar(t, "LC_API_AUTH_JWT_ISSUER", "https://auth.example.com"),
+				setEnvVar(t, "LC_API_AUTH_JWT_AUDIENCE", "lc-api"),
+				setEnvVar(t, "LC_API_AUTH_JWT_LEEWAY", "10"),
+				setEnvVar(t, "LC_API_TENANT_TRUSTED_HEADER", "X-Tenant-Id"),
+				setEnvVar(t, "LC_API_TENANT_OVERRIDES_PATH", "/etc/lc-api/tenants.json"),
+				setEnvVar(t, "LC_API_RATE_LIMIT_KEY", "tenant"),
+				setEnvVar(t, "LC_API_RATE_LIMIT_PER_MINUTE", "120"),
+				setEnvVar(t, "LC_API_RATE_LIMIT_BURST", "20"),
+				setEnvVar(t, "LC_API_RATE_LIMIT_OPERATIONS", "CreateChart=60:5,ListCharts=600:50"),
+				setEnvVar(t, "LC_API_COST_MAX_REQUEST", "100000"),
+				setEnvVar(t, "LC_API_COST_TENANT_BUDGET", "1000000"),
+				setEnvVar(t, "LC_API_COST_BUDGET_WINDOW", "600"),
+				setEnvVar(t, "LC_API_TLS_CERT_PATH", "/etc/lc-api/tls.pem"),
+				setEnvVar(t, "LC_API_TLS_KEY_PATH", "/etc/lc-api/tls-key.pem"),
+				setEnvVar(t, "LC_API_TLS_CLIENT_CA_PATH", "/etc/lc-api/client-ca.pem"),
+				setEnvVar(t, "LC_API_TLS_RELOAD_INTERVAL", "30"),
+				setEnvVar(t, "LC_API_SIGNED_URL_KEYS", "v2:0123456789abcdef,v1:fedcba9876543210"),
+				setEnvVar(t, "LC_API_SIGNED_URL_TTL", "600"),
+				setEnvVar(t, "LC_API_SIGNED_URL_MAX_TTL", "3600"),
+				setEnvVar(t, "LC_API_SIGNED_URL_BASE", "https://charts.example.com"),
+				setEnvVar(t, "LC_API_CLIENT_IP_TRUSTED_PROXIES", "10.0.0.0/8"),
+				setEnvVar(t, "LC_API_CLIENT_IP_ALLOW", "192.168.0.0/16"),
+				setEnvVar(t, "LC_API_CLIENT_IP_DENY", "192.168.1.1"),
+				setEnvVar(t, "LC_API_AUDIT_LOG_PATH", "/var/log/lc-api/audit.ndjson"),
+				setEnvVar(t, "LC_API_AUDIT_LOG_MAX_SIZE", "50"),
+				setEnvVar(t, "LC_API_AUDIT_LOG_MAX_BACKUPS", "3"),
 			},
 			[]func() error{
 				unsetEnvVar(t, "LC_API_RENDERER_ADDRESS"),
 				unsetEnvVar(t, "LC_API_RENDERER_CONN_TIMEOUT"),
 				unsetEnvVar(t, "LC_API_RENDERER_REQUEST_TIMEOUT"),
+				unsetEnvVar(t, "LC_API_RENDERER_TLS"),
+				unsetEnvVar(t, "LC_API_RENDERER_TLS_CA_PATH"),
+				unsetEnvVar(t, "LC_API_RENDERER_TLS_CERT_PATH"),
+				unsetEnvVar(t, "LC_API_RENDERER_TLS_KEY_PATH"),
+				unsetEnvVar(t, "LC_API_RENDERER_TLS_SERVER_NAME"),
+				unsetEnvVar(t, "LC_API_RENDERER_POOLS_PATH"),
+				unsetEnvVar(t, "LC_API_RENDERER_RETRY_MAX_ATTEMPTS"),
+				unsetEnvVar(t, "LC_API_RENDERER_RETRY_INITIAL_BACKOFF_MS"),
+				unsetEnvVar(t, "LC_API_RENDERER_RETRY_MAX_BACKOFF_MS"),
+				unsetEnvVar(t, "LC_API_RENDERER_RETRY_CODES"),
+				unsetEnvVar(t, "LC_API_RENDERER_RETRY_BUDGET_PERCENT"),
+				unsetEnvVar(t, "LC_API_RENDERER_RETRY_BUDGET_BURST"),
+				unsetEnvVar(t, "LC_API_RENDERER_HEDGE_PERCENTILE"),
+				unsetEnvVar(t, "LC_API_RENDERER_BREAKER_WINDOW"),
+				unsetEnvVar(t, "LC_API_RENDERER_BREAKER_MIN_REQUESTS"),
+				unsetEnvVar(t, "LC_API_RENDERER_BREAKER_ERROR_PERCENT"),
+				unsetEnvVar(t, "LC_API_RENDERER_BREAKER_SLOW_CALL_MS"),
+				unsetEnvVar(t, "LC_API_RENDERER_BREAKER_SLOW_PERCENT"),
+				unsetEnvVar(t, "LC_API_RENDERER_BREAKER_OPEN_TIMEOUT"),
+				unsetEnvVar(t, "LC_API_RENDERER_BREAKER_HALF_OPEN_REQUESTS"),
+				unsetEnvVar(t, "LC_API_RENDERER_PROBE_INTERVAL"),
+				unsetEnvVar(t, "LC_API_RENDERER_PROBE_TIMEOUT"),
+				unsetEnvVar(t, "LC_API_RENDERER_PROBE_KIND"),
+				unsetEnvVar(t, "LC_API_RENDERER_PROBE_HEALTH_SERVICE"),
+				unsetEnvVar(t, "LC_API_RENDERER_PROBE_HEALTHY_THRESHOLD"),
+				unsetEnvVar(t, "LC_API_RENDERER_PROBE_UNHEALTHY_THRESHOLD"),
+				unsetEnvVar(t, "LC_API_RENDERER_LIMIT_INITIAL"),
+				unsetEnvVar(t, "LC_API_RENDERER_LIMIT_MIN"),
+				unsetEnvVar(t, "LC_API_RENDERER_LIMIT_MAX"),
+				unsetEnvVar(t, "LC_API_RENDERER_LIMIT_LATENCY_MS"),
+				unsetEnvVar(t, "LC_API_RENDERER_LIMIT_BACKOFF_PERCENT"),
+				unsetEnvVar(t, "LC_API_RENDERER_LIMIT_QUEUE_SIZE"),
+				unsetEnvVar(t, "LC_API_RENDERER_LIMIT_QUEUE_TIMEOUT_MS"),
+				unsetEnvVar(t, "LC_API_RENDERER_LIMIT_RETRY_AFTER"),
 				unsetEnvVar(t, "LC_API_GRPC_ADDRESS"),
 				unsetEnvVar(t, "LC_API_GRPC_SHUTDOWN_TIMEOUT"),
 				unsetEnvVar(t, "LC_API_GRPC_HEALTH_CHECK_ADDRESS"),
@@ -55,12 +172,95 @@ func TestNewFromEnv(t *testing.T) {
 				unsetEnvVar(t, "LC_METRICS_READ_TIMEOUT"),
 				unsetEnvVar(t, "LC_METRICS_WRITE_TIMEOUT"),
 				unsetEnvVar(t, "LC_METRICS_IDLE_TIMEOUT"),
+				unsetEnvVar(t, "LC_API_STORAGE_KIND"),
+				unsetEnvVar(t, "LC_API_STORAGE_DIR"),
+				unsetEnvVar(t, "LC_API_STORAGE_PURGE_GRACE_PERIOD"),
+				unsetEnvVar(t, "LC_API_STORAGE_PURGE_INTERVAL"),
+				unsetEnvVar(t, "LC_API_STORAGE_CHART_TTL"),
+				unsetEnvVar(t, "LC_API_RENDER_CACHE_SIZE"),
+				unsetEnvVar(t, "LC_API_RENDER_CACHE_TTL"),
+				unsetEnvVar(t, "LC_API_RENDER_QUEUE_WORKERS"),
+				unsetEnvVar(t, "LC_API_RENDER_QUEUE_SIZE"),
+				unsetEnvVar(t, "LC_API_WEBHOOK_SECRET"),
+				unsetEnvVar(t, "LC_API_WEBHOOK_WORKERS"),
+				unsetEnvVar(t, "LC_API_WEBHOOK_QUEUE_SIZE"),
+				unsetEnvVar(t, "LC_API_WEBHOOK_MAX_ATTEMPTS"),
+				unsetEnvVar(t, "LC_API_WEBHOOK_INITIAL_BACKOFF"),
+				unsetEnvVar(t, "LC_API_WEBHOOK_MAX_BACKOFF"),
+				unsetEnvVar(t, "LC_API_WEBHOOK_REQUEST_TIMEOUT"),
+				unsetEnvVar(t, "LC_API_WEBHOOK_DEAD_LETTER_PATH"),
+				unsetEnvVar(t, "LC_API_WEBHOOK_DENY"),
+				unsetEnvVar(t, "LC_API_BATCH_PARALLELISM"),
+				unsetEnvVar(t, "LC_API_BATCH_MAX_SIZE"),
+				unsetEnvVar(t, "LC_API_AUTH_API_KEYS_PATH"),
+				unsetEnvVar(t, "LC_API_AUTH_JWKS_PATH"),
+				unsetEnvVar(t, "LC_API_AUTH_JWKS_RELOAD_INTERVAL"),
+				unsetEnvVar(t, "LC_API_AUTH_JWT_ISSUER"),
+				unsetEnvVar(t, "LC_API_AUTH_JWT_AUDIENCE"),
+				unsetEnvVar(t, "LC_API_AUTH_JWT_LEEWAY"),
+				unsetEnvVar(t, "LC_API_TENANT_TRUSTED_HEADER"),
+				unsetEnvVar(t, "LC_API_TENANT_OVERRIDES_PATH"),
+				unsetEnvVar(t, "LC_API_RATE_LIMIT_KEY"),
+				unsetEnvVar(t, "LC_API_RATE_LIMIT_PER_MINUTE"),
+				unsetEnvVar(t, "LC_API_RATE_LIMIT_BURST"),
+				unsetEnvVar(t, "LC_API_RATE_LIMIT_OPERATIONS"),
+				unsetEnvVar(t, "LC_API_COST_MAX_REQUEST"),
+				unsetEnvVar(t, "LC_API_COST_TENANT_BUDGET"),
+				unsetEnvVar(t, "LC_API_COST_BUDGET_WINDOW"),
+				unsetEnvVar(t, "LC_API_TLS_CERT_PATH"),
+				unsetEnvVar(t, "LC_API_TLS_KEY_PATH"),
+				unsetEnvVar(t, "LC_API_TLS_CLIENT_CA_PATH"),
+				unsetEnvVar(t, "LC_API_TLS_RELOAD_INTERVAL"),
+				unsetEnvVar(t, "LC_API_SIGNED_URL_KEYS"),
+				unsetEnvVar(t, "LC_API_SIGNED_URL_TTL"),
+				unsetEnvVar(t, "LC_API_SIGNED_URL_MAX_TTL"),
+				unsetEnvVar(t, "LC_API_SIGNED_URL_BASE"),
+				unsetEnvVar(t, "LC_API_CLIENT_IP_TRUSTED_PROXIES"),
+				unsetEnvVar(t, "LC_API_CLIENT_IP_ALLOW"),
+				unsetEnvVar(t, "LC_API_CLIENT_IP_DENY"),
+				unsetEnvVar(t, "LC_API_AUDIT_LOG_PATH"),
+				unsetEnvVar(t, "LC_API_AUDIT_LOG_MAX_SIZE"),
+				unsetEnvVar(t, "LC_API_AUDIT_LOG_MAX_BACKUPS"),
 			},
 			config.Config{
 				Renderer: config.RendererConfig{
-					Address:               "localhost:63020",
-					ConnTimeoutSeconds:    44,
-					RequestTimeoutSeconds: 120,
+					Address:                         "localhost:63020",
+					ConnTimeoutSeconds:              44,
+					RequestTimeoutSeconds:           120,
+					TLS:                             true,
+					TLSCAPath:                       "/etc/lc-api/renderer-ca.pem",
+					TLSCertPath:                     "/etc/lc-api/renderer-client.pem",
+					TLSKeyPath:                      "/etc/lc-api/renderer-client-key.pem",
+					TLSServerName:                   "lc-renderer",
+					PoolsPath:                       "/etc/lc-api/renderer-pools.json",
+					RetryMaxAttempts:                5,
+					RetryInitialBackoffMilliseconds: 100,
+					RetryMaxBackoffMilliseconds:     2000,
+					RetryCodes:                      "UNAVAILABLE,RESOURCE_EXHAUSTED",
+					RetryBudgetPercent:              10,
+					RetryBudgetBurst:                20,
+					HedgePercentile:                 95,
+					BreakerWindowSeconds:            30,
+					BreakerMinRequests:              50,
+					BreakerErrorPercent:             40,
+					BreakerSlowCallMilliseconds:     5000,
+					BreakerSlowPercent:              80,
+					BreakerOpenTimeoutSeconds:       60,
+					BreakerHalfOpenRequests:         5,
+					ProbeIntervalSeconds:            10,
+					ProbeTimeoutSeconds:             3,
+					ProbeKind:                       "both",
+					ProbeHealthService:              "lc-renderer",
+					ProbeHealthyThreshold:           3,
+					ProbeUnhealthyThreshold:         5,
+					LimitInitial:                    10,
+					LimitMin:                        4,
+					LimitMax:                        100,
+					LimitLatencyMilliseconds:        1000,
+					LimitBackoffPercent:             80,
+					LimitQueueSize:                  20,
+					LimitQueueTimeoutMilliseconds:   200,
+					LimitRetryAfterSeconds:          2,
 				},
 				GRPC: config.GRPCConfig{
 					Address:                "localhost:63010",
@@ -83,6 +283,81 @@ func TestNewFromEnv(t *testing.T) {
 					WriteTimeoutSeconds:    101,
 					IdleTimeoutSeconds:     1201,
 				},
+				Storage: config.StorageConfig{
+					Kind:                    "disk",
+					Dir:                     "/tmp/lc-api-charts",
+					PurgeGracePeriodSeconds: 3600,
+					PurgeIntervalSeconds:    10,
+					ChartTTLSeconds:         3600,
+				},
+				RenderCache: config.RenderCacheConfig{
+					Size:       10,
+					TTLSeconds: 60,
+				},
+				RenderQueue: config.RenderQueueConfig{
+					Workers: 2,
+					Size:    20,
+				},
+				Webhook: config.WebhookConfig{
+					Secret:                "webhook-secret",
+					Workers:               2,
+					QueueSize:             20,
+					MaxAttempts:           3,
+					InitialBackoffSeconds: 2,
+					MaxBackoffSeconds:     30,
+					RequestTimeoutSeconds: 5,
+					DeadLetterPath:        "/tmp/dead-letter.ndjson",
+					Deny:                  "10.0.0.0/8",
+				},
+				Batch: config.BatchConfig{
+					Parallelism: 16,
+					MaxSize:     500,
+				},
+				Auth: config.AuthConfig{
+					APIKeysPath:               "/etc/lc-api/api-keys",
+					JWKSPath:                  "/etc/lc-api/jwks.json",
+					JWKSReloadIntervalSeconds: 5,
+					JWTIssuer:                 "https://auth.example.com",
+					JWTAudience:               "lc-api",
+					JWTLeewaySeconds:          10,
+				},
+				Tenant: config.TenantConfig{
+					TrustedHeader: "X-Tenant-Id",
+					OverridesPath: "/etc/lc-api/tenants.json",
+				},
+				RateLimit: config.RateLimitConfig{
+					Key:        config.RateLimitKeyTenant,
+					PerMinute:  120,
+					Burst:      20,
+					Operations: "CreateChart=60:5,ListCharts=600:50",
+				},
+				Cost: config.CostConfig{
+					MaxRequestCost:      100000,
+					TenantBudget:        1000000,
+					BudgetWindowSeconds: 600,
+				},
+				TLS: config.TLSConfig{
+					CertPath:              "/etc/lc-api/tls.pem",
+					KeyPath:               "/etc/lc-api/tls-key.pem",
+					ClientCAPath:          "/etc/lc-api/client-ca.pem",
+					ReloadIntervalSeconds: 30,
+				},
+				SignedURL: config.SignedURLConfig{
+					Keys:              "v2:0123456789abcdef,v1:fedcba9876543210",
+					DefaultTTLSeconds: 600,
+					MaxTTLSeconds:     3600,
+					BaseURL:           "https://charts.example.com",
+				},
+				ClientIP: config.ClientIPConfig{
+					TrustedProxies: "10.0.0.0/8",
+					Allow:          "192.168.0.0/16",
+					Deny:           "192.168.1.1",
+				},
+				Audit: config.AuditConfig{
+					LogPath:          "/var/log/lc-api/audit.ndjson",
+					MaxSizeMegabytes: 50,
+					MaxBackups:       3,
+				},
 			},
 		},
 		{
@@ -103,9 +378,43 @@ func TestNewFromEnv(t *testing.T) {
 			},
 			config.Config{
 				Renderer: config.RendererConfig{
-					Address:               "dns:///localhost:54020",
-					ConnTimeoutSeconds:    5,
-					RequestTimeoutSeconds: 30,
+					Address:                         "dns:///localhost:54020",
+					ConnTimeoutSeconds:              5,
+					RequestTimeoutSeconds:           30,
+					TLS:                             false,
+					TLSCAPath:                       "",
+					TLSCertPath:                     "",
+					TLSKeyPath:                      "",
+					TLSServerName:                   "",
+					PoolsPath:                       "",
+					RetryMaxAttempts:                3,
+					RetryInitialBackoffMilliseconds: 50,
+					RetryMaxBackoffMilliseconds:     1000,
+					RetryCodes:                      "UNAVAILABLE",
+					RetryBudgetPercent:              20,
+					RetryBudgetBurst:                10,
+					HedgePercentile:                 0,
+					BreakerWindowSeconds:            10,
+					BreakerMinRequests:              20,
+					BreakerErrorPercent:             50,
+					BreakerSlowCallMilliseconds:     10000,
+					BreakerSlowPercent:              50,
+					BreakerOpenTimeoutSeconds:       30,
+					BreakerHalfOpenRequests:         3,
+					ProbeIntervalSeconds:            5,
+					ProbeTimeoutSeconds:             2,
+					ProbeKind:                       "render",
+					ProbeHealthService:              "",
+					ProbeHealthyThreshold:           2,
+					ProbeUnhealthyThreshold:         3,
+					LimitInitial:                    20,
+					LimitMin:                        2,
+					LimitMax:                        200,
+					LimitLatencyMilliseconds:        2000,
+					LimitBackoffPercent:             90,
+					LimitQueueSize:                  50,
+					LimitQueueTimeoutMilliseconds:   500,
+					LimitRetryAfterSeconds:          1,
 				},
 				GRPC: config.GRPCConfig{
 					Address:                "0.0.0.0:54010",
@@ -128,6 +437,81 @@ func TestNewFromEnv(t *testing.T) {
 					WriteTimeoutSeconds:    10,
 					IdleTimeoutSeconds:     120,
 				},
+				Storage: config.StorageConfig{
+					Kind:                    "memory",
+					Dir:                     "./charts",
+					PurgeGracePeriodSeconds: 86400,
+					PurgeIntervalSeconds:    60,
+					ChartTTLSeconds:         2592000,
+				},
+				RenderCache: config.RenderCacheConfig{
+					Size:       1000,
+					TTLSeconds: 3600,
+				},
+				RenderQueue: config.RenderQueueConfig{
+					Workers: 4,
+					Size:    100,
+				},
+				Webhook: config.WebhookConfig{
+					Secret:                "",
+					Workers:               4,
+					QueueSize:             100,
+					MaxAttempts:           5,
+					InitialBackoffSeconds: 1,
+					MaxBackoffSeconds:     60,
+					RequestTimeoutSeconds: 10,
+					DeadLetterPath:        "./webhooks-dead-letter.ndjson",
+					Deny:                  "0.0.0.0/8,10.0.0.0/8,100.64.0.0/10,127.0.0.0/8,169.254.0.0/16,172.16.0.0/12,192.168.0.0/16,::/128,::1/128,fc00::/7,fe80::/10",
+				},
+				Batch: config.BatchConfig{
+					Parallelism: 8,
+					MaxSize:     100,
+				},
+				Auth: config.AuthConfig{
+					APIKeysPath:               "",
+					JWKSPath:                  "",
+					JWKSReloadIntervalSeconds: 10,
+					JWTIssuer:                 "",
+					JWTAudience:               "",
+					JWTLeewaySeconds:          30,
+				},
+				Tenant: config.TenantConfig{
+					TrustedHeader: "",
+					OverridesPath: "",
+				},
+				RateLimit: config.RateLimitConfig{
+					Key:        config.RateLimitKeyIP,
+					PerMinute:  0,
+					Burst:      10,
+					Operations: "",
+				},
+				Cost: config.CostConfig{
+					MaxRequestCost:      0,
+					TenantBudget:        0,
+					BudgetWindowSeconds: 3600,
+				},
+				TLS: config.TLSConfig{
+					CertPath:              "",
+					KeyPath:               "",
+					ClientCAPath:          "",
+					ReloadIntervalSeconds: 10,
+				},
+				SignedURL: config.SignedURLConfig{
+					Keys:              "",
+					DefaultTTLSeconds: 86400,
+					MaxTTLSeconds:     604800,
+					BaseURL:           "",
+				},
+				ClientIP: config.ClientIPConfig{
+					TrustedProxies: "",
+					Allow:          "",
+					Deny:           "",
+				},
+				Audit: config.AuditConfig{
+					LogPath:          "",
+					MaxSizeMegabytes: 100,
+					MaxBackups:       10,
+				},
 			},
 		},
 		{
@@ -142,9 +526,43 @@ func TestNewFromEnv(t *testing.T) {
 			},
 			config.Config{
 				Renderer: config.RendererConfig{
-					Address:               "dns:///localhost:54020",
-					ConnTimeoutSeconds:    5,
-					RequestTimeoutSeconds: 30,
+					Address:                         "dns:///localhost:54020",
+					ConnTimeoutSeconds:              5,
+					RequestTimeoutSeconds:           30,
+					TLS:                             false,
+					TLSCAPath:                       "",
+					TLSCertPath:                     "",
+					TLSKeyPath:                      "",
+					TLSServerName:                   "",
+					PoolsPath:                       "",
+					RetryMaxAttempts:                3,
+					RetryInitialBackoffMilliseconds: 50,
+					RetryMaxBackoffMilliseconds:     1000,
+					RetryCodes:                      "UNAVAILABLE",
+					RetryBudgetPercent:              20,
+					RetryBudgetBurst:                10,
+					HedgePercentile:                 0,
+					BreakerWindowSeconds:            10,
+					BreakerMinRequests:              20,
+					BreakerErrorPercent:             50,
+					BreakerSlowCallMilliseconds:     10000,
+					BreakerSlowPercent:              50,
+					BreakerOpenTimeoutSeconds:       30,
+					BreakerHalfOpenRequests:         3,
+					ProbeIntervalSeconds:            5,
+					ProbeTimeoutSeconds:             2,
+					ProbeKind:                       "render",
+					ProbeHealthService:              "",
+					ProbeHealthyThreshold:           2,
+					ProbeUnhealthyThreshold:         3,
+					LimitInitial:                    20,
+					LimitMin:                        2,
+					LimitMax:                        200,
+					LimitLatencyMilliseconds:        2000,
+					LimitBackoffPercent:             90,
+					LimitQueueSize:                  50,
+					LimitQueueTimeoutMilliseconds:   500,
+					LimitRetryAfterSeconds:          1,
 				},
 				GRPC: config.GRPCConfig{
 					Address:                "localhost:63010",
@@ -167,6 +585,81 @@ func TestNewFromEnv(t *testing.T) {
 					WriteTimeoutSeconds:    10,
 					IdleTimeoutSeconds:     120,
 				},
+				Storage: config.StorageConfig{
+					Kind:                    "memory",
+					Dir:                     "./charts",
+					PurgeGracePeriodSeconds: 86400,
+					PurgeIntervalSeconds:    60,
+					ChartTTLSeconds:         2592000,
+				},
+				RenderCache: config.RenderCacheConfig{
+					Size:       1000,
+					TTLSeconds: 3600,
+				},
+				RenderQueue: config.RenderQueueConfig{
+					Workers: 4,
+					Size:    100,
+				},
+				Webhook: config.WebhookConfig{
+					Secret:                "",
+					Workers:               4,
+					QueueSize:             100,
+					MaxAttempts:           5,
+					InitialBackoffSeconds: 1,
+					MaxBackoffSeconds:     60,
+					RequestTimeoutSeconds: 10,
+					DeadLetterPath:        "./webhooks-dead-letter.ndjson",
+					Deny:                  "0.0.0.0/8,10.0.0.0/8,100.64.0.0/10,127.0.0.0/8,169.254.0.0/16,172.16.0.0/12,192.168.0.0/16,::/128,::1/128,fc00::/7,fe80::/10",
+				},
+				Batch: config.BatchConfig{
+					Parallelism: 8,
+					MaxSize:     100,
+				},
+				Auth: config.AuthConfig{
+					APIKeysPath:               "",
+					JWKSPath:                  "",
+					JWKSReloadIntervalSeconds: 10,
+					JWTIssuer:                 "",
+					JWTAudience:               "",
+					JWTLeewaySeconds:          30,
+				},
+				Tenant: config.TenantConfig{
+					TrustedHeader: "",
+					OverridesPath: "",
+				},
+				RateLimit: config.RateLimitConfig{
+					Key:        config.RateLimitKeyIP,
+					PerMinute:  0,
+					Burst:      10,
+					Operations: "",
+				},
+				Cost: config.CostConfig{
+					MaxRequestCost:      0,
+					TenantBudget:        0,
+					BudgetWindowSeconds: 3600,
+				},
+				TLS: config.TLSConfig{
+					CertPath:              "",
+					KeyPath:               "",
+					ClientCAPath:          "",
+					ReloadIntervalSeconds: 10,
+				},
+				SignedURL: config.SignedURLConfig{
+					Keys:              "",
+					DefaultTTLSeconds: 86400,
+					MaxTTLSeconds:     604800,
+					BaseURL:           "",
+				},
+				ClientIP: config.ClientIPConfig{
+					TrustedProxies: "",
+					Allow:          "",
+					Deny:           "",
+				},
+				Audit: config.AuditConfig{
+					LogPath:          "",
+					MaxSizeMegabytes: 100,
+					MaxBackups:       10,
+				},
 			},
 		},
 		{
@@ -183,9 +676,43 @@ func TestNewFromEnv(t *testing.T) {
 			},
 			config.Config{
 				Renderer: config.RendererConfig{
-					Address:               "localhost:63040",
-					ConnTimeoutSeconds:    250,
-					RequestTimeoutSeconds: 300,
+					Address:                         "localhost:63040",
+					ConnTimeoutSeconds:              250,
+					RequestTimeoutSeconds:           300,
+					TLS:                             false,
+					TLSCAPath:                       "",
+					TLSCertPath:                     "",
+					TLSKeyPath:                      "",
+					TLSServerName:                   "",
+					PoolsPath:                       "",
+					RetryMaxAttempts:                3,
+					RetryInitialBackoffMilliseconds: 50,
+					RetryMaxBackoffMilliseconds:     1000,
+					RetryCodes:                      "UNAVAILABLE",
+					RetryBudgetPercent:              20,
+					RetryBudgetBurst:                10,
+					HedgePercentile:                 0,
+					BreakerWindowSeconds:            10,
+					BreakerMinRequests:              20,
+					BreakerErrorPercent:             50,
+					BreakerSlowCallMilliseconds:     10000,
+					BreakerSlowPercent:              50,
+					BreakerOpenTimeoutSeconds:       30,
+					BreakerHalfOpenRequests:         3,
+					ProbeIntervalSeconds:            5,
+					ProbeTimeoutSeconds:             2,
+					ProbeKind:                       "render",
+					ProbeHealthService:              "",
+					ProbeHealthyThreshold:           2,
+					ProbeUnhealthyThreshold:         3,
+					LimitInitial:                    20,
+					LimitMin:                        2,
+					LimitMax:                        200,
+					LimitLatencyMilliseconds:        2000,
+					LimitBackoffPercent:             90,
+					LimitQueueSize:                  50,
+					LimitQueueTimeoutMilliseconds:   500,
+					LimitRetryAfterSeconds:          1,
 				},
 				GRPC: config.GRPCConfig{
 					Address:                "0.0.0.0:54010",
@@ -208,6 +735,81 @@ func TestNewFromEnv(t *testing.T) {
 					WriteTimeoutSeconds:    10,
 					IdleTimeoutSeconds:     120,
 				},
+				Storage: config.StorageConfig{
+					Kind:                    "memory",
+					Dir:                     "./charts",
+					PurgeGracePeriodSeconds: 86400,
+					PurgeIntervalSeconds:    60,
+					ChartTTLSeconds:         2592000,
+				},
+				RenderCache: config.RenderCacheConfig{
+					Size:       1000,
+					TTLSeconds: 3600,
+				},
+				RenderQueue: config.RenderQueueConfig{
+					Workers: 4,
+					Size:    100,
+				},
+				Webhook: config.WebhookConfig{
+					Secret:                "",
+					Workers:               4,
+					QueueSize:             100,
+					MaxAttempts:           5,
+					InitialBackoffSeconds: 1,
+					MaxBackoffSeconds:     60,
+					RequestTimeoutSeconds: 10,
+					DeadLetterPath:        "./webhooks-dead-letter.ndjson",
+					Deny:                  "0.0.0.0/8,10.0.0.0/8,100.64.0.0/10,127.0.0.0/8,169.254.0.0/16,172.16.0.0/12,192.168.0.0/16,::/128,::1/128,fc00::/7,fe80::/10",
+				},
+				Batch: config.BatchConfig{
+					Parallelism: 8,
+					MaxSize:     100,
+				},
+				Auth: config.AuthConfig{
+					APIKeysPath:               "",
+					JWKSPath:                  "",
+					JWKSReloadIntervalSeconds: 10,
+					JWTIssuer:                 "",
+					JWTAudience:               "",
+					JWTLeewaySeconds:          30,
+				},
+				Tenant: config.TenantConfig{
+					TrustedHeader: "",
+					OverridesPath: "",
+				},
+				RateLimit: config.RateLimitConfig{
+					Key:        config.RateLimitKeyIP,
+					PerMinute:  0,
+					Burst:      10,
+					Operations: "",
+				},
+				Cost: config.CostConfig{
+					MaxRequestCost:      0,
+					TenantBudget:        0,
+					BudgetWindowSeconds: 3600,
+				},
+				TLS: config.TLSConfig{
+					CertPath:              "",
+					KeyPath:               "",
+					ClientCAPath:          "",
+					ReloadIntervalSeconds: 10,
+				},
+				SignedURL: config.SignedURLConfig{
+					Keys:              "",
+					DefaultTTLSeconds: 86400,
+					MaxTTLSeconds:     604800,
+					BaseURL:           "",
+				},
+				ClientIP: config.ClientIPConfig{
+					TrustedProxies: "",
+					Allow:          "",
+					Deny:           "",
+				},
+				Audit: config.AuditConfig{
+					LogPath:          "",
+					MaxSizeMegabytes: 100,
+					MaxBackups:       10,
+				},
 			},
 		},
 		{
@@ -216,9 +818,43 @@ func TestNewFromEnv(t *testing.T) {
 			nil,
 			config.Config{
 				Renderer: config.RendererConfig{
-					Address:               "dns:///localhost:54020",
-					ConnTimeoutSeconds:    5,
-					RequestTimeoutSeconds: 30,
+					Address:                         "dns:///localhost:54020",
+					ConnTimeoutSeconds:              5,
+					RequestTimeoutSeconds:           30,
+					TLS:                             false,
+					TLSCAPath:                       "",
+					TLSCertPath:                     "",
+					TLSKeyPath:                      "",
+					TLSServerName:                   "",
+					PoolsPath:                       "",
+					RetryMaxAttempts:                3,
+					RetryInitialBackoffMilliseconds: 50,
+					RetryMaxBackoffMilliseconds:     1000,
+					RetryCodes:                      "UNAVAILABLE",
+					RetryBudgetPercent:              20,
+					RetryBudgetBurst:                10,
+					HedgePercentile:                 0,
+					BreakerWindowSeconds:            10,
+					BreakerMinRequests:              20,
+					BreakerErrorPercent:             50,
+					BreakerSlowCallMilliseconds:     10000,
+					BreakerSlowPercent:              50,
+					BreakerOpenTimeoutSeconds:       30,
+					BreakerHalfOpenRequests:         3,
+					ProbeIntervalSeconds:            5,
+					ProbeTimeoutSeconds:             2,
+					ProbeKind:                       "render",
+					ProbeHealthService:              "",
+					ProbeHealthyThreshold:           2,
+					ProbeUnhealthyThreshold:         3,
+					LimitInitial:                    20,
+					LimitMin:                        2,
+					LimitMax:                        200,
+					LimitLatencyMilliseconds:        2000,
+					LimitBackoffPercent:             90,
+					LimitQueueSize:                  50,
+					LimitQueueTimeoutMilliseconds:   500,
+					LimitRetryAfterSeconds:          1,
 				},
 				GRPC: config.GRPCConfig{
 					Address:                "0.0.0.0:54010",
@@ -241,6 +877,81 @@ func TestNewFromEnv(t *testing.T) {
 					WriteTimeoutSeconds:    10,
 					IdleTimeoutSeconds:     120,
 				},
+				Storage: config.StorageConfig{
+					Kind:                    "memory",
+					Dir:                     "./charts",
+					PurgeGracePeriodSeconds: 86400,
+					PurgeIntervalSeconds:    60,
+					ChartTTLSeconds:         2592000,
+				},
+				RenderCache: config.RenderCacheConfig{
+					Size:       1000,
+					TTLSeconds: 3600,
+				},
+				RenderQueue: config.RenderQueueConfig{
+					Workers: 4,
+					Size:    100,
+				},
+				Webhook: config.WebhookConfig{
+					Secret:                "",
+					Workers:               4,
+					QueueSize:             100,
+					MaxAttempts:           5,
+					InitialBackoffSeconds: 1,
+					MaxBackoffSeconds:     60,
+					RequestTimeoutSeconds: 10,
+					DeadLetterPath:        "./webhooks-dead-letter.ndjson",
+					Deny:                  "0.0.0.0/8,10.0.0.0/8,100.64.0.0/10,127.0.0.0/8,169.254.0.0/16,172.16.0.0/12,192.168.0.0/16,::/128,::1/128,fc00::/7,fe80::/10",
+				},
+				Batch: config.BatchConfig{
+					Parallelism: 8,
+					MaxSize:     100,
+				},
+				Auth: config.AuthConfig{
+					APIKeysPath:               "",
+					JWKSPath:                  "",
+					JWKSReloadIntervalSeconds: 10,
+					JWTIssuer:                 "",
+					JWTAudience:               "",
+					JWTLeewaySeconds:          30,
+				},
+				Tenant: config.TenantConfig{
+					TrustedHeader: "",
+					OverridesPath: "",
+				},
+				RateLimit: config.RateLimitConfig{
+					Key:        config.RateLimitKeyIP,
+					PerMinute:  0,
+					Burst:      10,
+					Operations: "",
+				},
+				Cost: config.CostConfig{
+					MaxRequestCost:      0,
+					TenantBudget:        0,
+					BudgetWindowSeconds: 3600,
+				},
+				TLS: config.TLSConfig{
+					CertPath:              "",
+					KeyPath:               "",
+					ClientCAPath:          "",
+					ReloadIntervalSeconds: 10,
+				},
+				SignedURL: config.SignedURLConfig{
+					Keys:              "",
+					DefaultTTLSeconds: 86400,
+					MaxTTLSeconds:     604800,
+					BaseURL:           "",
+				},
+				ClientIP: config.ClientIPConfig{
+					TrustedProxies: "",
+					Allow:          "",
+					Deny:           "",
+				},
+				Audit: config.AuditConfig{
+					LogPath:          "",
+					MaxSizeMegabytes: 100,
+					MaxBackups:       10,
+				},
 			},
 		},
 		{
@@ -255,9 +966,43 @@ func TestNewFromEnv(t *testing.T) {
 			},
 			config.Config{
 				Renderer: config.RendererConfig{
-					Address:               "dns:///localhost:54020",
-					ConnTimeoutSeconds:    5,
-					RequestTimeoutSeconds: 30,
+					Address:                         "dns:///localhost:54020",
+					ConnTimeoutSeconds:              5,
+					RequestTimeoutSeconds:           30,
+					TLS:                             false,
+					TLSCAPath:                       "",
+					TLSCertPath:                     "",
+					TLSKeyPath:                      "",
+					TLSServerName:                   "",
+					PoolsPath:                       "",
+					RetryMaxAttempts:                3,
+					RetryInitialBackoffMilliseconds: 50,
+					RetryMaxBackoffMilliseconds:     1000,
+					RetryCodes:                      "UNAVAILABLE",
+					RetryBudgetPercent:              20,
+					RetryBudgetBurst:                10,
+					HedgePercentile:                 0,
+					BreakerWindowSeconds:            10,
+					BreakerMinRequests:              20,
+					BreakerErrorPercent:             50,
+					BreakerSlowCallMilliseconds:     10000,
+					BreakerSlowPercent:              50,
+					BreakerOpenTimeoutSeconds:       30,
+					BreakerHalfOpenRequests:         3,
+					ProbeIntervalSeconds:            5,
+					ProbeTimeoutSeconds:             2,
+					ProbeKind:                       "render",
+					ProbeHealthService:              "",
+					ProbeHealthyThreshold:           2,
+					ProbeUnhealthyThreshold:         3,
+					LimitInitial:                    20,
+					LimitMin:                        2,
+					LimitMax:                        200,
+					LimitLatencyMilliseconds:        2000,
+					LimitBackoffPercent:             90,
+					LimitQueueSize:                  50,
+					LimitQueueTimeoutMilliseconds:   500,
+					LimitRetryAfterSeconds:          1,
 				},
 				GRPC: config.GRPCConfig{
 					Address:                "0.0.0.0:54010",
@@ -280,6 +1025,81 @@ func TestNewFromEnv(t *testing.T) {
 					WriteTimeoutSeconds:    10,
 					IdleTimeoutSeconds:     120,
 				},
+				Storage: config.StorageConfig{
+					Kind:                    "memory",
+					Dir:                     "./charts",
+					PurgeGracePeriodSeconds: 86400,
+					PurgeIntervalSeconds:    60,
+					ChartTTLSeconds:         2592000,
+				},
+				RenderCache: config.RenderCacheConfig{
+					Size:       1000,
+					TTLSeconds: 3600,
+				},
+				RenderQueue: config.RenderQueueConfig{
+					Workers: 4,
+					Size:    100,
+				},
+				Webhook: config.WebhookConfig{
+					Secret:                "",
+					Workers:               4,
+					QueueSize:             100,
+					MaxAttempts:           5,
+					InitialBackoffSeconds: 1,
+					MaxBackoffSeconds:     60,
+					RequestTimeoutSeconds: 10,
+					DeadLetterPath:        "./webhooks-dead-letter.ndjson",
+					Deny:                  "0.0.0.0/8,10.0.0.0/8,100.64.0.0/10,127.0.0.0/8,169.254.0.0/16,172.16.0.0/12,192.168.0.0/16,::/128,::1/128,fc00::/7,fe80::/10",
+				},
+				Batch: config.BatchConfig{
+					Parallelism: 8,
+					MaxSize:     100,
+				},
+				Auth: config.AuthConfig{
+					APIKeysPath:               "",
+					JWKSPath:                  "",
+					JWKSReloadIntervalSeconds: 10,
+					JWTIssuer:                 "",
+					JWTAudience:               "",
+					JWTLeewaySeconds:          30,
+				},
+				Tenant: config.TenantConfig{
+					TrustedHeader: "",
+					OverridesPath: "",
+				},
+				RateLimit: config.RateLimitConfig{
+					Key:        config.RateLimitKeyIP,
+					PerMinute:  0,
+					Burst:      10,
+					Operations: "",
+				},
+				Cost: config.CostConfig{
+					MaxRequestCost:      0,
+					TenantBudget:        0,
+					BudgetWindowSeconds: 3600,
+				},
+				TLS: config.TLSConfig{
+					CertPath:              "",
+					KeyPath:               "",
+					ClientCAPath:          "",
+					ReloadIntervalSeconds: 10,
+				},
+				SignedURL: config.SignedURLConfig{
+					Keys:              "",
+					DefaultTTLSeconds: 86400,
+					MaxTTLSeconds:     604800,
+					BaseURL:           "",
+				},
+				ClientIP: config.ClientIPConfig{
+					TrustedProxies: "",
+					Allow:          "",
+					Deny:           "",
+				},
+				Audit: config.AuditConfig{
+					LogPath:          "",
+					MaxSizeMegabytes: 100,
+					MaxBackups:       10,
+				},
 			},
 		},
 	}
@@ -0,0 +1,59 @@
+package convert
+
+import (
+	"encoding/base64"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/view"
+)
+
+// ChartReplyToJSON converts ChartReply to its JSON representation.
+func ChartReplyToJSON(chartReply *render.ChartReply) *view.ChartReply {
+	createdAt := chartReply.CreatedAt.AsTime()
+
+	chartData := string(chartReply.ChartData)
+	if len(chartReply.ChartData) != 0 {
+		chartData = base64.StdEncoding.EncodeToString(chartReply.ChartData)
+	}
+
+	return &view.ChartReply{
+		RequestID:    chartReply.RequestId,
+		ChartID:      chartReply.ChartId,
+		ChartStatus:  chartStatusToJSON(chartReply.ChartStatus).String(),
+		CreatedAt:    &createdAt,
+		DeletedAt:    timestampToJSON(chartReply.DeletedAt),
+		ChartData:    chartData,
+		Title:        chartReply.Title,
+		ExpiresAt:    timestampToJSON(chartReply.ExpiresAt),
+		ErrorMessage: chartReply.ErrorMessage,
+		Tenant:       chartReply.Tenant,
+		Cost:         chartReply.Cost,
+	}
+}
+
+// timestampToJSON converts an optional protobuf timestamp to a nullable JSON one.
+func timestampToJSON(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+
+	res := ts.AsTime()
+
+	return &res
+}
+
+func chartStatusToJSON(chartStatus render.ChartStatus) view.ChartStatus {
+	switch chartStatus {
+	case render.ChartStatus_CREATED:
+		return view.ChartStatusCreated
+	case render.ChartStatus_DELETED:
+		return view.ChartStatusDeleted
+	case render.ChartStatus_PENDING:
+		return view.ChartStatusPending
+	default:
+		return view.ChartStatusError
+	}
+}
@@ -0,0 +1,89 @@
+package convert_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/limpidchart/lc-api/internal/convert"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/view"
+)
+
+func TestChartReplyToJSON(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2021, 8, 22, 10, 20, 30, 0, time.UTC)
+	expiresAt := createdAt.Add(time.Hour)
+
+	tt := []struct {
+		name       string
+		chartReply *render.ChartReply
+		expected   *view.ChartReply
+	}{
+		{
+			"created",
+			&render.ChartReply{
+				RequestId:   "req_id_1",
+				ChartId:     "chart_id_1",
+				ChartStatus: render.ChartStatus_CREATED,
+				CreatedAt:   timestamppb.New(createdAt),
+				ChartData:   []byte("<svg>chart</svg>"),
+				Title:       "chart_title_1",
+				ExpiresAt:   timestamppb.New(expiresAt),
+			},
+			&view.ChartReply{
+				RequestID:   "req_id_1",
+				ChartID:     "chart_id_1",
+				ChartStatus: view.ChartStatusCreated.String(),
+				CreatedAt:   &createdAt,
+				ChartData:   "PHN2Zz5jaGFydDwvc3ZnPg==",
+				Title:       "chart_title_1",
+				ExpiresAt:   &expiresAt,
+			},
+		},
+		{
+			"pending",
+			&render.ChartReply{
+				RequestId:   "req_id_2",
+				ChartId:     "chart_id_2",
+				ChartStatus: render.ChartStatus_PENDING,
+				CreatedAt:   timestamppb.New(createdAt),
+			},
+			&view.ChartReply{
+				RequestID:   "req_id_2",
+				ChartID:     "chart_id_2",
+				ChartStatus: view.ChartStatusPending.String(),
+				CreatedAt:   &createdAt,
+			},
+		},
+		{
+			"failed",
+			&render.ChartReply{
+				RequestId:    "req_id_3",
+				ChartId:      "chart_id_3",
+				ChartStatus:  render.ChartStatus_ERROR,
+				CreatedAt:    timestamppb.New(createdAt),
+				ErrorMessage: "bad chart",
+			},
+			&view.ChartReply{
+				RequestID:    "req_id_3",
+				ChartID:      "chart_id_3",
+				ChartStatus:  view.ChartStatusError.String(),
+				CreatedAt:    &createdAt,
+				ErrorMessage: "bad chart",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, convert.ChartReplyToJSON(tc.chartReply))
+		})
+	}
+}
@@ -8,13 +8,14 @@ import (
 )
 
 // CreateChartRequestToRenderChartRequest validates CreateChartRequest to RenderChartRequest.
-func CreateChartRequestToRenderChartRequest(request *render.CreateChartRequest) (*render.RenderChartRequest, error) {
-	chartSizes, err := apitorenderer.ValidateChartSizes(request.Sizes)
+// Chart sizes are checked against the provided limits and default margins are taken from them.
+func CreateChartRequestToRenderChartRequest(request *render.CreateChartRequest, limits apitorenderer.Limits) (*render.RenderChartRequest, error) {
+	chartSizes, err := apitorenderer.ValidateChartSizesWithLimits(request.Sizes, limits)
 	if err != nil {
 		return nil, fmt.Errorf("unable to validate chart sizes: %w", err)
 	}
 
-	chartMargins, err := apitorenderer.ValidateChartMargins(request.Margins)
+	chartMargins, err := apitorenderer.ValidateChartMarginsWithLimits(request.Margins, limits)
 	if err != nil {
 		return nil, fmt.Errorf("unable to validate chart margins: %w", err)
 	}
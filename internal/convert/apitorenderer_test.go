@@ -1,6 +1,7 @@
 package convert_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,6 +10,7 @@ import (
 	"github.com/limpidchart/lc-api/internal/convert"
 	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
 	"github.com/limpidchart/lc-api/internal/testutils"
+	"github.com/limpidchart/lc-api/internal/validate/apitorenderer"
 )
 
 func TestCreateChartRequestToRenderChartRequest(t *testing.T) {
@@ -55,7 +57,45 @@ func TestCreateChartRequestToRenderChartRequest(t *testing.T) {
 			AddVerticalBarView().
 			AddView(testutils.NewLineView().SetDefaultColors().SetFillAndStrokeColor().Unembed()).
 			Unembed(),
+		apitorenderer.Limits{},
 	)
 	assert.NoError(t, err)
 	assert.Equal(t, expected, actual)
 }
+
+func TestCreateChartRequestToRenderChartRequest_Limits(t *testing.T) {
+	t.Parallel()
+
+	newRequest := func() *render.CreateChartRequest {
+		req := testutils.NewCreateChartRequest().
+			SetSizes().
+			SetBandBottomAxis().
+			SetLinearLeftAxis().
+			AddVerticalBarView().
+			Unembed()
+		req.Margins = &render.ChartMargins{}
+
+		return req
+	}
+
+	limits := apitorenderer.Limits{
+		MaxWidth:         1000,
+		MarginTopDefault: &wrapperspb.Int32Value{Value: 5},
+	}
+
+	actual, err := convert.CreateChartRequestToRenderChartRequest(newRequest(), limits)
+	assert.NoError(t, err)
+	assert.Equal(t, &render.ChartMargins{
+		MarginTop:    &wrapperspb.Int32Value{Value: 5},
+		MarginBottom: &wrapperspb.Int32Value{Value: 50},
+		MarginLeft:   &wrapperspb.Int32Value{Value: 60},
+		MarginRight:  &wrapperspb.Int32Value{Value: 40},
+	}, actual.Margins)
+
+	limits.MaxWidth = 900
+
+	actual, err = convert.CreateChartRequestToRenderChartRequest(newRequest(), limits)
+	assert.Nil(t, actual)
+	assert.True(t, errors.Is(err, apitorenderer.ErrChartSizeExceedsLimit))
+	assert.EqualError(t, err, "unable to validate chart sizes: chart size exceeds the limit: max width is 900")
+}
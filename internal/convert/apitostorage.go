@@ -0,0 +1,30 @@
+package convert
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/storage"
+)
+
+// ListChartsRequestToListOpts converts ListChartsRequest to storage.ListOpts.
+func ListChartsRequestToListOpts(request *render.ListChartsRequest) storage.ListOpts {
+	return storage.ListOpts{
+		PageSize:      int(request.PageSize),
+		PageToken:     request.PageToken,
+		CreatedAfter:  timestampToTime(request.CreatedAfter),
+		CreatedBefore: timestampToTime(request.CreatedBefore),
+		ChartStatus:   request.ChartStatus,
+		TitleContains: request.TitleContains,
+	}
+}
+
+func timestampToTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+
+	return ts.AsTime()
+}
@@ -0,0 +1,59 @@
+package convert_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/limpidchart/lc-api/internal/convert"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/storage"
+)
+
+func TestListChartsRequestToListOpts(t *testing.T) {
+	t.Parallel()
+
+	createdAfter := time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)
+	createdBefore := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name     string
+		request  *render.ListChartsRequest
+		expected storage.ListOpts
+	}{
+		{
+			"empty",
+			&render.ListChartsRequest{},
+			storage.ListOpts{},
+		},
+		{
+			"all_is_set",
+			&render.ListChartsRequest{
+				PageSize:      10,
+				PageToken:     "token",
+				CreatedAfter:  timestamppb.New(createdAfter),
+				CreatedBefore: timestamppb.New(createdBefore),
+				ChartStatus:   render.ChartStatus_CREATED,
+				TitleContains: "sales",
+			},
+			storage.ListOpts{
+				PageSize:      10,
+				PageToken:     "token",
+				CreatedAfter:  createdAfter,
+				CreatedBefore: createdBefore,
+				ChartStatus:   render.ChartStatus_CREATED,
+				TitleContains: "sales",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, convert.ListChartsRequestToListOpts(tc.request))
+		})
+	}
+}
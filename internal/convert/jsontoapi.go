@@ -3,7 +3,9 @@ package convert
 import (
 	"errors"
 	"fmt"
+	"time"
 
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
@@ -31,14 +33,24 @@ func JSONToCreateChartRequest(reqJSON *view.CreateChartRequest) (*render.CreateC
 	}
 
 	return &render.CreateChartRequest{
-		Title:   reqJSON.Chart.Title,
-		Sizes:   chartSizesFromJSON(reqJSON.Chart.Sizes),
-		Margins: chartMarginsFromJSON(reqJSON.Chart.Margins),
-		Axes:    chartAxes,
-		Views:   chartViews,
+		Title:       reqJSON.Chart.Title,
+		Sizes:       chartSizesFromJSON(reqJSON.Chart.Sizes),
+		Margins:     chartMarginsFromJSON(reqJSON.Chart.Margins),
+		Axes:        chartAxes,
+		Views:       chartViews,
+		ExpiresIn:   expiresInFromJSON(reqJSON.Chart.ExpiresIn),
+		CallbackUrl: reqJSON.Chart.CallbackURL,
 	}, nil
 }
 
+func expiresInFromJSON(expiresIn *int) *durationpb.Duration {
+	if expiresIn == nil {
+		return nil
+	}
+
+	return durationpb.New(time.Duration(*expiresIn) * time.Second)
+}
+
 func chartSizesFromJSON(sizes *view.ChartSizes) *render.ChartSizes {
 	if sizes == nil {
 		return nil
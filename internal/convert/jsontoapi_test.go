@@ -2,8 +2,10 @@ package convert_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/limpidchart/lc-api/internal/convert"
@@ -40,6 +42,7 @@ func TestJSONToCreateChartRequest(t *testing.T) {
 			testutils.NewVerticalBarView().SetDefaultPointBools().Unembed(),
 			testutils.NewLineView().SetDefaultBarBools().Unembed(),
 		},
+		ExpiresIn: durationpb.New(time.Minute),
 	}
 
 	actual, err := convert.JSONToCreateChartRequest(
@@ -53,6 +56,7 @@ func TestJSONToCreateChartRequest(t *testing.T) {
 			SetLeftAxisLabel().
 			AddVerticalBarView().
 			AddLineView().
+			SetExpiresIn(60).
 			Unembed(),
 	)
 	assert.NoError(t, err)
@@ -18,6 +18,7 @@ func TestRenderChartReplyToAPIChartReply(t *testing.T) {
 	reqID := uuid.New().String()
 	chartID := uuid.New().String()
 	now := time.Now().UTC()
+	title := "Chart title"
 	data := []byte("svg data")
 	renderChartRep := &render.RenderChartReply{
 		RequestId: reqID,
@@ -29,10 +30,10 @@ func TestRenderChartReplyToAPIChartReply(t *testing.T) {
 		ChartId:     chartID,
 		ChartStatus: render.ChartStatus_CREATED,
 		CreatedAt:   timestamppb.New(now),
-		DeletedAt:   timestamppb.New(now),
 		ChartData:   data,
+		Title:       title,
 	}
 
-	actual := convert.RenderChartReplyToAPIChartReply(reqID, chartID, now, renderChartRep)
+	actual := convert.RenderChartReplyToAPIChartReply(reqID, chartID, title, now, renderChartRep)
 	assert.Equal(t, expected, actual)
 }
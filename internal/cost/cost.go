@@ -0,0 +1,213 @@
+package cost
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+const (
+	// pixelsPerUnit is the chart area that costs a single unit, it's a 100x100 square.
+	pixelsPerUnit = 10_000
+
+	// viewCost is the cost of every chart view.
+	viewCost = 10
+
+	// valueCost is the cost of every scalar value, point or bar.
+	valueCost = 1
+
+	// budgetSlots is the number of slots the budget window is split into.
+	budgetSlots = 60
+)
+
+var (
+	// ErrCostExceedsLimit contains error message about chart that costs more than a single request is allowed to.
+	ErrCostExceedsLimit = errors.New("chart render cost exceeds the limit")
+
+	// ErrBudgetExhausted contains error message about tenant that has spent its render cost budget.
+	ErrBudgetExhausted = errors.New("render cost budget is exhausted")
+)
+
+// Limits represents render cost limits.
+// Zero values mean that the cost is not limited.
+type Limits struct {
+	MaxRequestCost int64
+	Budget         int64
+}
+
+// Accountant computes render cost of the requests and charges it from the rolling budgets of tenants.
+type Accountant struct {
+	limits       Limits
+	window       time.Duration
+	slotDuration time.Duration
+	renderCost   prometheus.Histogram
+
+	mu        sync.Mutex
+	spendings map[string]*spending
+}
+
+// spending contains costs spent by a tenant during the window split into slots.
+type spending struct {
+	slots    [budgetSlots]int64
+	lastSlot int64
+	total    int64
+}
+
+// NewAccountant configures a new Accountant.
+func NewAccountant(costCfg config.CostConfig, pRec metric.PromRecorder) *Accountant {
+	window := time.Duration(costCfg.BudgetWindowSeconds) * time.Second
+
+	return &Accountant{
+		limits: Limits{
+			MaxRequestCost: int64(costCfg.MaxRequestCost),
+			Budget:         int64(costCfg.TenantBudget),
+		},
+		window:       window,
+		slotDuration: window / budgetSlots,
+		renderCost:   pRec.RenderCost(),
+		spendings:    make(map[string]*spending),
+	}
+}
+
+// Score returns cost of the validated render request.
+// Every 100x100 pixels of the chart area cost a unit, every view costs 10 units and every value, point or bar costs a unit.
+func Score(req *render.RenderChartRequest) int64 {
+	area := int64(req.GetSizes().GetWidth().GetValue()) * int64(req.GetSizes().GetHeight().GetValue())
+	score := (area + pixelsPerUnit - 1) / pixelsPerUnit
+
+	for _, view := range req.GetViews() {
+		score += viewCost + valueCost*int64(valuesCount(view))
+	}
+
+	if score < 1 {
+		return 1
+	}
+
+	return score
+}
+
+// Price computes cost of the validated render request and checks it against the max request cost.
+// Limits override the configured ones if they are set.
+// It returns ErrCostExceedsLimit if the request can't be rendered.
+func (a *Accountant) Price(req *render.RenderChartRequest, limits Limits) (int64, error) {
+	score := Score(req)
+
+	a.renderCost.Observe(float64(score))
+
+	maxRequestCost := valueOrDefault(limits.MaxRequestCost, a.limits.MaxRequestCost)
+	if maxRequestCost > 0 && score > maxRequestCost {
+		return score, fmt.Errorf("%w: cost is %d, max cost is %d", ErrCostExceedsLimit, score, maxRequestCost)
+	}
+
+	return score, nil
+}
+
+// Charge charges the cost computed by Price from the tenant budget.
+// Limits override the configured ones if they are set.
+// It returns ErrBudgetExhausted if the budget can't fit the cost, nothing is charged in this case.
+func (a *Accountant) Charge(tenant string, cost int64, limits Limits) error {
+	budget := valueOrDefault(limits.Budget, a.limits.Budget)
+	if budget == 0 || a.slotDuration <= 0 {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	s, ok := a.spendings[tenant]
+	if !ok {
+		s = &spending{}
+		a.spendings[tenant] = s
+	}
+
+	s.advance(time.Now().UnixNano() / int64(a.slotDuration))
+
+	if s.total+cost > budget {
+		return fmt.Errorf("%w: %d of %d is spent in the last %s", ErrBudgetExhausted, s.total, budget, a.window)
+	}
+
+	s.slots[s.lastSlot%budgetSlots] += cost
+	s.total += cost
+
+	return nil
+}
+
+// Refund returns the cost of the request that is charged but not rendered to the tenant budget.
+// Requests fail shortly after they are charged, so the cost is taken back from the most recent slots of the window.
+func (a *Accountant) Refund(tenant string, cost int64) {
+	if cost <= 0 || a.slotDuration <= 0 {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	s, ok := a.spendings[tenant]
+	if !ok {
+		return
+	}
+
+	s.advance(time.Now().UnixNano() / int64(a.slotDuration))
+
+	for i := int64(0); i < budgetSlots && cost > 0; i++ {
+		slot := &s.slots[(s.lastSlot-i)%budgetSlots]
+
+		refund := cost
+		if *slot < refund {
+			refund = *slot
+		}
+
+		*slot -= refund
+		s.total -= refund
+		cost -= refund
+	}
+}
+
+// advance moves the spending to the slot and forgets costs of the slots that left the window.
+func (s *spending) advance(slot int64) {
+	if slot-s.lastSlot >= budgetSlots {
+		s.slots = [budgetSlots]int64{}
+		s.total = 0
+	} else {
+		for i := s.lastSlot + 1; i <= slot; i++ {
+			s.total -= s.slots[i%budgetSlots]
+			s.slots[i%budgetSlots] = 0
+		}
+	}
+
+	s.lastSlot = slot
+}
+
+func valuesCount(view *render.ChartView) int {
+	switch values := view.GetValues().(type) {
+	case *render.ChartView_ScalarValues:
+		return len(values.ScalarValues.GetValues())
+	case *render.ChartView_PointsValues:
+		return len(values.PointsValues.GetPoints())
+	case *render.ChartView_BarsValues:
+		count := 0
+
+		for _, dataset := range values.BarsValues.GetBarsDatasets() {
+			count += len(dataset.GetValues())
+		}
+
+		return count
+	default:
+		return 0
+	}
+}
+
+func valueOrDefault(val, def int64) int64 {
+	if val > 0 {
+		return val
+	}
+
+	return def
+}
@@ -0,0 +1,199 @@
+package cost_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/cost"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+func newRenderChartRequest(width, height int32, views ...*render.ChartView) *render.RenderChartRequest {
+	return &render.RenderChartRequest{
+		Sizes: &render.ChartSizes{
+			Width:  &wrapperspb.Int32Value{Value: width},
+			Height: &wrapperspb.Int32Value{Value: height},
+		},
+		Views: views,
+	}
+}
+
+func scalarView(count int) *render.ChartView {
+	return &render.ChartView{
+		Values: &render.ChartView_ScalarValues{
+			ScalarValues: &render.ChartViewScalarValues{Values: make([]float32, count)},
+		},
+	}
+}
+
+func pointsView(count int) *render.ChartView {
+	return &render.ChartView{
+		Values: &render.ChartView_PointsValues{
+			PointsValues: &render.ChartViewPointsValues{Points: make([]*render.ChartViewPointsValues_Point, count)},
+		},
+	}
+}
+
+func barsView(counts ...int) *render.ChartView {
+	datasets := make([]*render.ChartViewBarsValues_BarsDataset, 0, len(counts))
+	for _, count := range counts {
+		datasets = append(datasets, &render.ChartViewBarsValues_BarsDataset{Values: make([]float32, count)})
+	}
+
+	return &render.ChartView{
+		Values: &render.ChartView_BarsValues{
+			BarsValues: &render.ChartViewBarsValues{BarsDatasets: datasets},
+		},
+	}
+}
+
+func TestScore(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		req      *render.RenderChartRequest
+		expected int64
+	}{
+		{
+			"empty",
+			&render.RenderChartRequest{},
+			1,
+		},
+		{
+			"area_only",
+			newRenderChartRequest(800, 600),
+			48,
+		},
+		{
+			"area_rounded_up",
+			newRenderChartRequest(101, 100),
+			2,
+		},
+		{
+			"scalar_values",
+			newRenderChartRequest(100, 100, scalarView(5)),
+			16,
+		},
+		{
+			"points_values",
+			newRenderChartRequest(100, 100, pointsView(7)),
+			18,
+		},
+		{
+			"bars_values",
+			newRenderChartRequest(100, 100, barsView(3, 4)),
+			18,
+		},
+		{
+			"multiple_views",
+			newRenderChartRequest(200, 100, scalarView(2), pointsView(3), barsView(4)),
+			41,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, cost.Score(tc.req))
+		})
+	}
+}
+
+func TestAccountant_Price(t *testing.T) {
+	t.Parallel()
+
+	accountant := cost.NewAccountant(config.CostConfig{MaxRequestCost: 20, BudgetWindowSeconds: 3600}, metric.NewEmptyRecorder())
+
+	score, err := accountant.Price(newRenderChartRequest(100, 100, scalarView(5)), cost.Limits{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(16), score)
+
+	score, err = accountant.Price(newRenderChartRequest(100, 100, scalarView(10)), cost.Limits{})
+	assert.Equal(t, int64(21), score)
+	assert.True(t, errors.Is(err, cost.ErrCostExceedsLimit))
+	assert.EqualError(t, err, "chart render cost exceeds the limit: cost is 21, max cost is 20")
+
+	// Tenant limits override the configured ones.
+	_, err = accountant.Price(newRenderChartRequest(100, 100, scalarView(10)), cost.Limits{MaxRequestCost: 30})
+	assert.NoError(t, err)
+}
+
+func TestAccountant_Charge_Budget(t *testing.T) {
+	t.Parallel()
+
+	accountant := cost.NewAccountant(config.CostConfig{TenantBudget: 40, BudgetWindowSeconds: 3600}, metric.NewEmptyRecorder())
+	score := cost.Score(newRenderChartRequest(100, 100, scalarView(5)))
+
+	for i := 0; i < 2; i++ {
+		err := accountant.Charge("acme", score, cost.Limits{})
+		assert.NoError(t, err)
+	}
+
+	err := accountant.Charge("acme", score, cost.Limits{})
+	assert.True(t, errors.Is(err, cost.ErrBudgetExhausted))
+	assert.EqualError(t, err, "render cost budget is exhausted: 32 of 40 is spent in the last 1h0m0s")
+
+	// Rejected requests aren't charged, so cheaper requests still fit into the budget.
+	err = accountant.Charge("acme", cost.Score(newRenderChartRequest(100, 100)), cost.Limits{})
+	assert.NoError(t, err)
+
+	// Every tenant has its own budget.
+	err = accountant.Charge("globex", score, cost.Limits{})
+	assert.NoError(t, err)
+
+	// Tenant limits override the configured ones.
+	err = accountant.Charge("initech", score, cost.Limits{Budget: 10})
+	assert.True(t, errors.Is(err, cost.ErrBudgetExhausted))
+}
+
+func TestAccountant_Refund(t *testing.T) {
+	t.Parallel()
+
+	accountant := cost.NewAccountant(config.CostConfig{TenantBudget: 40, BudgetWindowSeconds: 3600}, metric.NewEmptyRecorder())
+	score := cost.Score(newRenderChartRequest(100, 100, scalarView(5)))
+
+	for i := 0; i < 2; i++ {
+		err := accountant.Charge("acme", score, cost.Limits{})
+		assert.NoError(t, err)
+	}
+
+	err := accountant.Charge("acme", score, cost.Limits{})
+	assert.True(t, errors.Is(err, cost.ErrBudgetExhausted))
+
+	// Refunded cost of the failed request can be spent again.
+	accountant.Refund("acme", 16)
+
+	err = accountant.Charge("acme", score, cost.Limits{})
+	assert.NoError(t, err)
+
+	// Refund never returns more than is spent.
+	accountant.Refund("acme", 1000)
+	accountant.Refund("globex", 16)
+
+	for i := 0; i < 2; i++ {
+		err = accountant.Charge("acme", score, cost.Limits{})
+		assert.NoError(t, err)
+	}
+
+	err = accountant.Charge("acme", score, cost.Limits{})
+	assert.EqualError(t, err, "render cost budget is exhausted: 32 of 40 is spent in the last 1h0m0s")
+}
+
+func TestAccountant_Charge_Unlimited(t *testing.T) {
+	t.Parallel()
+
+	accountant := cost.NewAccountant(config.CostConfig{BudgetWindowSeconds: 3600}, metric.NewEmptyRecorder())
+
+	for i := 0; i < 100; i++ {
+		err := accountant.Charge("", cost.Score(newRenderChartRequest(1000, 1000, barsView(100))), cost.Limits{})
+		assert.NoError(t, err)
+	}
+}
@@ -9,12 +9,34 @@ import (
 
 // EmptyRecorder represents recorder without registered metrics.
 type EmptyRecorder struct {
-	requestDuration *prometheus.HistogramVec
+	requestDuration          *prometheus.HistogramVec
+	renderCacheRequests      *prometheus.CounterVec
+	renderCacheEvictions     prometheus.Counter
+	webhookDeliveries        *prometheus.CounterVec
+	webhookDeliveryDuration  *prometheus.HistogramVec
+	rateLimitedRequests      *prometheus.CounterVec
+	renderCost               prometheus.Histogram
+	rendererBreakerState     *prometheus.GaugeVec
+	rendererConcurrencyLimit *prometheus.GaugeVec
+	rendererInFlight         *prometheus.GaugeVec
+	rendererQueued           *prometheus.GaugeVec
 }
 
 // NewEmptyRecorder returns a new EmptyRecorder.
 func NewEmptyRecorder() *EmptyRecorder {
-	return &EmptyRecorder{NewRequestDuration()}
+	return &EmptyRecorder{
+		requestDuration:          NewRequestDuration(),
+		renderCacheRequests:      NewRenderCacheRequests(),
+		renderCacheEvictions:     NewRenderCacheEvictions(),
+		webhookDeliveries:        NewWebhookDeliveries(),
+		webhookDeliveryDuration:  NewWebhookDeliveryDuration(),
+		rateLimitedRequests:      NewRateLimitedRequests(),
+		renderCost:               NewRenderCost(),
+		rendererBreakerState:     NewRendererBreakerState(),
+		rendererConcurrencyLimit: NewRendererConcurrencyLimit(),
+		rendererInFlight:         NewRendererInFlight(),
+		rendererQueued:           NewRendererQueued(),
+	}
 }
 
 // RequestDuration returns unregistered request_duration_seconds metric.
@@ -22,6 +44,56 @@ func (er *EmptyRecorder) RequestDuration() *prometheus.HistogramVec {
 	return er.requestDuration
 }
 
+// RenderCacheRequests returns unregistered render_cache_requests_total metric.
+func (er *EmptyRecorder) RenderCacheRequests() *prometheus.CounterVec {
+	return er.renderCacheRequests
+}
+
+// RenderCacheEvictions returns unregistered render_cache_evictions_total metric.
+func (er *EmptyRecorder) RenderCacheEvictions() prometheus.Counter {
+	return er.renderCacheEvictions
+}
+
+// WebhookDeliveries returns unregistered webhook_deliveries_total metric.
+func (er *EmptyRecorder) WebhookDeliveries() *prometheus.CounterVec {
+	return er.webhookDeliveries
+}
+
+// WebhookDeliveryDuration returns unregistered webhook_delivery_duration_seconds metric.
+func (er *EmptyRecorder) WebhookDeliveryDuration() *prometheus.HistogramVec {
+	return er.webhookDeliveryDuration
+}
+
+// RateLimitedRequests returns unregistered rate_limited_requests_total metric.
+func (er *EmptyRecorder) RateLimitedRequests() *prometheus.CounterVec {
+	return er.rateLimitedRequests
+}
+
+// RenderCost returns unregistered render_cost metric.
+func (er *EmptyRecorder) RenderCost() prometheus.Histogram {
+	return er.renderCost
+}
+
+// RendererBreakerState returns unregistered renderer_breaker_state metric.
+func (er *EmptyRecorder) RendererBreakerState() *prometheus.GaugeVec {
+	return er.rendererBreakerState
+}
+
+// RendererConcurrencyLimit returns unregistered renderer_concurrency_limit metric.
+func (er *EmptyRecorder) RendererConcurrencyLimit() *prometheus.GaugeVec {
+	return er.rendererConcurrencyLimit
+}
+
+// RendererInFlight returns unregistered renderer_in_flight_requests metric.
+func (er *EmptyRecorder) RendererInFlight() *prometheus.GaugeVec {
+	return er.rendererInFlight
+}
+
+// RendererQueued returns unregistered renderer_queued_requests metric.
+func (er *EmptyRecorder) RendererQueued() *prometheus.GaugeVec {
+	return er.rendererQueued
+}
+
 // HTTPHandler returns default Prometheus HTTP handler.
 func (er *EmptyRecorder) HTTPHandler() http.Handler {
 	return promhttp.Handler()
@@ -17,28 +17,105 @@ const (
 	ProtocolGRPC = "grpc"
 )
 
+const (
+	// RenderCacheHit represents render cache request result when chart data is found.
+	RenderCacheHit = "hit"
+
+	// RenderCacheMiss represents render cache request result when chart data is not found.
+	RenderCacheMiss = "miss"
+)
+
+const (
+	// WebhookDelivered represents webhook delivery result when the callback is accepted by its receiver.
+	WebhookDelivered = "delivered"
+
+	// WebhookDeadLetter represents webhook delivery result when all attempts failed and the callback is dead-lettered.
+	WebhookDeadLetter = "dead_letter"
+
+	// WebhookDropped represents webhook delivery result when the callback is dropped because the queue is full.
+	WebhookDropped = "dropped"
+)
+
 const (
 	protocolLabel   = "protocol"
 	methodLabel     = "method"
 	pathLabel       = "path"
 	statusCodeLabel = "status_code"
+	resultLabel     = "result"
+	operationLabel  = "operation"
+	keyLabel        = "key"
+	poolLabel       = "pool"
 
 	requestDurMetricName = "request_duration_seconds"
 	requestDurMetricHelp = "The latency of requests (seconds)."
+
+	renderCacheRequestsMetricName = "render_cache_requests_total"
+	renderCacheRequestsMetricHelp = "The number of render cache requests by result."
+
+	renderCacheEvictionsMetricName = "render_cache_evictions_total"
+	renderCacheEvictionsMetricHelp = "The number of render cache entries evicted because the cache is full."
+
+	webhookDeliveriesMetricName = "webhook_deliveries_total"
+	webhookDeliveriesMetricHelp = "The number of webhook deliveries by result."
+
+	webhookDeliveryDurMetricName = "webhook_delivery_duration_seconds"
+	webhookDeliveryDurMetricHelp = "The latency of webhook delivery attempts (seconds)."
+
+	rateLimitedRequestsMetricName = "rate_limited_requests_total"
+	rateLimitedRequestsMetricHelp = "The number of requests rejected by rate limiting."
+
+	renderCostMetricName = "render_cost"
+	renderCostMetricHelp = "The render cost score of chart creation requests."
+
+	rendererBreakerStateMetricName = "renderer_breaker_state"
+	rendererBreakerStateMetricHelp = "The circuit breaker state of lc-renderer pools (0 is closed, 1 is half-open, 2 is open)."
+
+	rendererConcurrencyLimitMetricName = "renderer_concurrency_limit"
+	rendererConcurrencyLimitMetricHelp = "The adaptive concurrency limit of lc-renderer pools."
+
+	rendererInFlightMetricName = "renderer_in_flight_requests"
+	rendererInFlightMetricHelp = "The number of lc-renderer requests in flight of lc-renderer pools."
+
+	rendererQueuedMetricName = "renderer_queued_requests"
+	rendererQueuedMetricHelp = "The number of lc-renderer requests waiting for the concurrency limit of lc-renderer pools."
 )
 
+// renderCostBuckets cover costs from sparklines to the biggest charts.
+// nolint: gochecknoglobals, gomnd
+var renderCostBuckets = prometheus.ExponentialBuckets(1, 4, 12)
+
 // PromRecorder represents an entity that records metrics and contains
 // configured HTTP handler that can be used by Prometheus.
 type PromRecorder interface {
 	RequestDuration() *prometheus.HistogramVec
+	RenderCacheRequests() *prometheus.CounterVec
+	RenderCacheEvictions() prometheus.Counter
+	WebhookDeliveries() *prometheus.CounterVec
+	WebhookDeliveryDuration() *prometheus.HistogramVec
+	RateLimitedRequests() *prometheus.CounterVec
+	RenderCost() prometheus.Histogram
+	RendererBreakerState() *prometheus.GaugeVec
+	RendererConcurrencyLimit() *prometheus.GaugeVec
+	RendererInFlight() *prometheus.GaugeVec
+	RendererQueued() *prometheus.GaugeVec
 	HTTPHandler() http.Handler
 }
 
 // Recorder represents application metrics recorder.
 type Recorder struct {
-	requestDuration *prometheus.HistogramVec
-	registerer      prometheus.Registerer
-	httpHandler     http.Handler
+	requestDuration          *prometheus.HistogramVec
+	renderCacheRequests      *prometheus.CounterVec
+	renderCacheEvictions     prometheus.Counter
+	webhookDeliveries        *prometheus.CounterVec
+	webhookDeliveryDuration  *prometheus.HistogramVec
+	rateLimitedRequests      *prometheus.CounterVec
+	renderCost               prometheus.Histogram
+	rendererBreakerState     *prometheus.GaugeVec
+	rendererConcurrencyLimit *prometheus.GaugeVec
+	rendererInFlight         *prometheus.GaugeVec
+	rendererQueued           *prometheus.GaugeVec
+	registerer               prometheus.Registerer
+	httpHandler              http.Handler
 }
 
 // NewRecorder registers all metrics and returns a new metric recorder.
@@ -61,12 +138,86 @@ func NewRecorder() (*Recorder, error) {
 		return nil, fmt.Errorf("unable to register %s metric: %w", requestDurMetricName, err)
 	}
 
+	renderCacheRequests := NewRenderCacheRequests()
+
+	if err := registry.Register(renderCacheRequests); err != nil {
+		return nil, fmt.Errorf("unable to register %s metric: %w", renderCacheRequestsMetricName, err)
+	}
+
+	renderCacheEvictions := NewRenderCacheEvictions()
+
+	if err := registry.Register(renderCacheEvictions); err != nil {
+		return nil, fmt.Errorf("unable to register %s metric: %w", renderCacheEvictionsMetricName, err)
+	}
+
+	webhookDeliveries := NewWebhookDeliveries()
+
+	if err := registry.Register(webhookDeliveries); err != nil {
+		return nil, fmt.Errorf("unable to register %s metric: %w", webhookDeliveriesMetricName, err)
+	}
+
+	webhookDeliveryDuration := NewWebhookDeliveryDuration()
+
+	if err := registry.Register(webhookDeliveryDuration); err != nil {
+		return nil, fmt.Errorf("unable to register %s metric: %w", webhookDeliveryDurMetricName, err)
+	}
+
+	rateLimitedRequests := NewRateLimitedRequests()
+
+	if err := registry.Register(rateLimitedRequests); err != nil {
+		return nil, fmt.Errorf("unable to register %s metric: %w", rateLimitedRequestsMetricName, err)
+	}
+
+	renderCost := NewRenderCost()
+
+	if err := registry.Register(renderCost); err != nil {
+		return nil, fmt.Errorf("unable to register %s metric: %w", renderCostMetricName, err)
+	}
+
+	rendererBreakerState := NewRendererBreakerState()
+
+	if err := registry.Register(rendererBreakerState); err != nil {
+		return nil, fmt.Errorf("unable to register %s metric: %w", rendererBreakerStateMetricName, err)
+	}
+
+	rendererConcurrencyLimit := NewRendererConcurrencyLimit()
+
+	if err := registry.Register(rendererConcurrencyLimit); err != nil {
+		return nil, fmt.Errorf("unable to register %s metric: %w", rendererConcurrencyLimitMetricName, err)
+	}
+
+	rendererInFlight := NewRendererInFlight()
+
+	if err := registry.Register(rendererInFlight); err != nil {
+		return nil, fmt.Errorf("unable to register %s metric: %w", rendererInFlightMetricName, err)
+	}
+
+	rendererQueued := NewRendererQueued()
+
+	if err := registry.Register(rendererQueued); err != nil {
+		return nil, fmt.Errorf("unable to register %s metric: %w", rendererQueuedMetricName, err)
+	}
+
 	// Configure metrics HTTP handler.
 	httpHandler := promhttp.InstrumentMetricHandler(
 		registry, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}),
 	)
 
-	return &Recorder{requestDuration, registry, httpHandler}, nil
+	return &Recorder{
+		requestDuration:          requestDuration,
+		renderCacheRequests:      renderCacheRequests,
+		renderCacheEvictions:     renderCacheEvictions,
+		webhookDeliveries:        webhookDeliveries,
+		webhookDeliveryDuration:  webhookDeliveryDuration,
+		rateLimitedRequests:      rateLimitedRequests,
+		renderCost:               renderCost,
+		rendererBreakerState:     rendererBreakerState,
+		rendererConcurrencyLimit: rendererConcurrencyLimit,
+		rendererInFlight:         rendererInFlight,
+		rendererQueued:           rendererQueued,
+		registerer:               registry,
+		httpHandler:              httpHandler,
+	}, nil
 }
 
 // RequestDuration returns registered request_duration_seconds metric.
@@ -74,6 +225,56 @@ func (r *Recorder) RequestDuration() *prometheus.HistogramVec {
 	return r.requestDuration
 }
 
+// RenderCacheRequests returns registered render_cache_requests_total metric.
+func (r *Recorder) RenderCacheRequests() *prometheus.CounterVec {
+	return r.renderCacheRequests
+}
+
+// RenderCacheEvictions returns registered render_cache_evictions_total metric.
+func (r *Recorder) RenderCacheEvictions() prometheus.Counter {
+	return r.renderCacheEvictions
+}
+
+// WebhookDeliveries returns registered webhook_deliveries_total metric.
+func (r *Recorder) WebhookDeliveries() *prometheus.CounterVec {
+	return r.webhookDeliveries
+}
+
+// WebhookDeliveryDuration returns registered webhook_delivery_duration_seconds metric.
+func (r *Recorder) WebhookDeliveryDuration() *prometheus.HistogramVec {
+	return r.webhookDeliveryDuration
+}
+
+// RateLimitedRequests returns registered rate_limited_requests_total metric.
+func (r *Recorder) RateLimitedRequests() *prometheus.CounterVec {
+	return r.rateLimitedRequests
+}
+
+// RenderCost returns registered render_cost metric.
+func (r *Recorder) RenderCost() prometheus.Histogram {
+	return r.renderCost
+}
+
+// RendererBreakerState returns registered renderer_breaker_state metric.
+func (r *Recorder) RendererBreakerState() *prometheus.GaugeVec {
+	return r.rendererBreakerState
+}
+
+// RendererConcurrencyLimit returns registered renderer_concurrency_limit metric.
+func (r *Recorder) RendererConcurrencyLimit() *prometheus.GaugeVec {
+	return r.rendererConcurrencyLimit
+}
+
+// RendererInFlight returns registered renderer_in_flight_requests metric.
+func (r *Recorder) RendererInFlight() *prometheus.GaugeVec {
+	return r.rendererInFlight
+}
+
+// RendererQueued returns registered renderer_queued_requests metric.
+func (r *Recorder) RendererQueued() *prometheus.GaugeVec {
+	return r.rendererQueued
+}
+
 // HTTPHandler returns configured HTTP handler.
 func (r *Recorder) HTTPHandler() http.Handler {
 	return r.httpHandler
@@ -90,3 +291,113 @@ func NewRequestDuration() *prometheus.HistogramVec {
 		[]string{protocolLabel, methodLabel, pathLabel, statusCodeLabel},
 	)
 }
+
+// NewRenderCacheRequests configures and returns a new render_cache_requests_total counter.
+func NewRenderCacheRequests() *prometheus.CounterVec {
+	return prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: renderCacheRequestsMetricName,
+			Help: renderCacheRequestsMetricHelp,
+		},
+		[]string{resultLabel},
+	)
+}
+
+// NewRenderCacheEvictions configures and returns a new render_cache_evictions_total counter.
+func NewRenderCacheEvictions() prometheus.Counter {
+	return prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: renderCacheEvictionsMetricName,
+			Help: renderCacheEvictionsMetricHelp,
+		},
+	)
+}
+
+// NewWebhookDeliveries configures and returns a new webhook_deliveries_total counter.
+func NewWebhookDeliveries() *prometheus.CounterVec {
+	return prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: webhookDeliveriesMetricName,
+			Help: webhookDeliveriesMetricHelp,
+		},
+		[]string{resultLabel},
+	)
+}
+
+// NewWebhookDeliveryDuration configures and returns a new webhook_delivery_duration_seconds histogram.
+func NewWebhookDeliveryDuration() *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    webhookDeliveryDurMetricName,
+			Help:    webhookDeliveryDurMetricHelp,
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{statusCodeLabel},
+	)
+}
+
+// NewRateLimitedRequests configures and returns a new rate_limited_requests_total counter.
+func NewRateLimitedRequests() *prometheus.CounterVec {
+	return prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: rateLimitedRequestsMetricName,
+			Help: rateLimitedRequestsMetricHelp,
+		},
+		[]string{protocolLabel, operationLabel, keyLabel},
+	)
+}
+
+// NewRenderCost configures and returns a new render_cost histogram.
+func NewRenderCost() prometheus.Histogram {
+	return prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    renderCostMetricName,
+			Help:    renderCostMetricHelp,
+			Buckets: renderCostBuckets,
+		},
+	)
+}
+
+// NewRendererBreakerState configures and returns a new renderer_breaker_state gauge.
+func NewRendererBreakerState() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: rendererBreakerStateMetricName,
+			Help: rendererBreakerStateMetricHelp,
+		},
+		[]string{poolLabel},
+	)
+}
+
+// NewRendererConcurrencyLimit configures and returns a new renderer_concurrency_limit gauge.
+func NewRendererConcurrencyLimit() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: rendererConcurrencyLimitMetricName,
+			Help: rendererConcurrencyLimitMetricHelp,
+		},
+		[]string{poolLabel},
+	)
+}
+
+// NewRendererInFlight configures and returns a new renderer_in_flight_requests gauge.
+func NewRendererInFlight() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: rendererInFlightMetricName,
+			Help: rendererInFlightMetricHelp,
+		},
+		[]string{poolLabel},
+	)
+}
+
+// NewRendererQueued configures and returns a new renderer_queued_requests gauge.
+func NewRendererQueued() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: rendererQueuedMetricName,
+			Help: rendererQueuedMetricHelp,
+		},
+		[]string{poolLabel},
+	)
+}
@@ -0,0 +1,287 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+	"github.com/limpidchart/lc-api/internal/config"
+)
+
+const (
+	// OperationCreateChart represents CreateChart RPC and POST /v0/charts route.
+	OperationCreateChart = "CreateChart"
+
+	// OperationCreateCharts represents CreateCharts RPC and POST /v0/charts:batch route.
+	OperationCreateCharts = "CreateCharts"
+
+	// OperationGetChart represents GetChart RPC and GET /v0/charts/{chart_id} routes.
+	OperationGetChart = "GetChart"
+
+	// OperationListCharts represents ListCharts RPC and GET /v0/charts route.
+	OperationListCharts = "ListCharts"
+
+	// OperationDeleteChart represents DeleteChart RPC and DELETE /v0/charts/{chart_id} route.
+	OperationDeleteChart = "DeleteChart"
+)
+
+const (
+	operationsSeparator    = ","
+	operationRuleSeparator = "="
+	rateBurstSeparator     = ":"
+
+	sweepInterval = time.Minute
+)
+
+// ErrBadConfig contains error message about rate limiting configuration that can't be used.
+var ErrBadConfig = errors.New("bad rate limit configuration")
+
+// Rule represents a token bucket that is refilled with PerMinute tokens every minute and keeps up to Burst tokens.
+// Zero PerMinute means that requests are not limited.
+type Rule struct {
+	PerMinute int
+	Burst     int
+}
+
+// Key represents the caller that owns a token bucket.
+type Key struct {
+	// Kind is one of config.RateLimitKeyAPIKey, config.RateLimitKeyTenant or config.RateLimitKeyIP.
+	Kind  string
+	Value string
+}
+
+// Limiter limits requests rate of every caller with a token bucket per operation.
+type Limiter struct {
+	keyKind     string
+	defaultRule Rule
+	rules       map[string]Rule
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+}
+
+type bucketKey struct {
+	operation string
+	key       Key
+}
+
+type bucket struct {
+	rule      Rule
+	tokens    float64
+	updatedAt time.Time
+}
+
+// Operations returns all rate limited operations.
+func Operations() []string {
+	return []string{OperationCreateChart, OperationCreateCharts, OperationGetChart, OperationListCharts, OperationDeleteChart}
+}
+
+// NewLimiter configures a new Limiter.
+// Operation rules are provided as comma separated "Operation=perMinute:burst" pairs that override the default rule.
+func NewLimiter(rateLimitCfg config.RateLimitConfig) (*Limiter, error) {
+	switch rateLimitCfg.Key {
+	case config.RateLimitKeyAPIKey, config.RateLimitKeyTenant, config.RateLimitKeyIP:
+	default:
+		return nil, fmt.Errorf("%w: unknown key %q", ErrBadConfig, rateLimitCfg.Key)
+	}
+
+	defaultRule := Rule{PerMinute: rateLimitCfg.PerMinute, Burst: rateLimitCfg.Burst}
+	if err := defaultRule.validate(); err != nil {
+		return nil, fmt.Errorf("%w: default rule: %s", ErrBadConfig, err)
+	}
+
+	rules, err := parseRules(rateLimitCfg.Operations)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Limiter{
+		keyKind:     rateLimitCfg.Key,
+		defaultRule: defaultRule,
+		rules:       rules,
+		buckets:     make(map[bucketKey]*bucket),
+		lastSweep:   time.Now(),
+	}, nil
+}
+
+// Enabled reports if any operation is rate limited.
+func (l *Limiter) Enabled() bool {
+	if l.defaultRule.PerMinute > 0 {
+		return true
+	}
+
+	for _, rule := range l.rules {
+		if rule.PerMinute > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Key returns key of the caller.
+// Callers without identity or with the default tenant are limited by their IP.
+func (l *Limiter) Key(identity *auth.Identity, tenant, ip string) Key {
+	switch {
+	case l.keyKind == config.RateLimitKeyAPIKey && identity != nil:
+		return Key{Kind: config.RateLimitKeyAPIKey, Value: identity.Subject}
+	case l.keyKind == config.RateLimitKeyTenant && tenant != "":
+		return Key{Kind: config.RateLimitKeyTenant, Value: tenant}
+	default:
+		return Key{Kind: config.RateLimitKeyIP, Value: ip}
+	}
+}
+
+// Allow takes a token from the caller bucket of the operation.
+// It returns false and the time after which a token is available if the bucket is empty.
+func (l *Limiter) Allow(operation string, key Key) (bool, time.Duration) {
+	rule := l.rule(operation)
+	if rule.PerMinute == 0 {
+		return true, 0
+	}
+
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	bKey := bucketKey{operation: operation, key: key}
+
+	b, ok := l.buckets[bKey]
+	if !ok {
+		b = &bucket{rule: rule, tokens: float64(rule.Burst), updatedAt: now}
+		l.buckets[bKey] = b
+	}
+
+	b.refill(now)
+
+	if b.tokens >= 1 {
+		b.tokens--
+
+		return true, 0
+	}
+
+	return false, time.Duration((1 - b.tokens) / b.perSecond() * float64(time.Second))
+}
+
+// RetryAfterSeconds rounds the time returned by Limiter.Allow up to whole seconds.
+func RetryAfterSeconds(retryAfter time.Duration) int {
+	return int(math.Ceil(retryAfter.Seconds()))
+}
+
+func (l *Limiter) rule(operation string) Rule {
+	if rule, ok := l.rules[operation]; ok {
+		return rule
+	}
+
+	return l.defaultRule
+}
+
+// sweep removes buckets that are full, they are recreated full on the next request.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+
+	for bKey, b := range l.buckets {
+		b.refill(now)
+
+		if b.tokens >= float64(b.rule.Burst) {
+			delete(l.buckets, bKey)
+		}
+	}
+
+	l.lastSweep = now
+}
+
+func (b *bucket) refill(now time.Time) {
+	b.tokens = math.Min(float64(b.rule.Burst), b.tokens+now.Sub(b.updatedAt).Seconds()*b.perSecond())
+	b.updatedAt = now
+}
+
+func (b *bucket) perSecond() float64 {
+	return float64(b.rule.PerMinute) / time.Minute.Seconds()
+}
+
+func (r Rule) validate() error {
+	if r.PerMinute < 0 || r.Burst < 0 {
+		return errors.New("rate and burst should not be negative")
+	}
+
+	if r.PerMinute > 0 && r.Burst == 0 {
+		return errors.New("burst should be positive for limited operations")
+	}
+
+	return nil
+}
+
+func parseRules(operations string) (map[string]Rule, error) {
+	rules := make(map[string]Rule)
+
+	if strings.TrimSpace(operations) == "" {
+		return rules, nil
+	}
+
+	for _, operationRule := range strings.Split(operations, operationsSeparator) {
+		operation, rule, err := parseRule(strings.TrimSpace(operationRule))
+		if err != nil {
+			return nil, fmt.Errorf("%w: operation rule %q: %s", ErrBadConfig, operationRule, err)
+		}
+
+		rules[operation] = rule
+	}
+
+	return rules, nil
+}
+
+func parseRule(operationRule string) (string, Rule, error) {
+	parts := strings.Split(operationRule, operationRuleSeparator)
+	if len(parts) != 2 {
+		return "", Rule{}, errors.New("should look like Operation=perMinute:burst")
+	}
+
+	operation := parts[0]
+	if !isOperation(operation) {
+		return "", Rule{}, fmt.Errorf("unknown operation %s", operation)
+	}
+
+	rateBurst := strings.Split(parts[1], rateBurstSeparator)
+	if len(rateBurst) != 2 {
+		return "", Rule{}, errors.New("should look like Operation=perMinute:burst")
+	}
+
+	perMinute, err := strconv.Atoi(rateBurst[0])
+	if err != nil {
+		return "", Rule{}, errors.New("rate should be an integer")
+	}
+
+	burst, err := strconv.Atoi(rateBurst[1])
+	if err != nil {
+		return "", Rule{}, errors.New("burst should be an integer")
+	}
+
+	rule := Rule{PerMinute: perMinute, Burst: burst}
+	if err := rule.validate(); err != nil {
+		return "", Rule{}, err
+	}
+
+	return operation, rule, nil
+}
+
+func isOperation(operation string) bool {
+	for _, op := range Operations() {
+		if op == operation {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,155 @@
+package ratelimit_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/ratelimit"
+)
+
+func TestNewLimiter_Err(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name         string
+		rateLimitCfg config.RateLimitConfig
+		expected     string
+	}{
+		{
+			"unknown_key",
+			config.RateLimitConfig{Key: "user_agent"},
+			`bad rate limit configuration: unknown key "user_agent"`,
+		},
+		{
+			"no_default_burst",
+			config.RateLimitConfig{Key: config.RateLimitKeyIP, PerMinute: 10},
+			"bad rate limit configuration: default rule: burst should be positive for limited operations",
+		},
+		{
+			"unknown_operation",
+			config.RateLimitConfig{Key: config.RateLimitKeyIP, Operations: "RenderChart=1:1"},
+			`bad rate limit configuration: operation rule "RenderChart=1:1": unknown operation RenderChart`,
+		},
+		{
+			"no_burst",
+			config.RateLimitConfig{Key: config.RateLimitKeyIP, Operations: "CreateChart=10"},
+			`bad rate limit configuration: operation rule "CreateChart=10": should look like Operation=perMinute:burst`,
+		},
+		{
+			"negative_rate",
+			config.RateLimitConfig{Key: config.RateLimitKeyIP, Operations: "CreateChart=1:1,ListCharts=-1:1"},
+			`bad rate limit configuration: operation rule "ListCharts=-1:1": rate and burst should not be negative`,
+		},
+		{
+			"not_integer_burst",
+			config.RateLimitConfig{Key: config.RateLimitKeyIP, Operations: "CreateChart=1:x"},
+			`bad rate limit configuration: operation rule "CreateChart=1:x": burst should be an integer`,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			limiter, err := ratelimit.NewLimiter(tc.rateLimitCfg)
+			assert.Nil(t, limiter)
+			assert.True(t, errors.Is(err, ratelimit.ErrBadConfig))
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	limiter, err := ratelimit.NewLimiter(config.RateLimitConfig{
+		Key:        config.RateLimitKeyIP,
+		PerMinute:  0,
+		Burst:      0,
+		Operations: "CreateChart=2:2, GetChart=0:0",
+	})
+	if err != nil {
+		t.Fatalf("unable to configure rate limiter: %s", err)
+	}
+
+	assert.True(t, limiter.Enabled())
+
+	first := ratelimit.Key{Kind: config.RateLimitKeyIP, Value: "10.0.0.1"}
+	second := ratelimit.Key{Kind: config.RateLimitKeyIP, Value: "10.0.0.2"}
+
+	for i := 0; i < 2; i++ {
+		ok, retryAfter := limiter.Allow(ratelimit.OperationCreateChart, first)
+		assert.True(t, ok)
+		assert.Zero(t, retryAfter)
+	}
+
+	ok, retryAfter := limiter.Allow(ratelimit.OperationCreateChart, first)
+	assert.False(t, ok)
+	assert.InDelta(t, (time.Second * 30).Seconds(), retryAfter.Seconds(), 1)
+	assert.Equal(t, 30, ratelimit.RetryAfterSeconds(retryAfter))
+
+	// Other callers and operations have their own buckets.
+	ok, _ = limiter.Allow(ratelimit.OperationCreateChart, second)
+	assert.True(t, ok)
+
+	for i := 0; i < 10; i++ {
+		ok, _ = limiter.Allow(ratelimit.OperationGetChart, first)
+		assert.True(t, ok)
+		ok, _ = limiter.Allow(ratelimit.OperationListCharts, first)
+		assert.True(t, ok)
+	}
+}
+
+func TestLimiter_Enabled(t *testing.T) {
+	t.Parallel()
+
+	limiter, err := ratelimit.NewLimiter(config.RateLimitConfig{Key: config.RateLimitKeyIP, Burst: 10})
+	assert.NoError(t, err)
+	assert.False(t, limiter.Enabled())
+
+	limiter, err = ratelimit.NewLimiter(config.RateLimitConfig{Key: config.RateLimitKeyIP, PerMinute: 60, Burst: 10})
+	assert.NoError(t, err)
+	assert.True(t, limiter.Enabled())
+
+	assert.False(t, (&ratelimit.Limiter{}).Enabled())
+}
+
+func TestLimiter_Key(t *testing.T) {
+	t.Parallel()
+
+	identity := &auth.Identity{Subject: "reporting"}
+
+	tt := []struct {
+		name     string
+		keyKind  string
+		identity *auth.Identity
+		tenant   string
+		expected ratelimit.Key
+	}{
+		{"api_key", config.RateLimitKeyAPIKey, identity, "acme", ratelimit.Key{Kind: config.RateLimitKeyAPIKey, Value: "reporting"}},
+		{"api_key_without_identity", config.RateLimitKeyAPIKey, nil, "acme", ratelimit.Key{Kind: config.RateLimitKeyIP, Value: "10.0.0.1"}},
+		{"tenant", config.RateLimitKeyTenant, identity, "acme", ratelimit.Key{Kind: config.RateLimitKeyTenant, Value: "acme"}},
+		{"default_tenant", config.RateLimitKeyTenant, identity, "", ratelimit.Key{Kind: config.RateLimitKeyIP, Value: "10.0.0.1"}},
+		{"ip", config.RateLimitKeyIP, identity, "acme", ratelimit.Key{Kind: config.RateLimitKeyIP, Value: "10.0.0.1"}},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			limiter, err := ratelimit.NewLimiter(config.RateLimitConfig{Key: tc.keyKind})
+			if err != nil {
+				t.Fatalf("unable to configure rate limiter: %s", err)
+			}
+
+			assert.Equal(t, tc.expected, limiter.Key(tc.identity, tc.tenant, "10.0.0.1"))
+		})
+	}
+}
@@ -20,8 +20,15 @@ const _ = grpc.SupportPackageIsVersion7
 type ChartAPIClient interface {
 	// Create chart and return its raw bytes representation with additional metadata.
 	CreateChart(ctx context.Context, in *CreateChartRequest, opts ...grpc.CallOption) (*ChartReply, error)
+	// Create charts from the batch concurrently and stream their results as they complete.
+	CreateCharts(ctx context.Context, in *CreateChartsRequest, opts ...grpc.CallOption) (ChartAPI_CreateChartsClient, error)
 	// Get a created chart raw bytes representation with additional metadata.
 	GetChart(ctx context.Context, in *GetChartRequest, opts ...grpc.CallOption) (*ChartReply, error)
+	// List created charts metadata.
+	ListCharts(ctx context.Context, in *ListChartsRequest, opts ...grpc.CallOption) (*ListChartsReply, error)
+	// Delete a chart and return its tombstone.
+	// Chart data is removed immediately, tombstone is purged after a grace period.
+	DeleteChart(ctx context.Context, in *DeleteChartRequest, opts ...grpc.CallOption) (*ChartReply, error)
 }
 
 type chartAPIClient struct {
@@ -41,6 +48,38 @@ func (c *chartAPIClient) CreateChart(ctx context.Context, in *CreateChartRequest
 	return out, nil
 }
 
+func (c *chartAPIClient) CreateCharts(ctx context.Context, in *CreateChartsRequest, opts ...grpc.CallOption) (ChartAPI_CreateChartsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ChartAPI_ServiceDesc.Streams[0], "/render.ChartAPI/CreateCharts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chartAPICreateChartsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ChartAPI_CreateChartsClient interface {
+	Recv() (*CreateChartsReply, error)
+	grpc.ClientStream
+}
+
+type chartAPICreateChartsClient struct {
+	grpc.ClientStream
+}
+
+func (x *chartAPICreateChartsClient) Recv() (*CreateChartsReply, error) {
+	m := new(CreateChartsReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *chartAPIClient) GetChart(ctx context.Context, in *GetChartRequest, opts ...grpc.CallOption) (*ChartReply, error) {
 	out := new(ChartReply)
 	err := c.cc.Invoke(ctx, "/render.ChartAPI/GetChart", in, out, opts...)
@@ -50,14 +89,39 @@ func (c *chartAPIClient) GetChart(ctx context.Context, in *GetChartRequest, opts
 	return out, nil
 }
 
+func (c *chartAPIClient) ListCharts(ctx context.Context, in *ListChartsRequest, opts ...grpc.CallOption) (*ListChartsReply, error) {
+	out := new(ListChartsReply)
+	err := c.cc.Invoke(ctx, "/render.ChartAPI/ListCharts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chartAPIClient) DeleteChart(ctx context.Context, in *DeleteChartRequest, opts ...grpc.CallOption) (*ChartReply, error) {
+	out := new(ChartReply)
+	err := c.cc.Invoke(ctx, "/render.ChartAPI/DeleteChart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChartAPIServer is the server API for ChartAPI service.
 // All implementations must embed UnimplementedChartAPIServer
 // for forward compatibility
 type ChartAPIServer interface {
 	// Create chart and return its raw bytes representation with additional metadata.
 	CreateChart(context.Context, *CreateChartRequest) (*ChartReply, error)
+	// Create charts from the batch concurrently and stream their results as they complete.
+	CreateCharts(*CreateChartsRequest, ChartAPI_CreateChartsServer) error
 	// Get a created chart raw bytes representation with additional metadata.
 	GetChart(context.Context, *GetChartRequest) (*ChartReply, error)
+	// List created charts metadata.
+	ListCharts(context.Context, *ListChartsRequest) (*ListChartsReply, error)
+	// Delete a chart and return its tombstone.
+	// Chart data is removed immediately, tombstone is purged after a grace period.
+	DeleteChart(context.Context, *DeleteChartRequest) (*ChartReply, error)
 	mustEmbedUnimplementedChartAPIServer()
 }
 
@@ -68,9 +132,18 @@ type UnimplementedChartAPIServer struct {
 func (UnimplementedChartAPIServer) CreateChart(context.Context, *CreateChartRequest) (*ChartReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateChart not implemented")
 }
+func (UnimplementedChartAPIServer) CreateCharts(*CreateChartsRequest, ChartAPI_CreateChartsServer) error {
+	return status.Errorf(codes.Unimplemented, "method CreateCharts not implemented")
+}
 func (UnimplementedChartAPIServer) GetChart(context.Context, *GetChartRequest) (*ChartReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChart not implemented")
 }
+func (UnimplementedChartAPIServer) ListCharts(context.Context, *ListChartsRequest) (*ListChartsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCharts not implemented")
+}
+func (UnimplementedChartAPIServer) DeleteChart(context.Context, *DeleteChartRequest) (*ChartReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteChart not implemented")
+}
 func (UnimplementedChartAPIServer) mustEmbedUnimplementedChartAPIServer() {}
 
 // UnsafeChartAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -102,6 +175,27 @@ func _ChartAPI_CreateChart_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChartAPI_CreateCharts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CreateChartsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChartAPIServer).CreateCharts(m, &chartAPICreateChartsServer{stream})
+}
+
+type ChartAPI_CreateChartsServer interface {
+	Send(*CreateChartsReply) error
+	grpc.ServerStream
+}
+
+type chartAPICreateChartsServer struct {
+	grpc.ServerStream
+}
+
+func (x *chartAPICreateChartsServer) Send(m *CreateChartsReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _ChartAPI_GetChart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetChartRequest)
 	if err := dec(in); err != nil {
@@ -120,6 +214,42 @@ func _ChartAPI_GetChart_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChartAPI_ListCharts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChartsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChartAPIServer).ListCharts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/render.ChartAPI/ListCharts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChartAPIServer).ListCharts(ctx, req.(*ListChartsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChartAPI_DeleteChart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteChartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChartAPIServer).DeleteChart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/render.ChartAPI/DeleteChart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChartAPIServer).DeleteChart(ctx, req.(*DeleteChartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ChartAPI_ServiceDesc is the grpc.ServiceDesc for ChartAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -135,7 +265,21 @@ var ChartAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetChart",
 			Handler:    _ChartAPI_GetChart_Handler,
 		},
+		{
+			MethodName: "ListCharts",
+			Handler:    _ChartAPI_ListCharts_Handler,
+		},
+		{
+			MethodName: "DeleteChart",
+			Handler:    _ChartAPI_DeleteChart_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CreateCharts",
+			Handler:       _ChartAPI_CreateCharts_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api_service.proto",
 }
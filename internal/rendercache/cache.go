@@ -0,0 +1,137 @@
+package rendercache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+// Cache represents a bounded LRU cache of rendered chart data.
+// Cache with zero size is disabled and never stores anything.
+type Cache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries map[string]*list.Element
+	lru     *list.List
+	pRec    metric.PromRecorder
+}
+
+type entry struct {
+	key       string
+	chartData []byte
+	expiresAt time.Time
+}
+
+// New configures a new Cache.
+func New(renderCacheCfg config.RenderCacheConfig, pRec metric.PromRecorder) *Cache {
+	return &Cache{
+		size:    renderCacheCfg.Size,
+		ttl:     time.Duration(renderCacheCfg.TTLSeconds) * time.Second,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		pRec:    pRec,
+	}
+}
+
+// Key returns a canonical hash of the normalized render request.
+// Request ID is not a part of the key since it's unique for every request.
+func Key(req *render.RenderChartRequest) (string, error) {
+	// nolint: forcetypeassert
+	normalized := proto.Clone(req).(*render.RenderChartRequest)
+	normalized.RequestId = ""
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(normalized)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal render chart request: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Get returns cached chart data by key.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	if c.size <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.pRec.RenderCacheRequests().WithLabelValues(metric.RenderCacheMiss).Inc()
+
+		return nil, false
+	}
+
+	// nolint: forcetypeassert
+	e := elem.Value.(*entry)
+
+	if c.ttl > 0 && time.Now().After(e.expiresAt) {
+		c.remove(elem)
+		c.pRec.RenderCacheRequests().WithLabelValues(metric.RenderCacheMiss).Inc()
+
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	c.pRec.RenderCacheRequests().WithLabelValues(metric.RenderCacheHit).Inc()
+
+	return e.chartData, true
+}
+
+// Add saves chart data by key evicting the least recently used entries if the cache is full.
+func (c *Cache) Add(key string, chartData []byte) {
+	if c.size <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+
+	if elem, ok := c.entries[key]; ok {
+		// nolint: forcetypeassert
+		e := elem.Value.(*entry)
+		e.chartData = chartData
+		e.expiresAt = expiresAt
+		c.lru.MoveToFront(elem)
+
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&entry{key: key, chartData: chartData, expiresAt: expiresAt})
+
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+		c.pRec.RenderCacheEvictions().Inc()
+	}
+}
+
+// Len returns number of cached entries.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+func (c *Cache) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+
+	// nolint: forcetypeassert
+	delete(c.entries, elem.Value.(*entry).key)
+}
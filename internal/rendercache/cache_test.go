@@ -0,0 +1,120 @@
+package rendercache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/convert"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/rendercache"
+	"github.com/limpidchart/lc-api/internal/testutils"
+	"github.com/limpidchart/lc-api/internal/validate/apitorenderer"
+)
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	newRequest := func() *testutils.CreateChartRequest {
+		return testutils.NewCreateChartRequest().
+			SetSizes().
+			SetBandBottomAxis().
+			SetLinearLeftAxis().
+			AddAreaView()
+	}
+
+	first, err := convert.CreateChartRequestToRenderChartRequest(newRequest().Unembed(), apitorenderer.Limits{})
+	if err != nil {
+		t.Fatalf("unable to convert create chart request: %s", err)
+	}
+
+	first.RequestId = "req_id_1"
+
+	second, err := convert.CreateChartRequestToRenderChartRequest(newRequest().Unembed(), apitorenderer.Limits{})
+	if err != nil {
+		t.Fatalf("unable to convert create chart request: %s", err)
+	}
+
+	second.RequestId = "req_id_2"
+
+	other, err := convert.CreateChartRequestToRenderChartRequest(newRequest().SetTitle().Unembed(), apitorenderer.Limits{})
+	if err != nil {
+		t.Fatalf("unable to convert create chart request: %s", err)
+	}
+
+	firstKey, err := rendercache.Key(first)
+	assert.NoError(t, err)
+
+	secondKey, err := rendercache.Key(second)
+	assert.NoError(t, err)
+
+	otherKey, err := rendercache.Key(other)
+	assert.NoError(t, err)
+
+	assert.Equal(t, firstKey, secondKey)
+	assert.NotEqual(t, firstKey, otherKey)
+	assert.Equal(t, "req_id_1", first.RequestId)
+}
+
+func TestCache_LRU(t *testing.T) {
+	t.Parallel()
+
+	rec := metric.NewEmptyRecorder()
+	cache := rendercache.New(config.RenderCacheConfig{Size: 2, TTLSeconds: 0}, rec)
+
+	cache.Add("key_1", []byte("data_1"))
+	cache.Add("key_2", []byte("data_2"))
+
+	// Make key_1 the most recently used.
+	data, ok := cache.Get("key_1")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("data_1"), data)
+
+	cache.Add("key_3", []byte("data_3"))
+
+	_, ok = cache.Get("key_2")
+	assert.False(t, ok)
+
+	_, ok = cache.Get("key_1")
+	assert.True(t, ok)
+
+	_, ok = cache.Get("key_3")
+	assert.True(t, ok)
+
+	assert.Equal(t, 2, cache.Len())
+	assert.Equal(t, float64(3), testutil.ToFloat64(rec.RenderCacheRequests().WithLabelValues(metric.RenderCacheHit)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(rec.RenderCacheRequests().WithLabelValues(metric.RenderCacheMiss)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(rec.RenderCacheEvictions()))
+}
+
+func TestCache_TTL(t *testing.T) {
+	t.Parallel()
+
+	cache := rendercache.New(config.RenderCacheConfig{Size: 2, TTLSeconds: 1}, metric.NewEmptyRecorder())
+
+	cache.Add("key_1", []byte("data_1"))
+
+	_, ok := cache.Get("key_1")
+	assert.True(t, ok)
+
+	time.Sleep(time.Millisecond * 1100)
+
+	_, ok = cache.Get("key_1")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestCache_Disabled(t *testing.T) {
+	t.Parallel()
+
+	cache := rendercache.New(config.RenderCacheConfig{Size: 0, TTLSeconds: 0}, metric.NewEmptyRecorder())
+
+	cache.Add("key_1", []byte("data_1"))
+
+	_, ok := cache.Get("key_1")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
@@ -0,0 +1,100 @@
+package renderer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+// ErrBadBatchSize contains error message about batch that is empty or too big.
+var ErrBadBatchSize = errors.New("bad batch size")
+
+// CreateFunc represents a function that creates a single chart from the batch by its index.
+type CreateFunc func(ctx context.Context, index int) (*render.ChartReply, error)
+
+// BatchResult represents a result of a single chart creation from the batch.
+type BatchResult struct {
+	Index int
+	Chart *render.ChartReply
+	Err   error
+}
+
+// Batcher creates charts from batches concurrently.
+type Batcher struct {
+	parallelism int
+	maxSize     int
+}
+
+// NewBatcher returns a new Batcher with the configured parallelism and max batch size.
+func NewBatcher(batchCfg config.BatchConfig) *Batcher {
+	parallelism := batchCfg.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	return &Batcher{
+		parallelism: parallelism,
+		maxSize:     batchCfg.MaxSize,
+	}
+}
+
+// ValidateSize checks if the batch of the provided size can be created.
+func (b *Batcher) ValidateSize(size int) error {
+	if size < 1 || size > b.maxSize {
+		return fmt.Errorf("%w: batch should contain from 1 to %d charts, got %d", ErrBadBatchSize, b.maxSize, size)
+	}
+
+	return nil
+}
+
+// CreateCharts calls create function for every index of the batch with no more than the configured number
+// of concurrent calls and returns their results in completion order.
+// Results channel is closed after all results are sent. Charts that aren't started before the context is done
+// have ErrCreateChartRequestCancelled error.
+func (b *Batcher) CreateCharts(ctx context.Context, size int, create CreateFunc) <-chan BatchResult {
+	// Results channel can keep all results so workers don't block if the caller stops reading.
+	results := make(chan BatchResult, size)
+	sem := make(chan struct{}, b.parallelism)
+
+	var wg sync.WaitGroup
+
+	go func() {
+		defer close(results)
+
+		for i := 0; i < size; i++ {
+			if ctx.Err() != nil {
+				results <- BatchResult{Index: i, Err: ErrCreateChartRequestCancelled}
+
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				results <- BatchResult{Index: i, Err: ErrCreateChartRequestCancelled}
+
+				continue
+			case sem <- struct{}{}:
+			}
+
+			wg.Add(1)
+
+			go func(index int) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				chart, err := create(ctx, index)
+				results <- BatchResult{Index: index, Chart: chart, Err: err}
+			}(i)
+		}
+
+		wg.Wait()
+	}()
+
+	return results
+}
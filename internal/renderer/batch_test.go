@@ -0,0 +1,125 @@
+package renderer_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/renderer"
+)
+
+func TestBatcher_ValidateSize(t *testing.T) {
+	t.Parallel()
+
+	batcher := renderer.NewBatcher(config.BatchConfig{Parallelism: 1, MaxSize: 2})
+
+	tt := []struct {
+		name  string
+		size  int
+		valid bool
+	}{
+		{"empty", 0, false},
+		{"one", 1, true},
+		{"max", 2, true},
+		{"too_big", 3, false},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := batcher.ValidateSize(tc.size)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, renderer.ErrBadBatchSize))
+			}
+		})
+	}
+}
+
+func TestBatcher_CreateCharts(t *testing.T) {
+	t.Parallel()
+
+	const (
+		parallelism = 2
+		size        = 6
+	)
+
+	batcher := renderer.NewBatcher(config.BatchConfig{Parallelism: parallelism, MaxSize: size})
+
+	var inFlight, maxInFlight int64
+
+	results := batcher.CreateCharts(context.Background(), size, func(ctx context.Context, index int) (*render.ChartReply, error) {
+		cur := atomic.AddInt64(&inFlight, 1)
+		defer atomic.AddInt64(&inFlight, -1)
+
+		for {
+			prev := atomic.LoadInt64(&maxInFlight)
+			if cur <= prev || atomic.CompareAndSwapInt64(&maxInFlight, prev, cur) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond * 20)
+
+		if index%2 == 1 {
+			return nil, fmt.Errorf("chart %d failed", index)
+		}
+
+		return &render.ChartReply{ChartId: fmt.Sprintf("chart_%d", index)}, nil
+	})
+
+	seen := make(map[int]bool)
+
+	for res := range results {
+		seen[res.Index] = true
+
+		if res.Index%2 == 1 {
+			assert.EqualError(t, res.Err, fmt.Sprintf("chart %d failed", res.Index))
+			assert.Nil(t, res.Chart)
+		} else {
+			assert.NoError(t, res.Err)
+			assert.Equal(t, fmt.Sprintf("chart_%d", res.Index), res.Chart.ChartId)
+		}
+	}
+
+	assert.Len(t, seen, size)
+	assert.Equal(t, int64(parallelism), atomic.LoadInt64(&maxInFlight))
+}
+
+func TestBatcher_CreateChartsCancelled(t *testing.T) {
+	t.Parallel()
+
+	batcher := renderer.NewBatcher(config.BatchConfig{Parallelism: 1, MaxSize: 3})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	results := batcher.CreateCharts(ctx, 3, func(ctx context.Context, index int) (*render.ChartReply, error) {
+		cancel()
+
+		return &render.ChartReply{}, nil
+	})
+
+	created := 0
+
+	for res := range results {
+		if res.Err == nil {
+			created++
+
+			continue
+		}
+
+		assert.True(t, errors.Is(res.Err, renderer.ErrCreateChartRequestCancelled))
+	}
+
+	assert.Equal(t, 1, created)
+}
@@ -0,0 +1,107 @@
+package renderer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+// RenderFunc represents a function that renders a chart.
+type RenderFunc func(ctx context.Context) (*render.RenderChartReply, error)
+
+// Coalescer shares in-flight render calls between concurrent identical requests.
+type Coalescer struct {
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+type coalescedCall struct {
+	done    chan struct{}
+	reply   *render.RenderChartReply
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// NewCoalescer returns a new Coalescer.
+func NewCoalescer() *Coalescer {
+	return &Coalescer{
+		calls: make(map[string]*coalescedCall),
+	}
+}
+
+// Do calls render function or waits for the in-flight call with the same key.
+// Render function context is not bound to any caller so cancellation of one caller doesn't affect others,
+// it's cancelled only after all callers stop waiting and the cancelled call isn't shared with the new callers.
+// Optional lead function is called before the caller starts a new call, the call isn't started if it fails.
+// Do reports if the caller has started the call.
+func (c *Coalescer) Do(ctx context.Context, key string, lead func() error, fn RenderFunc) (*render.RenderChartReply, bool, error) {
+	c.mu.Lock()
+
+	call, ok := c.calls[key]
+	if !ok {
+		if lead != nil {
+			if err := lead(); err != nil {
+				c.mu.Unlock()
+
+				return nil, false, err
+			}
+		}
+
+		callCtx, cancel := context.WithCancel(context.Background())
+		call = &coalescedCall{
+			done:   make(chan struct{}),
+			cancel: cancel,
+		}
+		c.calls[key] = call
+
+		go c.run(callCtx, key, call, fn)
+	}
+
+	call.waiters++
+
+	c.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		c.leave(key, call)
+
+		return nil, !ok, ErrCreateChartRequestCancelled
+	case <-call.done:
+		c.leave(key, call)
+
+		return call.reply, !ok, call.err
+	}
+}
+
+func (c *Coalescer) run(ctx context.Context, key string, call *coalescedCall, fn RenderFunc) {
+	defer call.cancel()
+
+	call.reply, call.err = fn(ctx)
+
+	c.mu.Lock()
+	c.forget(key, call)
+	c.mu.Unlock()
+
+	close(call.done)
+}
+
+func (c *Coalescer) leave(key string, call *coalescedCall) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	call.waiters--
+
+	if call.waiters == 0 {
+		c.forget(key, call)
+		call.cancel()
+	}
+}
+
+// forget removes the call so the new callers start another one, it should be called with the lock held.
+func (c *Coalescer) forget(key string, call *coalescedCall) {
+	if c.calls[key] == call {
+		delete(c.calls, key)
+	}
+}
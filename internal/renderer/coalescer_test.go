@@ -0,0 +1,198 @@
+package renderer_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/renderer"
+)
+
+func TestCoalescer_Do(t *testing.T) {
+	t.Parallel()
+
+	coalescer := renderer.NewCoalescer()
+
+	var calls int64
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fn := func(ctx context.Context) (*render.RenderChartReply, error) {
+		atomic.AddInt64(&calls, 1)
+		close(started)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-release:
+			return &render.RenderChartReply{ChartData: []byte("chart svg")}, nil
+		}
+	}
+
+	const callers = 5
+
+	wg := &sync.WaitGroup{}
+	replies := make([]*render.RenderChartReply, callers)
+	errs := make([]error, callers)
+
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			replies[i], _, errs[i] = coalescer.Do(context.Background(), "key", nil, fn)
+		}(i)
+	}
+
+	<-started
+
+	// Cancelled caller shouldn't abort the shared call while other callers are waiting.
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, cancelledErr := coalescer.Do(cancelledCtx, "key", nil, fn)
+	assert.True(t, errors.Is(cancelledErr, renderer.ErrCreateChartRequestCancelled))
+
+	time.Sleep(time.Millisecond * 50)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int64(1), atomic.LoadInt64(&calls))
+
+	for i := 0; i < callers; i++ {
+		assert.NoError(t, errs[i])
+		assert.Equal(t, []byte("chart svg"), replies[i].ChartData)
+	}
+}
+
+func TestCoalescer_DoCancelled(t *testing.T) {
+	t.Parallel()
+
+	coalescer := renderer.NewCoalescer()
+
+	callCancelled := make(chan struct{})
+	fn := func(ctx context.Context) (*render.RenderChartReply, error) {
+		<-ctx.Done()
+		close(callCancelled)
+
+		return nil, ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := coalescer.Do(ctx, "key", nil, fn)
+	assert.True(t, errors.Is(err, renderer.ErrCreateChartRequestCancelled))
+
+	// Shared call should be cancelled once there are no waiting callers.
+	select {
+	case <-callCancelled:
+	case <-time.After(time.Second):
+		t.Fatal("shared call is not cancelled")
+	}
+}
+
+func TestCoalescer_DoAfterCancelled(t *testing.T) {
+	t.Parallel()
+
+	coalescer := renderer.NewCoalescer()
+
+	var calls int64
+
+	callCancelled := make(chan struct{})
+	release := make(chan struct{})
+	fn := func(ctx context.Context) (*render.RenderChartReply, error) {
+		if atomic.AddInt64(&calls, 1) > 1 {
+			return &render.RenderChartReply{ChartData: []byte("chart svg")}, nil
+		}
+
+		// Cancelled call is still in-flight while the next caller arrives.
+		<-ctx.Done()
+		close(callCancelled)
+		<-release
+
+		return nil, ctx.Err()
+	}
+
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := coalescer.Do(ctx, "key", nil, fn)
+	assert.True(t, errors.Is(err, renderer.ErrCreateChartRequestCancelled))
+
+	<-callCancelled
+
+	// New caller shouldn't join the cancelled call.
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Second)
+	defer waitCancel()
+
+	reply, _, err := coalescer.Do(waitCtx, "key", nil, fn)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("chart svg"), reply.GetChartData())
+	assert.Equal(t, int64(2), atomic.LoadInt64(&calls))
+}
+
+func TestCoalescer_DoLead(t *testing.T) {
+	t.Parallel()
+
+	coalescer := renderer.NewCoalescer()
+
+	var leads int64
+
+	lead := func() error {
+		atomic.AddInt64(&leads, 1)
+
+		return nil
+	}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fn := func(ctx context.Context) (*render.RenderChartReply, error) {
+		close(started)
+		<-release
+
+		return &render.RenderChartReply{ChartData: []byte("chart svg")}, nil
+	}
+
+	ledCh := make(chan bool, 1)
+
+	go func() {
+		_, led, _ := coalescer.Do(context.Background(), "key", lead, fn)
+		ledCh <- led
+	}()
+
+	<-started
+
+	joinedCtx, joinedCancel := context.WithCancel(context.Background())
+	joinedCancel()
+
+	// Caller that joins the in-flight call doesn't lead it.
+	_, led, err := coalescer.Do(joinedCtx, "key", lead, fn)
+	assert.False(t, led)
+	assert.True(t, errors.Is(err, renderer.ErrCreateChartRequestCancelled))
+
+	close(release)
+
+	assert.True(t, <-ledCh)
+	assert.Equal(t, int64(1), atomic.LoadInt64(&leads))
+
+	// Call isn't started if lead fails.
+	errLead := errors.New("budget is exhausted")
+
+	_, led, err = coalescer.Do(context.Background(), "key", func() error { return errLead }, func(ctx context.Context) (*render.RenderChartReply, error) {
+		t.Error("call is started after failed lead")
+
+		return nil, nil
+	})
+	assert.False(t, led)
+	assert.Equal(t, errLead, err)
+}
@@ -0,0 +1,400 @@
+package renderer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/limpidchart/lc-api/internal/breaker"
+	"github.com/limpidchart/lc-api/internal/concurrency"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/tlsutils"
+)
+
+// DefaultPool is the name of the lc-renderer pool that is configured by the renderer address.
+const DefaultPool = "default"
+
+// ErrBadPoolsFile contains error message about renderer pools file that can't be parsed.
+var ErrBadPoolsFile = errors.New("bad renderer pools file")
+
+// Pool represents a named lc-renderer connection.
+type Pool struct {
+	name           string
+	conn           *grpc.ClientConn
+	client         render.ChartRendererClient
+	breaker        *breaker.Breaker
+	limiter        *concurrency.Limiter
+	requestTimeout time.Duration
+	fallback       string
+	policy         retryPolicy
+	budget         *retryBudget
+	latencies      *latencyWindow
+	prober         *prober
+}
+
+// Name returns pool name.
+func (p *Pool) Name() string {
+	return p.name
+}
+
+// Client returns lc-renderer client of the pool.
+func (p *Pool) Client() render.ChartRendererClient {
+	return p.client
+}
+
+// RequestTimeout returns lc-renderer request timeout of the pool.
+func (p *Pool) RequestTimeout() time.Duration {
+	return p.requestTimeout
+}
+
+// BreakerState returns circuit breaker state of the pool.
+func (p *Pool) BreakerState() breaker.State {
+	return p.breaker.State()
+}
+
+// IsHealthy reports if the pool passes its health probes and its circuit breaker isn't open.
+// Connection state is checked instead of the probes if probing is disabled.
+func (p *Pool) IsHealthy() bool {
+	if p.breaker.State() == breaker.StateOpen {
+		return false
+	}
+
+	if p.prober != nil {
+		return p.prober.isHealthy()
+	}
+
+	state := p.conn.GetState()
+
+	return state == connectivity.Ready || state == connectivity.Idle
+}
+
+// Health represents health of the lc-renderer pools keyed by their names.
+type Health map[string]bool
+
+// Healthy reports if at least one pool is healthy, requests of the unhealthy pools fail over to it.
+func (h Health) Healthy() bool {
+	for _, healthy := range h {
+		if healthy {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Pools routes render requests between named lc-renderer pools.
+// Requests are routed by the first matching route or to the default pool,
+// requests of the unhealthy pool fail over to its fallback pool.
+type Pools struct {
+	pools  map[string]*Pool
+	routes []route
+}
+
+type route struct {
+	pool     string
+	tenant   string
+	minArea  int64
+	maxArea  int64
+	minViews int
+	maxViews int
+}
+
+type poolsJSON struct {
+	Pools  map[string]poolJSON `json:"pools"`
+	Routes []routeJSON         `json:"routes"`
+}
+
+type poolJSON struct {
+	Address               string `json:"address"`
+	TLSServerName         string `json:"tls_server_name"`
+	RequestTimeoutSeconds int    `json:"request_timeout"`
+	Fallback              string `json:"fallback"`
+}
+
+type routeJSON struct {
+	Pool     string `json:"pool"`
+	Tenant   string `json:"tenant"`
+	MinArea  int64  `json:"min_area"`
+	MaxArea  int64  `json:"max_area"`
+	MinViews int    `json:"min_views"`
+	MaxViews int    `json:"max_views"`
+}
+
+// NewPools connects to the lc-renderer pools.
+// Default pool uses the renderer address, other pools and routes are read from the JSON pools file if it's configured.
+// All pools share the renderer connection timeout, TLS certificates, retry policy and circuit breaker configuration,
+// every pool has its own retry budget, circuit breaker which state is recorded by the renderer_breaker_state metric
+// and concurrency limiter.
+// Every pool is probed in background if probing is enabled, probing is stopped by Close.
+func NewPools(ctx context.Context, rendererCfg config.RendererConfig, rendererCerts *tlsutils.Certificates, pRec metric.PromRecorder) (*Pools, error) {
+	policy, err := newRetryPolicy(rendererCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	probe, err := newProbePolicy(rendererCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	poolsCfg, err := readPoolsFile(rendererCfg.PoolsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Pools{
+		pools:  make(map[string]*Pool, len(poolsCfg.Pools)+1),
+		routes: make([]route, 0, len(poolsCfg.Routes)),
+	}
+
+	defaultPoolJSON := poolsCfg.Pools[DefaultPool]
+	defaultPoolJSON.Address = rendererCfg.Address
+	poolsCfg.Pools[DefaultPool] = defaultPoolJSON
+
+	if err := validatePools(poolsCfg); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrBadPoolsFile, err)
+	}
+
+	for _, routeJSON := range poolsCfg.Routes {
+		p.routes = append(p.routes, route{
+			pool:     routeJSON.Pool,
+			tenant:   routeJSON.Tenant,
+			minArea:  routeJSON.MinArea,
+			maxArea:  routeJSON.MaxArea,
+			minViews: routeJSON.MinViews,
+			maxViews: routeJSON.MaxViews,
+		})
+	}
+
+	// Pools are connected in a stable order so the errors are reproducible.
+	for _, name := range sortedPoolNames(poolsCfg.Pools) {
+		poolJSON := poolsCfg.Pools[name]
+
+		poolRendererCfg := rendererCfg
+		poolRendererCfg.Address = poolJSON.Address
+
+		if poolJSON.TLSServerName != "" {
+			poolRendererCfg.TLSServerName = poolJSON.TLSServerName
+		}
+
+		requestTimeout := time.Duration(rendererCfg.RequestTimeoutSeconds) * time.Second
+		if poolJSON.RequestTimeoutSeconds > 0 {
+			requestTimeout = time.Duration(poolJSON.RequestTimeoutSeconds) * time.Second
+		}
+
+		stateGauge := pRec.RendererBreakerState().WithLabelValues(name)
+
+		poolBreaker, err := breaker.NewBreaker(rendererCfg, func(state breaker.State) {
+			stateGauge.Set(float64(state))
+		})
+		if err != nil {
+			p.Close()
+
+			// nolint: wrapcheck
+			return nil, err
+		}
+
+		limiter, err := concurrency.NewLimiter(rendererCfg, pRec, name)
+		if err != nil {
+			p.Close()
+
+			// nolint: wrapcheck
+			return nil, err
+		}
+
+		conn, err := NewConn(ctx, poolRendererCfg, rendererCerts)
+		if err != nil {
+			p.Close()
+
+			return nil, fmt.Errorf("pool %q: %w", name, err)
+		}
+
+		pool := &Pool{
+			name:           name,
+			conn:           conn,
+			client:         breaker.NewClient(render.NewChartRendererClient(conn), poolBreaker),
+			breaker:        poolBreaker,
+			limiter:        limiter,
+			requestTimeout: requestTimeout,
+			fallback:       poolJSON.Fallback,
+			policy:         policy,
+			budget:         newRetryBudget(policy),
+		}
+
+		if policy.hedgePercentile > 0 {
+			pool.latencies = newLatencyWindow()
+		}
+
+		if probe.enabled() {
+			pool.prober = newProber(probe, conn)
+
+			go pool.prober.run()
+		}
+
+		p.pools[name] = pool
+	}
+
+	return p, nil
+}
+
+// Route returns the pool for the render request of the tenant.
+// Fallback pools are followed while the pool is unhealthy,
+// the routed pool is returned if none of its fallback pools are healthy.
+func (p *Pools) Route(tenant string, req *render.RenderChartRequest) *Pool {
+	routed := p.pools[p.match(tenant, req)]
+
+	visited := make(map[string]struct{})
+
+	for pool := routed; pool != nil; pool = p.pools[pool.fallback] {
+		if _, ok := visited[pool.name]; ok {
+			break
+		}
+
+		if pool.IsHealthy() {
+			return pool
+		}
+
+		visited[pool.name] = struct{}{}
+	}
+
+	return routed
+}
+
+// Health returns health of all pools.
+func (p *Pools) Health() Health {
+	health := make(Health, len(p.pools))
+
+	for name, pool := range p.pools {
+		health[name] = pool.IsHealthy()
+	}
+
+	return health
+}
+
+// Pool returns the pool by name or nil if it doesn't exist.
+func (p *Pools) Pool(name string) *Pool {
+	return p.pools[name]
+}
+
+// Close stops probing and closes connections of all pools.
+func (p *Pools) Close() {
+	for _, pool := range p.pools {
+		if pool.prober != nil {
+			pool.prober.stop()
+		}
+
+		pool.conn.Close()
+	}
+}
+
+// match returns name of the pool of the first matching route or the default pool.
+func (p *Pools) match(tenant string, req *render.RenderChartRequest) string {
+	area := int64(req.GetSizes().GetWidth().GetValue()) * int64(req.GetSizes().GetHeight().GetValue())
+	views := len(req.GetViews())
+
+	for _, r := range p.routes {
+		if r.matches(tenant, area, views) {
+			return r.pool
+		}
+	}
+
+	return DefaultPool
+}
+
+// matches reports if all conditions of the route are met, zero values are not checked.
+func (r route) matches(tenant string, area int64, views int) bool {
+	switch {
+	case r.tenant != "" && r.tenant != tenant:
+		return false
+	case r.minArea > 0 && area < r.minArea:
+		return false
+	case r.maxArea > 0 && area > r.maxArea:
+		return false
+	case r.minViews > 0 && views < r.minViews:
+		return false
+	case r.maxViews > 0 && views > r.maxViews:
+		return false
+	default:
+		return true
+	}
+}
+
+func readPoolsFile(path string) (poolsJSON, error) {
+	poolsCfg := poolsJSON{}
+
+	if path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return poolsJSON{}, fmt.Errorf("unable to read renderer pools file: %w", err)
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+
+		if err := dec.Decode(&poolsCfg); err != nil {
+			return poolsJSON{}, fmt.Errorf("%w: %s", ErrBadPoolsFile, err)
+		}
+
+		if _, ok := poolsCfg.Pools[DefaultPool]; ok && poolsCfg.Pools[DefaultPool].Address != "" {
+			return poolsJSON{}, fmt.Errorf("%w: address of the %s pool is configured by the renderer address", ErrBadPoolsFile, DefaultPool)
+		}
+	}
+
+	if poolsCfg.Pools == nil {
+		poolsCfg.Pools = make(map[string]poolJSON)
+	}
+
+	return poolsCfg, nil
+}
+
+func validatePools(poolsCfg poolsJSON) error {
+	for _, name := range sortedPoolNames(poolsCfg.Pools) {
+		poolJSON := poolsCfg.Pools[name]
+
+		switch {
+		case poolJSON.Address == "" && name != DefaultPool:
+			return fmt.Errorf("pool %q: address should not be empty", name)
+		case poolJSON.RequestTimeoutSeconds < 0:
+			return fmt.Errorf("pool %q: request_timeout should not be negative", name)
+		case poolJSON.Fallback == name:
+			return fmt.Errorf("pool %q: fallback should be another pool", name)
+		}
+
+		if _, ok := poolsCfg.Pools[poolJSON.Fallback]; poolJSON.Fallback != "" && !ok {
+			return fmt.Errorf("pool %q: unknown fallback pool %q", name, poolJSON.Fallback)
+		}
+	}
+
+	for i, routeJSON := range poolsCfg.Routes {
+		if _, ok := poolsCfg.Pools[routeJSON.Pool]; !ok {
+			return fmt.Errorf("route %d: unknown pool %q", i, routeJSON.Pool)
+		}
+
+		if routeJSON.MinArea < 0 || routeJSON.MaxArea < 0 || routeJSON.MinViews < 0 || routeJSON.MaxViews < 0 {
+			return fmt.Errorf("route %d: area and views bounds should not be negative", i)
+		}
+	}
+
+	return nil
+}
+
+func sortedPoolNames(pools map[string]poolJSON) []string {
+	names := make([]string, 0, len(pools))
+	for name := range pools {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
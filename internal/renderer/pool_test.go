@@ -0,0 +1,286 @@
+package renderer_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/limpidchart/lc-api/internal/breaker"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/renderer"
+	"github.com/limpidchart/lc-api/internal/testutils"
+)
+
+func writePools(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "renderer-pools.json")
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("unable to write renderer pools file: %s", err)
+	}
+
+	return path
+}
+
+func startTestingRenderer(ctx context.Context, t *testing.T) string {
+	t.Helper()
+
+	chartRendererServer, err := testutils.NewTestingChartRendererServer(testutils.Opts{
+		ChartData: []byte("chart svg"),
+		FailMsg:   "",
+		Latency:   time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unable to configure testing lc-renderer server: %s", err)
+	}
+
+	go func() {
+		if serveErr := chartRendererServer.Serve(ctx); serveErr != nil {
+			t.Errorf("unable to start testing lc-renderer server: %s", serveErr)
+
+			return
+		}
+	}()
+
+	return chartRendererServer.Address()
+}
+
+func renderChartRequest(width, height int32, views int) *render.RenderChartRequest {
+	req := &render.RenderChartRequest{
+		Sizes: &render.ChartSizes{Width: wrapperspb.Int32(width), Height: wrapperspb.Int32(height)},
+	}
+
+	for i := 0; i < views; i++ {
+		req.Views = append(req.Views, &render.ChartView{})
+	}
+
+	return req
+}
+
+func TestPools_Route(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	heavyCtx, heavyCancel := context.WithCancel(ctx)
+	defer heavyCancel()
+
+	defaultAddress := startTestingRenderer(ctx, t)
+	smallAddress := startTestingRenderer(ctx, t)
+	heavyAddress := startTestingRenderer(heavyCtx, t)
+
+	poolsPath := writePools(t, fmt.Sprintf(`{
+  "pools": {
+    "small": {"address": %q, "fallback": "default"},
+    "heavy": {"address": %q, "request_timeout": 60, "fallback": "default"}
+  },
+  "routes": [
+    {"pool": "heavy", "tenant": "acme"},
+    {"pool": "heavy", "min_area": 1000000},
+    {"pool": "heavy", "min_views": 5},
+    {"pool": "small", "max_area": 100000, "max_views": 1}
+  ]
+}`, smallAddress, heavyAddress))
+
+	pools, err := renderer.NewPools(ctx, config.RendererConfig{
+		Address:               defaultAddress,
+		ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
+		RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
+		PoolsPath:             poolsPath,
+	}, nil, metric.NewEmptyRecorder())
+	if err != nil {
+		t.Fatalf("unable to connect to lc-renderer pools: %s", err)
+	}
+
+	defer pools.Close()
+
+	assert.Equal(t, renderer.Health{"default": true, "small": true, "heavy": true}, pools.Health())
+	assert.Equal(t, time.Second*60, pools.Pool("heavy").RequestTimeout())
+	assert.Equal(t, time.Second*testutils.RendererRequestTimeoutSecs, pools.Pool("small").RequestTimeout())
+
+	tt := []struct {
+		name     string
+		tenant   string
+		req      *render.RenderChartRequest
+		expected string
+	}{
+		{"tenant", "acme", renderChartRequest(100, 100, 1), "heavy"},
+		{"big_area", "", renderChartRequest(2000, 1000, 1), "heavy"},
+		{"many_views", "", renderChartRequest(300, 300, 5), "heavy"},
+		{"small_chart", "", renderChartRequest(300, 300, 1), "small"},
+		{"small_chart_with_views", "", renderChartRequest(300, 300, 2), "default"},
+		{"medium_chart", "", renderChartRequest(800, 600, 1), "default"},
+	}
+
+	for _, tc := range tt {
+		assert.Equal(t, tc.expected, pools.Route(tc.tenant, tc.req).Name(), tc.name)
+	}
+
+	// Requests of the stopped pool fail over to its fallback pool.
+	heavyCancel()
+
+	for pools.Pool("heavy").IsHealthy() {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("heavy pool is still healthy after its lc-renderer is stopped")
+		case <-time.After(time.Millisecond * 10):
+		}
+	}
+
+	health := pools.Health()
+	assert.False(t, health["heavy"])
+	assert.True(t, health.Healthy())
+	assert.Equal(t, "default", pools.Route("acme", renderChartRequest(100, 100, 1)).Name())
+}
+
+func TestPools_Breaker(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	failingRendererServer, err := testutils.NewTestingChartRendererServer(testutils.Opts{
+		FailMsg:  "connection reset by peer",
+		FailCode: codes.Unavailable,
+		Latency:  time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unable to configure testing lc-renderer server: %s", err)
+	}
+
+	go func() {
+		if serveErr := failingRendererServer.Serve(ctx); serveErr != nil {
+			t.Errorf("unable to start testing lc-renderer server: %s", serveErr)
+
+			return
+		}
+	}()
+
+	spareAddress := startTestingRenderer(ctx, t)
+	poolsPath := writePools(t, fmt.Sprintf(`{
+  "pools": {
+    "default": {"fallback": "spare"},
+    "spare": {"address": %q}
+  }
+}`, spareAddress))
+
+	pRec := metric.NewEmptyRecorder()
+
+	pools, err := renderer.NewPools(ctx, config.RendererConfig{
+		Address:                   failingRendererServer.Address(),
+		ConnTimeoutSeconds:        testutils.RendererConnTimeoutSecs,
+		PoolsPath:                 poolsPath,
+		BreakerWindowSeconds:      60,
+		BreakerMinRequests:        2,
+		BreakerErrorPercent:       50,
+		BreakerOpenTimeoutSeconds: 60,
+		BreakerHalfOpenRequests:   1,
+	}, nil, pRec)
+	if err != nil {
+		t.Fatalf("unable to connect to lc-renderer pools: %s", err)
+	}
+
+	defer pools.Close()
+
+	for i := 0; i < 2; i++ {
+		_, renderErr := pools.Route("", renderChartRequest(100, 100, 1)).RenderChart(ctx, renderChartRequest(100, 100, 1))
+		assert.Equal(t, codes.Unavailable, status.Code(renderErr))
+	}
+
+	// Requests of the pool with the open breaker fail over to its fallback pool.
+	assert.Equal(t, breaker.StateOpen, pools.Pool(renderer.DefaultPool).BreakerState())
+	assert.Equal(t, renderer.Health{"default": false, "spare": true}, pools.Health())
+	assert.Equal(t, "spare", pools.Route("", renderChartRequest(100, 100, 1)).Name())
+	assert.Equal(t, float64(breaker.StateOpen), testutil.ToFloat64(pRec.RendererBreakerState().WithLabelValues(renderer.DefaultPool)))
+	assert.Equal(t, float64(breaker.StateClosed), testutil.ToFloat64(pRec.RendererBreakerState().WithLabelValues("spare")))
+
+	_, renderErr := pools.Pool(renderer.DefaultPool).RenderChart(ctx, renderChartRequest(100, 100, 1))
+	assert.True(t, breaker.IsOpenErr(renderErr))
+	assert.Equal(t, 2, failingRendererServer.Requests())
+}
+
+func TestNewPools_Err(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			"not_json",
+			`pools: {}`,
+			"bad renderer pools file: invalid character 'p' looking for beginning of value",
+		},
+		{
+			"unknown_field",
+			`{"pools": {"heavy": {"addr": "localhost:54021"}}}`,
+			`bad renderer pools file: json: unknown field "addr"`,
+		},
+		{
+			"default_address",
+			`{"pools": {"default": {"address": "localhost:54021"}}}`,
+			"bad renderer pools file: address of the default pool is configured by the renderer address",
+		},
+		{
+			"empty_address",
+			`{"pools": {"heavy": {}}}`,
+			`bad renderer pools file: pool "heavy": address should not be empty`,
+		},
+		{
+			"negative_timeout",
+			`{"pools": {"heavy": {"address": "localhost:54021", "request_timeout": -1}}}`,
+			`bad renderer pools file: pool "heavy": request_timeout should not be negative`,
+		},
+		{
+			"self_fallback",
+			`{"pools": {"heavy": {"address": "localhost:54021", "fallback": "heavy"}}}`,
+			`bad renderer pools file: pool "heavy": fallback should be another pool`,
+		},
+		{
+			"unknown_fallback",
+			`{"pools": {"default": {"fallback": "small"}}}`,
+			`bad renderer pools file: pool "default": unknown fallback pool "small"`,
+		},
+		{
+			"unknown_route_pool",
+			`{"routes": [{"pool": "heavy", "min_views": 5}]}`,
+			`bad renderer pools file: route 0: unknown pool "heavy"`,
+		},
+		{
+			"negative_route_bounds",
+			`{"routes": [{"pool": "default", "min_area": -1}]}`,
+			"bad renderer pools file: route 0: area and views bounds should not be negative",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pools, err := renderer.NewPools(context.Background(), config.RendererConfig{
+				Address:            "localhost:54020",
+				ConnTimeoutSeconds: 1,
+				PoolsPath:          writePools(t, tc.content),
+			}, nil, metric.NewEmptyRecorder())
+			assert.Nil(t, pools)
+			assert.True(t, errors.Is(err, renderer.ErrBadPoolsFile))
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package renderer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/convert"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/validate/apitorenderer"
+)
+
+// probeRequestID is the request ID of the canary chart rendering.
+const probeRequestID = "lc-api-probe"
+
+// ErrBadProbeConfig contains error message about lc-renderer probe configuration that can't be used.
+var ErrBadProbeConfig = errors.New("bad lc-renderer probe configuration")
+
+// probePolicy represents health probing configuration that is shared by all pools.
+type probePolicy struct {
+	interval           time.Duration
+	timeout            time.Duration
+	checkHealth        bool
+	checkRender        bool
+	healthService      string
+	healthyThreshold   int
+	unhealthyThreshold int
+	canary             *render.RenderChartRequest
+}
+
+func newProbePolicy(rendererCfg config.RendererConfig) (probePolicy, error) {
+	if rendererCfg.ProbeIntervalSeconds == 0 {
+		return probePolicy{}, nil
+	}
+
+	switch {
+	case rendererCfg.ProbeIntervalSeconds < 0:
+		return probePolicy{}, fmt.Errorf("%w: interval should not be negative", ErrBadProbeConfig)
+	case rendererCfg.ProbeTimeoutSeconds <= 0:
+		return probePolicy{}, fmt.Errorf("%w: timeout should be positive", ErrBadProbeConfig)
+	case rendererCfg.ProbeHealthyThreshold <= 0 || rendererCfg.ProbeUnhealthyThreshold <= 0:
+		return probePolicy{}, fmt.Errorf("%w: healthy and unhealthy thresholds should be positive", ErrBadProbeConfig)
+	}
+
+	policy := probePolicy{
+		interval:           time.Duration(rendererCfg.ProbeIntervalSeconds) * time.Second,
+		timeout:            time.Duration(rendererCfg.ProbeTimeoutSeconds) * time.Second,
+		healthService:      rendererCfg.ProbeHealthService,
+		healthyThreshold:   rendererCfg.ProbeHealthyThreshold,
+		unhealthyThreshold: rendererCfg.ProbeUnhealthyThreshold,
+	}
+
+	switch rendererCfg.ProbeKind {
+	case config.RendererProbeHealth:
+		policy.checkHealth = true
+	case config.RendererProbeRender:
+		policy.checkRender = true
+	case config.RendererProbeBoth:
+		policy.checkHealth = true
+		policy.checkRender = true
+	default:
+		return probePolicy{}, fmt.Errorf("%w: unknown probe kind %q", ErrBadProbeConfig, rendererCfg.ProbeKind)
+	}
+
+	if policy.checkRender {
+		canary, err := canaryRequest()
+		if err != nil {
+			return probePolicy{}, fmt.Errorf("%w: %s", ErrBadProbeConfig, err)
+		}
+
+		policy.canary = canary
+	}
+
+	return policy, nil
+}
+
+func (p probePolicy) enabled() bool {
+	return p.interval > 0
+}
+
+// canaryRequest returns the smallest line chart that lc-renderer should always be able to render.
+func canaryRequest() (*render.RenderChartRequest, error) {
+	// nolint: gomnd
+	req, err := convert.CreateChartRequestToRenderChartRequest(&render.CreateChartRequest{
+		Sizes: &render.ChartSizes{
+			Width:  wrapperspb.Int32(100),
+			Height: wrapperspb.Int32(100),
+		},
+		Margins: &render.ChartMargins{
+			MarginTop:    wrapperspb.Int32(10),
+			MarginBottom: wrapperspb.Int32(10),
+			MarginLeft:   wrapperspb.Int32(10),
+			MarginRight:  wrapperspb.Int32(10),
+		},
+		Axes: &render.ChartAxes{
+			AxisBottom: &render.ChartScale{
+				Kind: render.ChartScale_BAND,
+				Domain: &render.ChartScale_DomainCategories{
+					DomainCategories: &render.DomainCategories{Categories: []string{"A", "B", "C"}},
+				},
+			},
+			AxisLeft: &render.ChartScale{
+				Kind: render.ChartScale_LINEAR,
+				Domain: &render.ChartScale_DomainNumeric{
+					DomainNumeric: &render.DomainNumeric{Start: 0, End: 10},
+				},
+			},
+		},
+		Views: []*render.ChartView{
+			{
+				Kind: render.ChartView_LINE,
+				Values: &render.ChartView_ScalarValues{
+					ScalarValues: &render.ChartViewScalarValues{Values: []float32{1, 5, 10}},
+				},
+			},
+		},
+	}, apitorenderer.Limits{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to prepare canary chart: %w", err)
+	}
+
+	req.RequestId = probeRequestID
+
+	return req, nil
+}
+
+// prober periodically probes lc-renderer of a pool and keeps its health verdict.
+// Verdict starts healthy and changes only after the configured number of consecutive opposite probe results,
+// so a single failed or succeeded probe doesn't flap the pool health.
+type prober struct {
+	policy       probePolicy
+	healthClient grpc_health_v1.HealthClient
+	renderClient render.ChartRendererClient
+	ctx          context.Context
+	stop         context.CancelFunc
+
+	mu        sync.Mutex
+	healthy   bool
+	successes int
+	failures  int
+}
+
+// newProber returns a new prober for the pool connection.
+// Canary charts are rendered without the pool circuit breaker, so probes don't affect its state.
+func newProber(policy probePolicy, conn *grpc.ClientConn) *prober {
+	ctx, stop := context.WithCancel(context.Background())
+
+	return &prober{
+		policy:       policy,
+		healthClient: grpc_health_v1.NewHealthClient(conn),
+		renderClient: render.NewChartRendererClient(conn),
+		ctx:          ctx,
+		stop:         stop,
+		healthy:      true,
+	}
+}
+
+// run probes lc-renderer right away and then every interval until the prober is stopped,
+// probe results after the stop are dropped.
+func (p *prober) run() {
+	ticker := time.NewTicker(p.policy.interval)
+	defer ticker.Stop()
+
+	for {
+		ok := p.probe()
+
+		select {
+		case <-p.ctx.Done():
+			return
+		default:
+			p.record(ok)
+		}
+
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probe reports if all configured checks of lc-renderer succeed within the probe timeout.
+func (p *prober) probe() bool {
+	ctx, cancel := context.WithTimeout(p.ctx, p.policy.timeout)
+	defer cancel()
+
+	if p.policy.checkHealth {
+		reply, err := p.healthClient.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: p.policy.healthService})
+		if err != nil || reply.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+			return false
+		}
+	}
+
+	if p.policy.checkRender {
+		if _, err := p.renderClient.RenderChart(ctx, p.policy.canary); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *prober) record(ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if ok {
+		p.failures = 0
+		p.successes++
+
+		if !p.healthy && p.successes >= p.policy.healthyThreshold {
+			p.healthy = true
+		}
+
+		return
+	}
+
+	p.successes = 0
+	p.failures++
+
+	if p.healthy && p.failures >= p.policy.unhealthyThreshold {
+		p.healthy = false
+	}
+}
+
+func (p *prober) isHealthy() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.healthy
+}
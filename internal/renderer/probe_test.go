@@ -0,0 +1,170 @@
+package renderer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/renderer"
+	"github.com/limpidchart/lc-api/internal/testutils"
+)
+
+func probeConfig(kind string, healthyThreshold, unhealthyThreshold int) config.RendererConfig {
+	return config.RendererConfig{
+		ProbeIntervalSeconds:    1,
+		ProbeTimeoutSeconds:     1,
+		ProbeKind:               kind,
+		ProbeHealthyThreshold:   healthyThreshold,
+		ProbeUnhealthyThreshold: unhealthyThreshold,
+	}
+}
+
+func waitForHealth(ctx context.Context, t *testing.T, pool *renderer.Pool, healthy bool) {
+	t.Helper()
+
+	for pool.IsHealthy() != healthy {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("pool health is not changed to %t", healthy)
+		case <-time.After(time.Millisecond * 10):
+		}
+	}
+}
+
+func TestPools_ProbeRender(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	// Canary charts of the first two probes fail, so the pool becomes unhealthy and then recovers.
+	pool, chartRendererServer := newRetryTestingPool(ctx, t, testutils.Opts{
+		FailMsg:   "unable to render chart",
+		FailCode:  codes.Internal,
+		FailFirst: 2,
+		ChartData: []byte("chart svg"),
+		Latency:   time.Millisecond,
+	}, probeConfig(config.RendererProbeRender, 2, 2))
+
+	assert.True(t, pool.IsHealthy())
+
+	waitForHealth(ctx, t, pool, false)
+	assert.Equal(t, 2, chartRendererServer.Requests())
+
+	// Single successful probe doesn't make the pool healthy.
+	for chartRendererServer.Requests() < 3 {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("pool is not probed")
+		case <-time.After(time.Millisecond * 10):
+		}
+	}
+
+	time.Sleep(time.Millisecond * 100)
+	assert.False(t, pool.IsHealthy())
+
+	waitForHealth(ctx, t, pool, true)
+	assert.Equal(t, 4, chartRendererServer.Requests())
+}
+
+func TestPools_ProbeHealth(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	chartRendererServer, err := testutils.NewTestingChartRendererServer(testutils.Opts{
+		ChartData: []byte("chart svg"),
+		Latency:   time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unable to configure testing lc-renderer server: %s", err)
+	}
+
+	chartRendererServer.SetServing(false)
+
+	go func() {
+		if serveErr := chartRendererServer.Serve(ctx); serveErr != nil {
+			t.Errorf("unable to start testing lc-renderer server: %s", serveErr)
+
+			return
+		}
+	}()
+
+	rendererCfg := probeConfig(config.RendererProbeHealth, 1, 1)
+	rendererCfg.Address = chartRendererServer.Address()
+	rendererCfg.ConnTimeoutSeconds = testutils.RendererConnTimeoutSecs
+
+	pools, err := renderer.NewPools(ctx, rendererCfg, nil, metric.NewEmptyRecorder())
+	if err != nil {
+		t.Fatalf("unable to connect to lc-renderer pools: %s", err)
+	}
+
+	defer pools.Close()
+
+	// Pool is unhealthy while its lc-renderer accepts connections but isn't serving.
+	waitForHealth(ctx, t, pools.Pool(renderer.DefaultPool), false)
+	assert.False(t, pools.Health().Healthy())
+
+	chartRendererServer.SetServing(true)
+
+	waitForHealth(ctx, t, pools.Pool(renderer.DefaultPool), true)
+	assert.True(t, pools.Health().Healthy())
+	assert.Equal(t, 0, chartRendererServer.Requests())
+}
+
+func TestNewPools_BadProbeConfig(t *testing.T) {
+	t.Parallel()
+
+	withCfg := func(change func(rendererCfg *config.RendererConfig)) config.RendererConfig {
+		rendererCfg := probeConfig(config.RendererProbeBoth, 2, 3)
+		change(&rendererCfg)
+
+		return rendererCfg
+	}
+
+	tt := []struct {
+		name        string
+		rendererCfg config.RendererConfig
+		expected    string
+	}{
+		{
+			"negative_interval",
+			withCfg(func(c *config.RendererConfig) { c.ProbeIntervalSeconds = -1 }),
+			"bad lc-renderer probe configuration: interval should not be negative",
+		},
+		{
+			"zero_timeout",
+			withCfg(func(c *config.RendererConfig) { c.ProbeTimeoutSeconds = 0 }),
+			"bad lc-renderer probe configuration: timeout should be positive",
+		},
+		{
+			"zero_healthy_threshold",
+			withCfg(func(c *config.RendererConfig) { c.ProbeHealthyThreshold = 0 }),
+			"bad lc-renderer probe configuration: healthy and unhealthy thresholds should be positive",
+		},
+		{
+			"unknown_kind",
+			withCfg(func(c *config.RendererConfig) { c.ProbeKind = "tcp" }),
+			`bad lc-renderer probe configuration: unknown probe kind "tcp"`,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pools, err := renderer.NewPools(context.Background(), tc.rendererCfg, nil, metric.NewEmptyRecorder())
+			assert.Nil(t, pools)
+			assert.True(t, errors.Is(err, renderer.ErrBadProbeConfig))
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package renderer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/storage"
+)
+
+const workersName = "render workers"
+
+// ErrRenderQueueFull contains error message about asynchronous render queue that has no free slots.
+var ErrRenderQueueFull = errors.New("render queue is full")
+
+// ErrRenderQueueStopped contains error message about asynchronous render job that is dropped on shutdown.
+var ErrRenderQueueStopped = errors.New("render queue is stopped before the chart is rendered")
+
+// Queue represents a bounded queue of asynchronous render jobs.
+type Queue struct {
+	jobs  chan renderJob
+	slots chan struct{}
+}
+
+type renderJob struct {
+	opts           CreateChartOpts
+	chart          *render.ChartReply
+	renderChartReq *render.RenderChartRequest
+}
+
+// NewQueue returns a new Queue that can keep up to the configured number of jobs.
+func NewQueue(renderQueueCfg config.RenderQueueConfig) *Queue {
+	return &Queue{
+		jobs:  make(chan renderJob, renderQueueCfg.Size),
+		slots: make(chan struct{}, renderQueueCfg.Size),
+	}
+}
+
+// Len returns number of queued jobs.
+func (q *Queue) Len() int {
+	return len(q.jobs)
+}
+
+// reserve takes a queue slot so the following push doesn't block.
+// It reports if there was a free slot.
+func (q *Queue) reserve() bool {
+	select {
+	case q.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// release frees a queue slot taken by reserve.
+func (q *Queue) release() {
+	<-q.slots
+}
+
+// push adds a job into the queue, a slot should be reserved before.
+func (q *Queue) push(job renderJob) {
+	q.jobs <- job
+}
+
+// Workers represents a pool of workers that render charts from Queue and save results into storage.
+type Workers struct {
+	log     *zerolog.Logger
+	queue   *Queue
+	workers int
+}
+
+// NewWorkers configures a new Workers.
+func NewWorkers(log *zerolog.Logger, queue *Queue, renderQueueCfg config.RenderQueueConfig) *Workers {
+	return &Workers{
+		log:     log,
+		queue:   queue,
+		workers: renderQueueCfg.Workers,
+	}
+}
+
+// Serve processes queued jobs until the provided context is done.
+// Jobs that are still queued after that are saved with ERROR status.
+func (w *Workers) Serve(ctx context.Context) error {
+	done := make(chan struct{}, w.workers)
+
+	for i := 0; i < w.workers; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+
+			for {
+				// Queued jobs are not taken after the context is done even if both are ready.
+				if ctx.Err() != nil {
+					return
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-w.queue.jobs:
+					w.queue.release()
+					w.process(ctx, job)
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < w.workers; i++ {
+		<-done
+	}
+
+	w.log.Info().
+		Time(zerolog.TimestampFieldName, time.Now().UTC()).
+		Msg("Stopping render workers")
+
+	w.drain()
+
+	return nil
+}
+
+// Address returns an empty string since Workers don't listen on any address.
+func (w *Workers) Address() string {
+	return ""
+}
+
+// Name returns workers name.
+func (w *Workers) Name() string {
+	return workersName
+}
+
+func (w *Workers) process(ctx context.Context, job renderJob) {
+	renderReply, rendered, err := renderChartCached(ctx, job.opts, job.renderChartReq, nil)
+	if err != nil {
+		w.fail(job, err)
+
+		return
+	}
+
+	// Chart is charged once it's queued, so its cost is refunded if lc-renderer isn't requested for it.
+	if !rendered {
+		job.opts.Costs.Refund(job.opts.Tenant, job.chart.Cost)
+	}
+
+	job.chart.ChartStatus = render.ChartStatus_CREATED
+	job.chart.ChartData = renderReply.ChartData
+
+	w.update(job)
+}
+
+func (w *Workers) drain() {
+	for {
+		select {
+		case job := <-w.queue.jobs:
+			w.queue.release()
+			w.fail(job, ErrRenderQueueStopped)
+		default:
+			return
+		}
+	}
+}
+
+// fail saves the chart with ERROR status and refunds its render cost.
+func (w *Workers) fail(job renderJob, err error) {
+	job.opts.Costs.Refund(job.opts.Tenant, job.chart.Cost)
+
+	job.chart.ChartStatus = render.ChartStatus_ERROR
+	job.chart.ErrorMessage = err.Error()
+
+	w.update(job)
+}
+
+func (w *Workers) update(job renderJob) {
+	// Storage operations are not cancelled on shutdown so the job result is not lost.
+	err := job.opts.Storage.UpdateChart(context.Background(), job.chart)
+
+	switch {
+	case err == nil:
+		notify(job.opts, job.chart)
+	case errors.Is(err, storage.ErrChartNotFound), errors.Is(err, storage.ErrChartDeleted):
+		// Chart is deleted or expired while it was rendered.
+	default:
+		w.log.Error().
+			Time(zerolog.TimestampFieldName, time.Now().UTC()).
+			Str("request_id", job.opts.RequestID).
+			Str("chart_id", job.chart.ChartId).
+			Err(err).
+			Msg("Unable to save rendered chart")
+	}
+}
@@ -0,0 +1,67 @@
+package renderer_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/cost"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/renderer"
+	"github.com/limpidchart/lc-api/internal/storage"
+	"github.com/limpidchart/lc-api/internal/testutils"
+)
+
+func TestQueue(t *testing.T) {
+	t.Parallel()
+
+	log := zerolog.New(os.Stderr)
+	renderQueueCfg := config.RenderQueueConfig{Workers: 1, Size: 1}
+	queue := renderer.NewQueue(renderQueueCfg)
+	chartStorage := storage.NewMemory()
+	costs := cost.NewAccountant(config.CostConfig{}, metric.NewEmptyRecorder())
+
+	newOpts := func() renderer.CreateChartOpts {
+		req := testutils.NewCreateChartRequest().
+			SetSizes().
+			SetBandBottomAxis().
+			SetLinearLeftAxis().
+			AddAreaView().
+			Unembed()
+		req.Async = true
+
+		return renderer.CreateChartOpts{
+			RequestID: testutils.RandomUUID(t).String(),
+			Request:   req,
+			Storage:   chartStorage,
+			Queue:     queue,
+			Costs:     costs,
+		}
+	}
+
+	pending, err := renderer.CreateChart(context.Background(), newOpts())
+	assert.NoError(t, err)
+	assert.Equal(t, render.ChartStatus_PENDING, pending.ChartStatus)
+	assert.Equal(t, 1, queue.Len())
+
+	_, err = renderer.CreateChart(context.Background(), newOpts())
+	assert.True(t, errors.Is(err, renderer.ErrRenderQueueFull))
+
+	// Jobs that are left in the queue on shutdown should be saved as failed.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.NoError(t, renderer.NewWorkers(&log, queue, renderQueueCfg).Serve(ctx))
+	assert.Equal(t, 0, queue.Len())
+
+	failed, err := chartStorage.GetChart(context.Background(), "", pending.ChartId)
+	assert.NoError(t, err)
+	assert.Equal(t, render.ChartStatus_ERROR, failed.ChartStatus)
+	assert.Equal(t, renderer.ErrRenderQueueStopped.Error(), failed.ErrorMessage)
+}
@@ -4,17 +4,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
 
 	"github.com/limpidchart/lc-api/internal/config"
 	"github.com/limpidchart/lc-api/internal/convert"
+	"github.com/limpidchart/lc-api/internal/cost"
 	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/rendercache"
+	"github.com/limpidchart/lc-api/internal/storage"
+	"github.com/limpidchart/lc-api/internal/tlsutils"
+	"github.com/limpidchart/lc-api/internal/validate/apitorenderer"
+	"github.com/limpidchart/lc-api/internal/webhook"
 )
 
 const rendererServiceCfg = `{"loadBalancingPolicy":"round_robin"}`
@@ -25,17 +36,30 @@ var (
 
 	// ErrGenerateChartIDFailed contains error message about failed chart ID generation.
 	ErrGenerateChartIDFailed = errors.New("unable to generate a random UUID for chart ID")
+
+	// ErrSaveChartFailed contains error message about failed chart saving.
+	ErrSaveChartFailed = errors.New("unable to save chart")
+
+	// ErrBadExpiresIn contains error message about chart expiration duration that is not positive.
+	ErrBadExpiresIn = errors.New("expires_in should be positive")
 )
 
 // NewConn creates a new lc-renderer connection.
-func NewConn(ctx context.Context, rendererCfg config.RendererConfig) (*grpc.ClientConn, error) {
+// Connection uses TLS with the provided certificates, it's not encrypted if they are nil.
+func NewConn(ctx context.Context, rendererCfg config.RendererConfig, rendererCerts *tlsutils.Certificates) (*grpc.ClientConn, error) {
 	rendererConnCtx, rendererConnCancel := context.WithTimeout(ctx, time.Second*time.Duration(rendererCfg.ConnTimeoutSeconds))
 	defer rendererConnCancel()
 
+	transportCreds := grpc.WithInsecure()
+	if rendererCerts != nil {
+		tlsCfg := rendererCerts.ClientConfig(rendererServerName(rendererCfg))
+		transportCreds = grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg))
+	}
+
 	rendererConn, err := grpc.DialContext(
 		rendererConnCtx,
 		rendererCfg.Address,
-		grpc.WithInsecure(),
+		transportCreds,
 		grpc.WithBlock(),
 		grpc.WithDefaultServiceConfig(rendererServiceCfg),
 	)
@@ -46,65 +70,260 @@ func NewConn(ctx context.Context, rendererCfg config.RendererConfig) (*grpc.Clie
 	return rendererConn, nil
 }
 
+// rendererServerName returns the configured lc-renderer server name or the host of its address.
+func rendererServerName(rendererCfg config.RendererConfig) string {
+	if rendererCfg.TLSServerName != "" {
+		return rendererCfg.TLSServerName
+	}
+
+	// Address can contain resolver scheme like dns:///localhost:54020.
+	hostPort := rendererCfg.Address
+	if i := strings.LastIndex(hostPort, "/"); i >= 0 {
+		hostPort = hostPort[i+1:]
+	}
+
+	host, _, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return hostPort
+	}
+
+	return host
+}
+
 // CreateChartOpts represents options for CreateChart method.
+// Positive Timeout overrides lc-renderer request timeout of the pool that renders the chart.
 type CreateChartOpts struct {
-	RequestID      string
-	Request        *render.CreateChartRequest
-	RendererClient render.ChartRendererClient
-	Timeout        time.Duration
+	RequestID   string
+	Tenant      string
+	Limits      apitorenderer.Limits
+	CostLimits  cost.Limits
+	Request     *render.CreateChartRequest
+	Renderers   *Pools
+	Timeout     time.Duration
+	Storage     storage.Storage
+	ChartTTL    time.Duration
+	RenderCache *rendercache.Cache
+	Coalescer   *Coalescer
+	Queue       *Queue
+	Webhooks    *webhook.Queue
+	Costs       *cost.Accountant
 }
 
-// CreateChart converts render.CreateChartRequest and requests a chart rendering from lc-renderer.
+// CreateChart converts render.CreateChartRequest, requests a chart rendering from lc-renderer
+// and saves the rendered chart into storage.
+// Chart expires after the request expires_in duration or after the default ChartTTL if it's not set.
+// Zero ChartTTL means that charts don't expire by default.
+// Chart is saved with PENDING status and rendered by Workers in background if the request is asynchronous.
+// Final chart representation is delivered to the request callback URL if it's set.
+// Chart is owned by the provided tenant and validated against the tenant limits.
+// Render cost of the chart is returned in the chart reply and charged from the tenant budget only if the chart is
+// rendered by lc-renderer for this request: charts from the render cache and charts of the requests that join
+// an identical in-flight render are not charged. Asynchronous charts are charged once they are queued and refunded
+// if they are not rendered for this request. Cost is refunded if the chart isn't created or its rendering fails.
 //
 // Note: tests are implemented in internal/servergrpc package.
 func CreateChart(ctx context.Context, opts CreateChartOpts) (*render.ChartReply, error) {
 	now := time.Now().UTC()
 
-	renderChartReq, err := convert.CreateChartRequestToRenderChartRequest(opts.Request)
+	chartTTL, err := chartTTLFromRequest(opts.Request, opts.ChartTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Request.CallbackUrl != "" {
+		if err := opts.Webhooks.ValidateCallbackURL(opts.Request.CallbackUrl); err != nil {
+			return nil, err
+		}
+	}
+
+	renderChartReq, err := convert.CreateChartRequestToRenderChartRequest(opts.Request, opts.Limits)
 	if err != nil {
 		return nil, err
 	}
 
 	renderChartReq.RequestId = opts.RequestID
 
-	rendererCtx, rendererCancel := context.WithTimeout(ctx, opts.Timeout)
-	defer rendererCancel()
+	renderCost, err := opts.Costs.Price(renderChartReq, opts.CostLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	createChart := createChartSync
+	if opts.Request.Async {
+		createChart = createChartAsync
+	}
+
+	return createChart(ctx, opts, renderChartReq, now, chartTTL, renderCost)
+}
+
+// createChartSync renders and saves the chart.
+// Render cost is charged right before lc-renderer is requested.
+func createChartSync(ctx context.Context, opts CreateChartOpts, renderChartReq *render.RenderChartRequest, now time.Time, chartTTL time.Duration, renderCost int64) (*render.ChartReply, error) {
+	renderReply, charged, err := renderChartCached(ctx, opts, renderChartReq, func() error {
+		return opts.Costs.Charge(opts.Tenant, renderCost, opts.CostLimits)
+	})
+
+	refund := func() {
+		if charged {
+			opts.Costs.Refund(opts.Tenant, renderCost)
+		}
+	}
+
+	if err != nil {
+		refund()
+
+		return nil, err
+	}
+
+	chartID, err := uuid.NewRandom()
+	if err != nil {
+		refund()
+
+		return nil, ErrGenerateChartIDFailed
+	}
+
+	chartReply := convert.RenderChartReplyToAPIChartReply(opts.RequestID, chartID.String(), renderChartReq.Title, now, renderReply)
+	chartReply.Tenant = opts.Tenant
+	chartReply.Cost = renderCost
+	setExpiresAt(chartReply, now, chartTTL)
+
+	if err := opts.Storage.SaveChart(ctx, chartReply); err != nil {
+		refund()
+
+		return nil, fmt.Errorf("%w: %s", ErrSaveChartFailed, err)
+	}
+
+	notify(opts, chartReply)
+
+	return chartReply, nil
+}
+
+// notify queues delivery of the chart to the request callback URL if it's set.
+func notify(opts CreateChartOpts, chartReply *render.ChartReply) {
+	if opts.Request.CallbackUrl != "" {
+		opts.Webhooks.Push(opts.Request.CallbackUrl, chartReply)
+	}
+}
+
+// createChartAsync saves a PENDING chart and queues its rendering.
+// Render cost is charged before the chart is queued, so the budget is checked synchronously.
+func createChartAsync(ctx context.Context, opts CreateChartOpts, renderChartReq *render.RenderChartRequest, now time.Time, chartTTL time.Duration, renderCost int64) (*render.ChartReply, error) {
+	chartID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, ErrGenerateChartIDFailed
+	}
+
+	chartReply := &render.ChartReply{
+		RequestId:   opts.RequestID,
+		ChartId:     chartID.String(),
+		ChartStatus: render.ChartStatus_PENDING,
+		CreatedAt:   timestamppb.New(now),
+		Title:       renderChartReq.Title,
+		Tenant:      opts.Tenant,
+		Cost:        renderCost,
+	}
+	setExpiresAt(chartReply, now, chartTTL)
+
+	if !opts.Queue.reserve() {
+		return nil, ErrRenderQueueFull
+	}
+
+	if err := opts.Costs.Charge(opts.Tenant, renderCost, opts.CostLimits); err != nil {
+		opts.Queue.release()
+
+		return nil, err
+	}
+
+	if err := opts.Storage.SaveChart(ctx, chartReply); err != nil {
+		opts.Queue.release()
+		opts.Costs.Refund(opts.Tenant, renderCost)
+
+		return nil, fmt.Errorf("%w: %s", ErrSaveChartFailed, err)
+	}
+
+	// Job has its own copy of the chart since the reply is returned to the caller.
+	// nolint: forcetypeassert
+	opts.Queue.push(renderJob{
+		opts:           opts,
+		chart:          proto.Clone(chartReply).(*render.ChartReply),
+		renderChartReq: renderChartReq,
+	})
+
+	return chartReply, nil
+}
+
+func setExpiresAt(chartReply *render.ChartReply, now time.Time, chartTTL time.Duration) {
+	if chartTTL > 0 {
+		chartReply.ExpiresAt = timestamppb.New(now.Add(chartTTL))
+	}
+}
+
+// renderChartCached returns chart data from the render cache or requests it from lc-renderer and caches it.
+// Concurrent identical requests that are routed to the same pool with the same timeout share a single lc-renderer call,
+// its retries and hedged requests are done within the request timeout. Optional lead function is called before this request starts a new lc-renderer call,
+// the call isn't started if it fails. It reports if lc-renderer call is started by this request.
+func renderChartCached(ctx context.Context, opts CreateChartOpts, renderChartReq *render.RenderChartRequest, lead func() error) (*render.RenderChartReply, bool, error) {
+	cacheKey, err := rendercache.Key(renderChartReq)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if chartData, ok := opts.RenderCache.Get(cacheKey); ok {
+		return &render.RenderChartReply{RequestId: renderChartReq.RequestId, ChartData: chartData}, false, nil
+	}
+
+	pool := opts.Renderers.Route(opts.Tenant, renderChartReq)
+
+	timeout := pool.RequestTimeout()
+	if opts.Timeout > 0 {
+		timeout = opts.Timeout
+	}
+
+	// Requests of different tenants can be routed to different pools and have different timeouts,
+	// so they share a call only if both match.
+	coalesceKey := fmt.Sprintf("%s/%s/%s", pool.Name(), timeout, cacheKey)
+
+	renderReply, led, err := opts.Coalescer.Do(ctx, coalesceKey, lead, func(callCtx context.Context) (*render.RenderChartReply, error) {
+		rendererCtx, rendererCancel := context.WithTimeout(callCtx, timeout)
+		defer rendererCancel()
+
+		reply, err := pool.RenderChart(rendererCtx, renderChartReq)
 
-	select {
-	case <-ctx.Done():
-		return nil, ErrCreateChartRequestCancelled
-	case renderResult := <-renderChart(rendererCtx, opts.RendererClient, renderChartReq):
 		switch {
-		case isTimedOutErr(renderResult.err):
+		case isTimedOutErr(err):
 			return nil, ErrCreateChartRequestCancelled
-		case renderResult.err != nil:
-			return nil, renderResult.err
+		case status.Code(err) == codes.Unavailable:
+			return nil, fmt.Errorf("%w: %s", ErrRendererUnavailable, status.Convert(err).Message())
+		case err != nil:
+			return nil, err
 		default:
-			chartID, err := uuid.NewRandom()
-			if err != nil {
-				return nil, ErrGenerateChartIDFailed
-			}
+			opts.RenderCache.Add(cacheKey, reply.ChartData)
 
-			return convert.RenderChartReplyToAPIChartReply(opts.RequestID, chartID.String(), now, renderResult.reply), nil
+			return reply, nil
 		}
+	})
+	if err != nil {
+		return nil, led, err
 	}
-}
 
-type renderChartResult struct {
-	reply *render.RenderChartReply
-	err   error
+	return &render.RenderChartReply{RequestId: renderChartReq.RequestId, ChartData: renderReply.ChartData}, led, nil
 }
 
-func renderChart(ctx context.Context, client render.ChartRendererClient, req *render.RenderChartRequest) <-chan renderChartResult {
-	result := make(chan renderChartResult)
+func chartTTLFromRequest(req *render.CreateChartRequest, defaultTTL time.Duration) (time.Duration, error) {
+	if req.GetExpiresIn() == nil {
+		return defaultTTL, nil
+	}
 
-	go func() {
-		reply, err := client.RenderChart(ctx, req)
-		result <- renderChartResult{reply, err}
-		close(result)
-	}()
+	if err := req.ExpiresIn.CheckValid(); err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrBadExpiresIn, err)
+	}
+
+	expiresIn := req.ExpiresIn.AsDuration()
+	if expiresIn <= 0 {
+		return 0, ErrBadExpiresIn
+	}
 
-	return result
+	return expiresIn, nil
 }
 
 func isTimedOutErr(err error) bool {
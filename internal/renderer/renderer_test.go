@@ -2,14 +2,24 @@ package renderer_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/cost"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/rendercache"
 	"github.com/limpidchart/lc-api/internal/renderer"
+	"github.com/limpidchart/lc-api/internal/storage"
 	"github.com/limpidchart/lc-api/internal/testutils"
+	"github.com/limpidchart/lc-api/internal/tlsutils"
+	"github.com/limpidchart/lc-api/internal/webhook"
 )
 
 func TestNewConn(t *testing.T) {
@@ -39,7 +49,172 @@ func TestNewConn(t *testing.T) {
 		RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
 	}
 
-	chartRendererConn, err := renderer.NewConn(context.Background(), rendererCfg)
+	chartRendererConn, err := renderer.NewConn(context.Background(), rendererCfg, nil)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, chartRendererConn)
 }
+
+func TestNewConn_MutualTLS(t *testing.T) {
+	ca := testutils.NewTestingCA(t, "lc")
+	serverCertPath, serverKeyPath := ca.Issue(t, "lc-renderer")
+	clientCertPath, clientKeyPath := ca.Issue(t, "lc-api")
+
+	serverCerts, err := tlsutils.LoadCertificates(serverCertPath, serverKeyPath, ca.CertPath)
+	if err != nil {
+		t.Fatalf("unable to load lc-renderer certificates: %s", err)
+	}
+
+	chartRendererServer, err := testutils.NewTestingChartRendererServer(testutils.Opts{
+		ChartData: []byte("chart svg"),
+		FailMsg:   "",
+		Latency:   time.Millisecond,
+		TLS:       serverCerts.ServerConfig(),
+	})
+	if err != nil {
+		t.Fatalf("unable to configure testing lc-renderer server: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	go func() {
+		if serveErr := chartRendererServer.Serve(ctx); serveErr != nil {
+			t.Errorf("unable to start testing lc-renderer server: %s", serveErr)
+
+			return
+		}
+	}()
+
+	rendererCfg := config.RendererConfig{
+		Address:               "dns:///" + chartRendererServer.Address(),
+		ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
+		RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
+		TLS:                   true,
+	}
+
+	rendererCerts, err := tlsutils.LoadCertificates(clientCertPath, clientKeyPath, ca.CertPath)
+	if err != nil {
+		t.Fatalf("unable to load lc-api certificates: %s", err)
+	}
+
+	chartRendererConn, err := renderer.NewConn(ctx, rendererCfg, rendererCerts)
+	if err != nil {
+		t.Fatalf("unable to connect to testing lc-renderer server: %s", err)
+	}
+
+	defer chartRendererConn.Close()
+
+	reply, err := render.NewChartRendererClient(chartRendererConn).RenderChart(ctx, &render.RenderChartRequest{RequestId: "request"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("chart svg"), reply.GetChartData())
+}
+
+func startSlowTestingRenderer(ctx context.Context, t *testing.T, latency time.Duration) *testutils.TestingChartRendererServer {
+	t.Helper()
+
+	chartRendererServer, err := testutils.NewTestingChartRendererServer(testutils.Opts{
+		ChartData: []byte("chart svg"),
+		FailMsg:   "",
+		Latency:   latency,
+	})
+	if err != nil {
+		t.Fatalf("unable to configure testing lc-renderer server: %s", err)
+	}
+
+	go func() {
+		if serveErr := chartRendererServer.Serve(ctx); serveErr != nil {
+			t.Errorf("unable to start testing lc-renderer server: %s", serveErr)
+
+			return
+		}
+	}()
+
+	return chartRendererServer
+}
+
+func TestCreateChart_CoalesceRoute(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	const latency = time.Millisecond * 200
+
+	defaultRenderer := startSlowTestingRenderer(ctx, t, latency)
+	acmeRenderer := startSlowTestingRenderer(ctx, t, latency)
+
+	pools, err := renderer.NewPools(ctx, config.RendererConfig{
+		Address:               defaultRenderer.Address(),
+		ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
+		RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
+		PoolsPath: writePools(t, fmt.Sprintf(`{
+  "pools": {"acme": {"address": %q}},
+  "routes": [{"pool": "acme", "tenant": "acme"}]
+}`, acmeRenderer.Address())),
+	}, nil, metric.NewEmptyRecorder())
+	if err != nil {
+		t.Fatalf("unable to connect to lc-renderer pools: %s", err)
+	}
+
+	defer pools.Close()
+
+	pRec := metric.NewEmptyRecorder()
+	newOpts := func(tenant string, timeout time.Duration) renderer.CreateChartOpts {
+		return renderer.CreateChartOpts{
+			Tenant: tenant,
+			Request: testutils.NewCreateChartRequest().
+				SetSizes().
+				SetBandBottomAxis().
+				SetLinearLeftAxis().
+				AddLineView().
+				Unembed(),
+			Renderers:   pools,
+			Timeout:     timeout,
+			Storage:     storage.NewMemory(),
+			RenderCache: rendercache.New(config.RenderCacheConfig{}, pRec),
+			Webhooks:    webhook.NewQueue(config.WebhookConfig{}, pRec),
+			Costs:       cost.NewAccountant(config.CostConfig{}, pRec),
+		}
+	}
+
+	// createCharts creates charts concurrently with a shared coalescer, the first one starts the lc-renderer call.
+	createCharts := func(opts ...renderer.CreateChartOpts) []error {
+		coalescer := renderer.NewCoalescer()
+		errs := make([]error, len(opts))
+		wg := &sync.WaitGroup{}
+
+		for i := range opts {
+			opts[i].Coalescer = coalescer
+
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				_, errs[i] = renderer.CreateChart(ctx, opts[i])
+			}(i)
+
+			time.Sleep(latency / 10)
+		}
+
+		wg.Wait()
+
+		return errs
+	}
+
+	t.Run("tenant_pools", func(t *testing.T) {
+		errs := createCharts(newOpts("globex", 0), newOpts("acme", 0))
+
+		assert.NoError(t, errs[0])
+		assert.NoError(t, errs[1])
+		assert.Equal(t, 1, defaultRenderer.Requests())
+		assert.Equal(t, 1, acmeRenderer.Requests())
+	})
+
+	t.Run("timeouts", func(t *testing.T) {
+		errs := createCharts(newOpts("globex", latency/4), newOpts("globex", 0))
+
+		assert.True(t, errors.Is(errs[0], renderer.ErrCreateChartRequestCancelled))
+		assert.NoError(t, errs[1])
+	})
+}
@@ -0,0 +1,350 @@
+package renderer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/limpidchart/lc-api/internal/breaker"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+const (
+	// retryBudgetTokenCost is the number of budget tokens that is withdrawn by a single retry or hedged request,
+	// every request deposits RetryBudgetPercent tokens.
+	retryBudgetTokenCost = 100
+
+	// latencyWindowSize is the number of the most recent lc-renderer latencies that are used to get the hedge delay.
+	latencyWindowSize = 512
+
+	// hedgeMinSamples is the number of latencies that are needed before the requests are hedged.
+	hedgeMinSamples = 20
+
+	maxHedgePercentile = 100
+)
+
+var (
+	// ErrBadRetryConfig contains error message about lc-renderer retry configuration that can't be used.
+	ErrBadRetryConfig = errors.New("bad lc-renderer retry configuration")
+
+	// ErrRendererUnavailable contains error message about lc-renderer that is unavailable after all attempts.
+	ErrRendererUnavailable = errors.New("lc-renderer is unavailable")
+)
+
+// retryPolicy represents retry and hedging configuration that is shared by all pools.
+type retryPolicy struct {
+	maxAttempts     int
+	initialBackoff  time.Duration
+	maxBackoff      time.Duration
+	retryCodes      map[codes.Code]struct{}
+	budgetPercent   int
+	budgetBurst     int
+	hedgePercentile int
+}
+
+func newRetryPolicy(rendererCfg config.RendererConfig) (retryPolicy, error) {
+	switch {
+	case rendererCfg.RetryMaxAttempts < 0:
+		return retryPolicy{}, fmt.Errorf("%w: max attempts should not be negative", ErrBadRetryConfig)
+	case rendererCfg.RetryInitialBackoffMilliseconds < 0:
+		return retryPolicy{}, fmt.Errorf("%w: initial backoff should not be negative", ErrBadRetryConfig)
+	case rendererCfg.RetryMaxBackoffMilliseconds < rendererCfg.RetryInitialBackoffMilliseconds:
+		return retryPolicy{}, fmt.Errorf("%w: max backoff should not be less than initial backoff", ErrBadRetryConfig)
+	case rendererCfg.RetryBudgetPercent < 0 || rendererCfg.RetryBudgetBurst < 0:
+		return retryPolicy{}, fmt.Errorf("%w: retry budget should not be negative", ErrBadRetryConfig)
+	case rendererCfg.HedgePercentile < 0 || rendererCfg.HedgePercentile >= maxHedgePercentile:
+		return retryPolicy{}, fmt.Errorf("%w: hedge percentile should be between 0 and %d", ErrBadRetryConfig, maxHedgePercentile-1)
+	}
+
+	retryCodes, err := parseRetryCodes(rendererCfg.RetryCodes)
+	if err != nil {
+		return retryPolicy{}, err
+	}
+
+	return retryPolicy{
+		maxAttempts:     rendererCfg.RetryMaxAttempts,
+		initialBackoff:  time.Duration(rendererCfg.RetryInitialBackoffMilliseconds) * time.Millisecond,
+		maxBackoff:      time.Duration(rendererCfg.RetryMaxBackoffMilliseconds) * time.Millisecond,
+		retryCodes:      retryCodes,
+		budgetPercent:   rendererCfg.RetryBudgetPercent,
+		budgetBurst:     rendererCfg.RetryBudgetBurst,
+		hedgePercentile: rendererCfg.HedgePercentile,
+	}, nil
+}
+
+// parseRetryCodes parses comma separated gRPC code names like UNAVAILABLE.
+func parseRetryCodes(raw string) (map[codes.Code]struct{}, error) {
+	retryCodes := make(map[codes.Code]struct{})
+
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		var code codes.Code
+		if err := code.UnmarshalJSON([]byte(strconv.Quote(name))); err != nil {
+			return nil, fmt.Errorf("%w: unknown retry code %q", ErrBadRetryConfig, name)
+		}
+
+		if code == codes.OK {
+			return nil, fmt.Errorf("%w: retry code should not be OK", ErrBadRetryConfig)
+		}
+
+		retryCodes[code] = struct{}{}
+	}
+
+	return retryCodes, nil
+}
+
+func (p retryPolicy) isRetryable(err error) bool {
+	_, ok := p.retryCodes[status.Code(err)]
+
+	return ok
+}
+
+// backoff returns jittered delay before the retry that follows the provided attempt.
+// Delay is chosen uniformly between a half and a full exponential backoff.
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	backoff := p.initialBackoff
+
+	for i := 1; i < attempt && backoff < p.maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > p.maxBackoff {
+		backoff = p.maxBackoff
+	}
+
+	half := int64(backoff / 2)
+	if half == 0 {
+		return backoff
+	}
+
+	// nolint: gosec
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// retryBudget limits retries and hedged requests of a pool to a percentage of its requests.
+// Budget starts full and can't accumulate more than burst retries, so a failing lc-renderer doesn't get
+// more than the configured share of the extra load.
+type retryBudget struct {
+	mu        sync.Mutex
+	tokens    int
+	maxTokens int
+	deposit   int
+}
+
+func newRetryBudget(policy retryPolicy) *retryBudget {
+	maxTokens := policy.budgetBurst * retryBudgetTokenCost
+
+	return &retryBudget{
+		tokens:    maxTokens,
+		maxTokens: maxTokens,
+		deposit:   policy.budgetPercent,
+	}
+}
+
+// request deposits tokens of a new request.
+func (b *retryBudget) request() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.tokens += b.deposit; b.tokens > b.maxTokens {
+		b.tokens = b.maxTokens
+	}
+}
+
+// withdraw reports if there are enough tokens for a retry and withdraws them.
+func (b *retryBudget) withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.tokens < retryBudgetTokenCost {
+		return false
+	}
+
+	b.tokens -= retryBudgetTokenCost
+
+	return true
+}
+
+// latencyWindow keeps the most recent latencies of the successful lc-renderer requests.
+type latencyWindow struct {
+	mu        sync.Mutex
+	latencies []time.Duration
+	next      int
+}
+
+func newLatencyWindow() *latencyWindow {
+	return &latencyWindow{
+		latencies: make([]time.Duration, 0, latencyWindowSize),
+	}
+}
+
+func (w *latencyWindow) add(latency time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.latencies) < latencyWindowSize {
+		w.latencies = append(w.latencies, latency)
+
+		return
+	}
+
+	w.latencies[w.next] = latency
+	w.next = (w.next + 1) % latencyWindowSize
+}
+
+// percentile returns the latency percentile, it's not known until there are enough samples.
+func (w *latencyWindow) percentile(percentile int) (time.Duration, bool) {
+	w.mu.Lock()
+	sorted := make([]time.Duration, len(w.latencies))
+	copy(sorted, w.latencies)
+	w.mu.Unlock()
+
+	if len(sorted) < hedgeMinSamples {
+		return 0, false
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return sorted[len(sorted)*percentile/maxHedgePercentile], true
+}
+
+type renderResult struct {
+	reply *render.RenderChartReply
+	err   error
+}
+
+// RenderChart requests a chart rendering from the pool.
+// Failed requests with the retryable codes are retried with jittered exponential backoff while the retry budget
+// of the pool allows it. Requests that are slower than the hedge percentile of the pool latency are hedged,
+// a second copy is sent over the pool connection (so it's balanced to another lc-renderer if the pool has several)
+// and the first reply is taken.
+// All attempts are done within the provided context, retries are not made if their backoff exceeds its deadline
+// or the circuit breaker of the pool is open.
+// Every sent request takes a slot of the pool concurrency limit, see renderChartOnce.
+func (p *Pool) RenderChart(ctx context.Context, req *render.RenderChartRequest) (*render.RenderChartReply, error) {
+	p.budget.request()
+
+	for attempt := 1; ; attempt++ {
+		reply, err := p.renderChartHedged(ctx, req)
+		if err == nil || attempt >= p.policy.maxAttempts || !p.policy.isRetryable(err) || breaker.IsOpenErr(err) {
+			return reply, err
+		}
+
+		backoff := p.policy.backoff(attempt)
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < backoff {
+			return nil, err
+		}
+
+		if !p.budget.withdraw() {
+			return nil, err
+		}
+
+		backoffTimer := time.NewTimer(backoff)
+
+		select {
+		case <-ctx.Done():
+			backoffTimer.Stop()
+
+			return nil, contextErr(ctx)
+		case <-backoffTimer.C:
+		}
+	}
+}
+
+// renderChartHedged sends the request and its hedged copy once the hedge delay has passed.
+// It returns the first successful reply or the last error once all sent requests fail.
+func (p *Pool) renderChartHedged(ctx context.Context, req *render.RenderChartRequest) (*render.RenderChartReply, error) {
+	hedgeDelay, ok := p.hedgeDelay()
+	if !ok {
+		return p.renderChartOnce(ctx, req)
+	}
+
+	hedgeCtx, hedgeCancel := context.WithCancel(ctx)
+	defer hedgeCancel()
+
+	// Results are buffered so the slower request doesn't block after the first reply is taken.
+	results := make(chan renderResult, 2)
+	send := func() {
+		reply, err := p.renderChartOnce(hedgeCtx, req)
+		results <- renderResult{reply: reply, err: err}
+	}
+
+	go send()
+
+	hedgeTimer := time.NewTimer(hedgeDelay)
+	defer hedgeTimer.Stop()
+
+	inFlight := 1
+
+	for {
+		select {
+		case <-hedgeTimer.C:
+			if p.budget.withdraw() {
+				inFlight++
+
+				go send()
+			}
+		case res := <-results:
+			inFlight--
+
+			if res.err == nil || inFlight == 0 {
+				return res.reply, res.err
+			}
+		}
+	}
+}
+
+// renderChartOnce sends a single request within the pool concurrency limit and records its latency if it succeeds.
+// Request waits for a free slot of the pool limiter and is rejected with *concurrency.LimitError if it doesn't get one.
+// Slot is held only while the request is sent, so its latency and result adapt the limit without retry backoffs.
+func (p *Pool) renderChartOnce(ctx context.Context, req *render.RenderChartRequest) (*render.RenderChartReply, error) {
+	if err := p.limiter.Acquire(ctx); err != nil {
+		// nolint: wrapcheck
+		return nil, err
+	}
+
+	start := time.Now()
+	reply, err := p.client.RenderChart(ctx, req)
+	latency := time.Since(start)
+
+	p.limiter.Release(latency, err)
+
+	if err == nil && p.latencies != nil {
+		p.latencies.add(latency)
+	}
+
+	// nolint: wrapcheck
+	return reply, err
+}
+
+func (p *Pool) hedgeDelay() (time.Duration, bool) {
+	if p.latencies == nil {
+		return 0, false
+	}
+
+	return p.latencies.percentile(p.policy.hedgePercentile)
+}
+
+// contextErr converts the context error into gRPC status error like the ones returned by the gRPC client.
+func contextErr(ctx context.Context) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, context.DeadlineExceeded.Error())
+	}
+
+	return status.Error(codes.Canceled, context.Canceled.Error())
+}
@@ -0,0 +1,293 @@
+package renderer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/renderer"
+	"github.com/limpidchart/lc-api/internal/testutils"
+)
+
+const hedgeWarmupRequests = 20
+
+func newRetryTestingPool(ctx context.Context, t *testing.T, serverOpts testutils.Opts, rendererCfg config.RendererConfig) (*renderer.Pool, *testutils.TestingChartRendererServer) {
+	t.Helper()
+
+	chartRendererServer, err := testutils.NewTestingChartRendererServer(serverOpts)
+	if err != nil {
+		t.Fatalf("unable to configure testing lc-renderer server: %s", err)
+	}
+
+	go func() {
+		if serveErr := chartRendererServer.Serve(ctx); serveErr != nil {
+			t.Errorf("unable to start testing lc-renderer server: %s", serveErr)
+
+			return
+		}
+	}()
+
+	rendererCfg.Address = chartRendererServer.Address()
+	rendererCfg.ConnTimeoutSeconds = testutils.RendererConnTimeoutSecs
+
+	pools, err := renderer.NewPools(ctx, rendererCfg, nil, metric.NewEmptyRecorder())
+	if err != nil {
+		t.Fatalf("unable to connect to lc-renderer pools: %s", err)
+	}
+
+	t.Cleanup(pools.Close)
+
+	return pools.Pool(renderer.DefaultPool), chartRendererServer
+}
+
+func TestPool_RenderChart_Retry(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name             string
+		failCode         codes.Code
+		failFirst        int
+		maxAttempts      int
+		budgetBurst      int
+		expectedCode     codes.Code
+		expectedRequests int
+	}{
+		{
+			name:             "retried",
+			failCode:         codes.Unavailable,
+			failFirst:        2,
+			maxAttempts:      3,
+			budgetBurst:      10,
+			expectedCode:     codes.OK,
+			expectedRequests: 3,
+		},
+		{
+			name:             "attempts_exhausted",
+			failCode:         codes.Unavailable,
+			failFirst:        5,
+			maxAttempts:      3,
+			budgetBurst:      10,
+			expectedCode:     codes.Unavailable,
+			expectedRequests: 3,
+		},
+		{
+			name:             "budget_exhausted",
+			failCode:         codes.Unavailable,
+			failFirst:        5,
+			maxAttempts:      5,
+			budgetBurst:      1,
+			expectedCode:     codes.Unavailable,
+			expectedRequests: 2,
+		},
+		{
+			name:             "not_retryable",
+			failCode:         codes.InvalidArgument,
+			failFirst:        5,
+			maxAttempts:      3,
+			budgetBurst:      10,
+			expectedCode:     codes.InvalidArgument,
+			expectedRequests: 1,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+			defer cancel()
+
+			pool, chartRendererServer := newRetryTestingPool(ctx, t, testutils.Opts{
+				FailMsg:   "connection reset by peer",
+				FailCode:  tc.failCode,
+				FailFirst: tc.failFirst,
+				ChartData: []byte("chart svg"),
+				Latency:   time.Millisecond,
+			}, config.RendererConfig{
+				RetryMaxAttempts:                tc.maxAttempts,
+				RetryInitialBackoffMilliseconds: 1,
+				RetryMaxBackoffMilliseconds:     5,
+				RetryCodes:                      "UNAVAILABLE",
+				RetryBudgetBurst:                tc.budgetBurst,
+			})
+
+			_, err := pool.RenderChart(ctx, renderChartRequest(100, 100, 1))
+
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Equal(t, tc.expectedRequests, chartRendererServer.Requests())
+		})
+	}
+}
+
+func TestPool_RenderChart_RetryLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	pool, chartRendererServer := newRetryTestingPool(ctx, t, testutils.Opts{
+		FailMsg:   "connection reset by peer",
+		FailCode:  codes.Unavailable,
+		FailFirst: 1,
+		ChartData: []byte("chart svg"),
+		Latency:   time.Millisecond,
+	}, config.RendererConfig{
+		RetryMaxAttempts:                2,
+		RetryInitialBackoffMilliseconds: 400,
+		RetryMaxBackoffMilliseconds:     400,
+		RetryCodes:                      "UNAVAILABLE",
+		RetryBudgetBurst:                1,
+		LimitInitial:                    1,
+		LimitMin:                        1,
+		LimitMax:                        1,
+		LimitLatencyMilliseconds:        100,
+		LimitBackoffPercent:             50,
+		LimitRetryAfterSeconds:          1,
+	})
+
+	retried := make(chan error, 1)
+
+	go func() {
+		_, err := pool.RenderChart(ctx, renderChartRequest(100, 100, 1))
+		retried <- err
+	}()
+
+	for chartRendererServer.Requests() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	time.Sleep(time.Millisecond * 50)
+
+	// Request that waits for the retry backoff doesn't hold the only slot of the pool.
+	_, err := pool.RenderChart(ctx, renderChartRequest(100, 100, 1))
+	assert.NoError(t, err)
+
+	assert.NoError(t, <-retried)
+	assert.Equal(t, 3, chartRendererServer.Requests())
+}
+
+func TestPool_RenderChart_RetryDeadline(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	pool, chartRendererServer := newRetryTestingPool(ctx, t, testutils.Opts{
+		FailMsg:  "connection reset by peer",
+		FailCode: codes.Unavailable,
+		Latency:  time.Millisecond,
+	}, config.RendererConfig{
+		RetryMaxAttempts:                3,
+		RetryInitialBackoffMilliseconds: 2000,
+		RetryMaxBackoffMilliseconds:     2000,
+		RetryCodes:                      "UNAVAILABLE",
+		RetryBudgetBurst:                10,
+	})
+
+	renderCtx, renderCancel := context.WithTimeout(ctx, time.Millisecond*500)
+	defer renderCancel()
+
+	start := time.Now()
+	_, err := pool.RenderChart(renderCtx, renderChartRequest(100, 100, 1))
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, chartRendererServer.Requests())
+	assert.True(t, time.Since(start) < time.Millisecond*500)
+}
+
+func TestPool_RenderChart_Hedge(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	chartData := []byte("chart svg")
+	slowLatency := time.Second * 3
+
+	pool, chartRendererServer := newRetryTestingPool(ctx, t, testutils.Opts{
+		ChartData:   chartData,
+		Latency:     time.Millisecond,
+		SlowRequest: hedgeWarmupRequests + 1,
+		SlowLatency: slowLatency,
+	}, config.RendererConfig{
+		RetryBudgetPercent: 100,
+		RetryBudgetBurst:   1,
+		HedgePercentile:    90,
+	})
+
+	// Requests are not hedged until there are enough latency samples.
+	for i := 0; i < hedgeWarmupRequests; i++ {
+		if _, err := pool.RenderChart(ctx, renderChartRequest(100, 100, 1)); err != nil {
+			t.Fatalf("unable to render chart: %s", err)
+		}
+	}
+
+	start := time.Now()
+	reply, err := pool.RenderChart(ctx, renderChartRequest(100, 100, 1))
+
+	assert.NoError(t, err)
+	assert.Equal(t, chartData, reply.ChartData)
+	assert.Equal(t, hedgeWarmupRequests+2, chartRendererServer.Requests())
+	assert.True(t, time.Since(start) < slowLatency)
+}
+
+func TestNewPools_BadRetryConfig(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name        string
+		rendererCfg config.RendererConfig
+		expected    string
+	}{
+		{
+			"negative_attempts",
+			config.RendererConfig{RetryMaxAttempts: -1},
+			"bad lc-renderer retry configuration: max attempts should not be negative",
+		},
+		{
+			"max_backoff_less_than_initial",
+			config.RendererConfig{RetryInitialBackoffMilliseconds: 100, RetryMaxBackoffMilliseconds: 50},
+			"bad lc-renderer retry configuration: max backoff should not be less than initial backoff",
+		},
+		{
+			"negative_budget",
+			config.RendererConfig{RetryBudgetPercent: -1},
+			"bad lc-renderer retry configuration: retry budget should not be negative",
+		},
+		{
+			"hedge_percentile_too_big",
+			config.RendererConfig{HedgePercentile: 100},
+			"bad lc-renderer retry configuration: hedge percentile should be between 0 and 99",
+		},
+		{
+			"unknown_code",
+			config.RendererConfig{RetryCodes: "UNAVAILABLE,RESET"},
+			`bad lc-renderer retry configuration: unknown retry code "RESET"`,
+		},
+		{
+			"ok_code",
+			config.RendererConfig{RetryCodes: "OK"},
+			"bad lc-renderer retry configuration: retry code should not be OK",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pools, err := renderer.NewPools(context.Background(), tc.rendererCfg, nil, metric.NewEmptyRecorder())
+			assert.Nil(t, pools)
+			assert.True(t, errors.Is(err, renderer.ErrBadRetryConfig))
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/limpidchart/lc-api/internal/audit"
+)
+
+// NewAuditEntry returns an audit log entry of the operation with the request ID, caller identity,
+// tenant and client IP of the request.
+// Identity is empty if authentication is disabled.
+func NewAuditEntry(ctx context.Context, operation string) audit.Entry {
+	entry := audit.Entry{
+		Operation: operation,
+		Transport: audit.TransportGRPC,
+		RequestID: GetRequestID(ctx),
+		Tenant:    GetTenant(ctx),
+		ClientIP:  peerIP(ctx),
+	}
+
+	if identity := GetIdentity(ctx); identity != nil {
+		entry.Identity = identity.Subject
+	}
+
+	return entry
+}
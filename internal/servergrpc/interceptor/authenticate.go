@@ -0,0 +1,97 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+	"github.com/limpidchart/lc-api/internal/backend"
+)
+
+const (
+	// APIKeyMetadataKey represents a metadata key that contains API key.
+	APIKeyMetadataKey = "x-api-key"
+
+	// AuthorizationMetadataKey represents a metadata key that contains bearer token.
+	AuthorizationMetadataKey = "authorization"
+)
+
+// Authenticate checks request credentials and saves the caller identity into context.
+// It returns codes.Unauthenticated status.Status if credentials are missing or not valid.
+func Authenticate(log *zerolog.Logger, bCon backend.ConnSupervisor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		newCtx, err := authenticate(ctx, log, bCon.Authenticator())
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(newCtx, req)
+	}
+}
+
+// AuthenticateStream is a stream counterpart of Authenticate.
+func AuthenticateStream(log *zerolog.Logger, bCon backend.ConnSupervisor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		newCtx, err := authenticate(ss.Context(), log, bCon.Authenticator())
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: newCtx})
+	}
+}
+
+// GetIdentity returns the caller identity or nil if authentication is disabled.
+func GetIdentity(ctx context.Context) *auth.Identity {
+	if identity, ok := ctx.Value(ctxIdentity).(*auth.Identity); ok {
+		return identity
+	}
+
+	return nil
+}
+
+func authenticate(ctx context.Context, log *zerolog.Logger, authenticator *auth.Authenticator) (context.Context, error) {
+	if !authenticator.Enabled() {
+		return ctx, nil
+	}
+
+	identity, err := authenticator.Authenticate(credentialsFromMetadata(ctx))
+	if err != nil {
+		log.Warn().
+			Str(RequestIDLogKey, GetRequestID(ctx)).
+			Str(ipKey, peerIP(ctx)).
+			Str(errKey, err.Error()).
+			Msg("Unable to authenticate request")
+
+		// nolint: wrapcheck
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	observeIdentity(ctx, identity)
+
+	return context.WithValue(ctx, ctxIdentity, identity), nil
+}
+
+func credentialsFromMetadata(ctx context.Context) auth.Credentials {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return auth.Credentials{}
+	}
+
+	creds := auth.Credentials{}
+
+	if vals := md.Get(APIKeyMetadataKey); len(vals) > 0 {
+		creds.APIKey = vals[0]
+	}
+
+	if vals := md.Get(AuthorizationMetadataKey); len(vals) > 0 {
+		creds.BearerToken = auth.BearerToken(vals[0])
+	}
+
+	return creds
+}
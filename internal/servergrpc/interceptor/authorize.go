@@ -0,0 +1,97 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+)
+
+// ErrorInfoDomain represents a domain of errdetails.ErrorInfo returned by lc-api.
+const ErrorInfoDomain = "lc-api"
+
+// methodScopes contains scopes required by gRPC methods.
+var methodScopes = map[string]string{
+	"/render.ChartAPI/CreateChart":  auth.ScopeChartsCreate,
+	"/render.ChartAPI/CreateCharts": auth.ScopeChartsCreate,
+	"/render.ChartAPI/GetChart":     auth.ScopeChartsRead,
+	"/render.ChartAPI/ListCharts":   auth.ScopeChartsList,
+	"/render.ChartAPI/DeleteChart":  auth.ScopeChartsDelete,
+}
+
+// Authorize checks that the caller identity is granted the scope required by the method.
+// It returns codes.PermissionDenied status.Status with errdetails.ErrorInfo if the scope is missing.
+// Methods without a known scope are denied for authenticated callers.
+func Authorize(log *zerolog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorize(ctx, log, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// AuthorizeStream is a stream counterpart of Authorize.
+func AuthorizeStream(log *zerolog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(ss.Context(), log, info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func authorize(ctx context.Context, log *zerolog.Logger, fullMethod string) error {
+	identity := GetIdentity(ctx)
+	if identity == nil {
+		return nil
+	}
+
+	scope, ok := methodScopes[fullMethod]
+	if !ok {
+		log.Error().
+			Str(RequestIDLogKey, GetRequestID(ctx)).
+			Str(methodKey, fullMethod).
+			Msg("Method doesn't have a required scope")
+
+		// nolint: wrapcheck
+		return status.Errorf(codes.PermissionDenied, "method %s is not allowed", fullMethod)
+	}
+
+	if err := auth.Authorize(identity, scope); err != nil {
+		log.Warn().
+			Str(RequestIDLogKey, GetRequestID(ctx)).
+			Str(identityKey, identity.Subject).
+			Str(scopeKey, scope).
+			Msg("Request is forbidden")
+
+		return permissionDenied(err, scope)
+	}
+
+	return nil
+}
+
+func permissionDenied(err error, scope string) error {
+	st := status.New(codes.PermissionDenied, err.Error())
+
+	detailed, detailsErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   auth.ReasonMissingScope,
+		Domain:   ErrorInfoDomain,
+		Metadata: map[string]string{"scope": scope},
+	})
+	if detailsErr != nil {
+		// nolint: wrapcheck
+		return status.Error(codes.PermissionDenied, fmt.Sprintf("%s: %s", auth.ReasonMissingScope, err))
+	}
+
+	// nolint: wrapcheck
+	return detailed.Err()
+}
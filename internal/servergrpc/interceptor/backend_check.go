@@ -11,10 +11,17 @@ import (
 	"github.com/limpidchart/lc-api/internal/backend"
 )
 
+// renderMethods contains gRPC methods that render charts, other methods are served from storage.
+var renderMethods = map[string]bool{
+	"/render.ChartAPI/CreateChart":  true,
+	"/render.ChartAPI/CreateCharts": true,
+}
+
 // BackendCheck checks if backend is healthy and returns codes.Unavailable status.Status if it's not.
+// Only methods that render charts are checked, so saved charts are available while lc-renderer is not.
 func BackendCheck(log *zerolog.Logger, bCon backend.ConnSupervisor) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		if !bCon.IsHealthy() {
+		if renderMethods[info.FullMethod] && !bCon.IsHealthy().Healthy() {
 			log.Error().Msg("Backend connections are not healthy")
 
 			return nil, status.Errorf(codes.Unavailable, "Service Unavailable")
@@ -23,3 +30,16 @@ func BackendCheck(log *zerolog.Logger, bCon backend.ConnSupervisor) grpc.UnarySe
 		return handler(ctx, req)
 	}
 }
+
+// BackendCheckStream is a stream counterpart of BackendCheck.
+func BackendCheckStream(log *zerolog.Logger, bCon backend.ConnSupervisor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if renderMethods[info.FullMethod] && !bCon.IsHealthy().Healthy() {
+			log.Error().Msg("Backend connections are not healthy")
+
+			return status.Errorf(codes.Unavailable, "Service Unavailable")
+		}
+
+		return handler(srv, ss)
+	}
+}
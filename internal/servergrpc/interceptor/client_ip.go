@@ -0,0 +1,86 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/clientip"
+)
+
+const (
+	xRealIPMetadataKey       = "x-real-ip"
+	xForwardedForMetadataKey = "x-forwarded-for"
+)
+
+// SetClientIP resolves the client IP from the peer address and from the forwarded metadata of trusted proxies
+// and saves it into context.
+// It returns codes.PermissionDenied status.Status if the client IP is denied.
+func SetClientIP(log *zerolog.Logger, bCon backend.ConnSupervisor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		newCtx, err := setClientIP(ctx, log, bCon.ClientIPs())
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(newCtx, req)
+	}
+}
+
+// SetClientIPStream is a stream counterpart of SetClientIP.
+func SetClientIPStream(log *zerolog.Logger, bCon backend.ConnSupervisor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		newCtx, err := setClientIP(ss.Context(), log, bCon.ClientIPs())
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: newCtx})
+	}
+}
+
+// GetClientIP returns the client IP resolved by SetClientIP or an empty string if it's not resolved.
+func GetClientIP(ctx context.Context) string {
+	if clientIP, ok := ctx.Value(ctxClientIP).(string); ok {
+		return clientIP
+	}
+
+	return ""
+}
+
+func setClientIP(ctx context.Context, log *zerolog.Logger, clientIPs *clientip.Resolver) (context.Context, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	clientIP := clientIPs.Resolve(p.Addr.String(), firstMetadataValue(md, xRealIPMetadataKey), firstMetadataValue(md, xForwardedForMetadataKey))
+
+	if err := clientIPs.Check(clientIP); err != nil {
+		log.Warn().
+			Str(RequestIDLogKey, GetRequestID(ctx)).
+			Str(ipKey, clientIP).
+			Str(errKey, err.Error()).
+			Msg("Request is denied")
+
+		// nolint: wrapcheck
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	return context.WithValue(ctx, ctxClientIP, clientIP), nil
+}
+
+func firstMetadataValue(md metadata.MD, key string) string {
+	if vals := md.Get(key); len(vals) > 0 {
+		return vals[0]
+	}
+
+	return ""
+}
@@ -10,66 +10,125 @@ import (
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
+	"github.com/limpidchart/lc-api/internal/auth"
 	"github.com/limpidchart/lc-api/internal/metric"
 )
 
+// RequestIDLogKey represents a request ID key logger field.
+const RequestIDLogKey = "request_id"
+
 const (
-	protocolKey  = "protocol"
-	requestIDKey = "request_id"
-	ipKey        = "ip"
-	codeKey      = "code"
-	methodKey    = "method"
-	durationKey  = "duration"
-	errKey       = "error"
+	protocolKey = "protocol"
+	ipKey       = "ip"
+	codeKey     = "code"
+	methodKey   = "method"
+	durationKey = "duration"
+	errKey      = "error"
+	identityKey = "identity"
+	tenantKey   = "tenant"
+	scopeKey    = "scope"
+
+	rateLimitKeyKey = "rate_limit_key"
 )
 
 const unknownIP = "unknown"
 
+// observedRequest contains request details that are resolved by the interceptors called after Observer.
+type observedRequest struct {
+	identity *auth.Identity
+	tenant   string
+}
+
 // Observer handles observability (metrics and logging) for every request.
 func Observer(log *zerolog.Logger, pRec metric.PromRecorder) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		startTime := time.Now().UTC()
+		observed := &observedRequest{}
 
-		resp, err := handler(ctx, req)
+		resp, err := handler(context.WithValue(ctx, ctxObservedRequest, observed), req)
 
-		observe(ctx, log, startTime, info, pRec, err)
+		observe(ctx, log, observed, startTime, info.FullMethod, pRec, err)
 
 		return resp, err
 	}
 }
 
-func observe(ctx context.Context, log *zerolog.Logger, startTime time.Time, info *grpc.UnaryServerInfo, pRec metric.PromRecorder, err error) {
+// ObserverStream is a stream counterpart of Observer.
+func ObserverStream(log *zerolog.Logger, pRec metric.PromRecorder) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		startTime := time.Now().UTC()
+		observed := &observedRequest{}
+		ctx := context.WithValue(ss.Context(), ctxObservedRequest, observed)
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+
+		observe(ss.Context(), log, observed, startTime, info.FullMethod, pRec, err)
+
+		return err
+	}
+}
+
+// observeIdentity saves the caller identity for the Observer log fields.
+func observeIdentity(ctx context.Context, identity *auth.Identity) {
+	if observed, ok := ctx.Value(ctxObservedRequest).(*observedRequest); ok {
+		observed.identity = identity
+	}
+}
+
+// observeTenant saves the request tenant for the Observer log fields.
+func observeTenant(ctx context.Context, tenant string) {
+	if observed, ok := ctx.Value(ctxObservedRequest).(*observedRequest); ok {
+		observed.tenant = tenant
+	}
+}
+
+func observe(ctx context.Context, log *zerolog.Logger, observed *observedRequest, startTime time.Time, fullMethod string, pRec metric.PromRecorder, err error) {
 	reqID := GetRequestID(ctx)
 	duration := time.Since(startTime)
 
 	if err != nil {
-		logEvent := basicLoggerFields(ctx, log.Error(), startTime, duration, reqID, info.FullMethod, err)
+		logEvent := basicLoggerFields(ctx, log.Error(), observed, startTime, duration, reqID, fullMethod, err)
 		logEvent = errLoggerFields(logEvent, err)
 		logEvent.Msg("")
 	} else {
-		logEvent := basicLoggerFields(ctx, log.Info(), startTime, duration, reqID, info.FullMethod, err)
+		logEvent := basicLoggerFields(ctx, log.Info(), observed, startTime, duration, reqID, fullMethod, err)
 		logEvent.Msg("")
 	}
 
-	pRec.RequestDuration().WithLabelValues(metric.ProtocolGRPC, path.Base(info.FullMethod), info.FullMethod, status.Convert(err).Code().String()).Observe(duration.Seconds())
+	pRec.RequestDuration().WithLabelValues(metric.ProtocolGRPC, path.Base(fullMethod), fullMethod, status.Convert(err).Code().String()).Observe(duration.Seconds())
 }
 
-func basicLoggerFields(ctx context.Context, logEvent *zerolog.Event, startTime time.Time, duration time.Duration, reqID, method string, err error) *zerolog.Event {
-	return logEvent.
+func basicLoggerFields(ctx context.Context, logEvent *zerolog.Event, observed *observedRequest, startTime time.Time, duration time.Duration, reqID, method string, err error) *zerolog.Event {
+	logEvent = logEvent.
 		Time(zerolog.TimestampFieldName, startTime).
 		Str(protocolKey, metric.ProtocolGRPC).
-		Str(requestIDKey, reqID).
+		Str(RequestIDLogKey, reqID).
 		Str(ipKey, peerIP(ctx)).
 		Str(codeKey, status.Convert(err).Code().String()).
 		Str(methodKey, path.Base(method)).
 		Dur(durationKey, duration)
+
+	if observed.identity != nil {
+		logEvent = logEvent.Str(identityKey, observed.identity.Subject)
+	}
+
+	if observed.tenant != "" {
+		logEvent = logEvent.Str(tenantKey, observed.tenant)
+	}
+
+	return logEvent
 }
 
 func errLoggerFields(logEvent *zerolog.Event, err error) *zerolog.Event {
 	return logEvent.Str(errKey, status.Convert(err).Message())
 }
 
+// peerIP returns the client IP resolved by SetClientIP or the peer address.
 func peerIP(ctx context.Context) string {
+	if clientIP := GetClientIP(ctx); clientIP != "" {
+		return clientIP
+	}
+
 	ip, ok := peer.FromContext(ctx)
 	if ok {
 		return ip.Addr.String()
@@ -0,0 +1,89 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/ratelimit"
+)
+
+// RateLimit takes a token from the caller bucket of the method.
+// It returns codes.ResourceExhausted status.Status with errdetails.RetryInfo if the bucket is empty.
+func RateLimit(log *zerolog.Logger, bCon backend.ConnSupervisor, pRec metric.PromRecorder) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := rateLimit(ctx, log, bCon.RateLimiter(), pRec, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// RateLimitStream is a stream counterpart of RateLimit.
+func RateLimitStream(log *zerolog.Logger, bCon backend.ConnSupervisor, pRec metric.PromRecorder) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := rateLimit(ss.Context(), log, bCon.RateLimiter(), pRec, info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func rateLimit(ctx context.Context, log *zerolog.Logger, limiter *ratelimit.Limiter, pRec metric.PromRecorder, fullMethod string) error {
+	if !limiter.Enabled() {
+		return nil
+	}
+
+	operation := path.Base(fullMethod)
+	key := limiter.Key(GetIdentity(ctx), GetTenant(ctx), clientIP(ctx))
+
+	ok, retryAfter := limiter.Allow(operation, key)
+	if ok {
+		return nil
+	}
+
+	log.Warn().
+		Str(RequestIDLogKey, GetRequestID(ctx)).
+		Str(methodKey, operation).
+		Str(rateLimitKeyKey, key.Kind).
+		Msg("Request is rate limited")
+
+	pRec.RateLimitedRequests().WithLabelValues(metric.ProtocolGRPC, operation, key.Kind).Inc()
+
+	msg := fmt.Sprintf("too many requests, retry in %d seconds", ratelimit.RetryAfterSeconds(retryAfter))
+
+	detailed, detailsErr := status.New(codes.ResourceExhausted, msg).WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	})
+	if detailsErr != nil {
+		// nolint: wrapcheck
+		return status.Error(codes.ResourceExhausted, msg)
+	}
+
+	// nolint: wrapcheck
+	return detailed.Err()
+}
+
+// clientIP returns peerIP without port.
+func clientIP(ctx context.Context) string {
+	ip := peerIP(ctx)
+
+	host, _, err := net.SplitHostPort(ip)
+	if err != nil {
+		return ip
+	}
+
+	return host
+}
@@ -35,6 +35,29 @@ func Recover(log *zerolog.Logger) grpc.UnaryServerInterceptor {
 	}
 }
 
+// RecoverStream is a stream counterpart of Recover.
+func RecoverStream(log *zerolog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		panicked := true
+
+		defer func() {
+			if rec := recover(); rec != nil || panicked {
+				stack := make([]byte, stackSize)
+				stack = stack[:runtime.Stack(stack, false)]
+
+				log.Error().Bytes(zerolog.ErrorStackFieldName, stack).Interface(zerolog.ErrorFieldName, rec).Msg("gRPC server panicked")
+
+				err = InternalError()
+			}
+		}()
+
+		err = handler(srv, ss)
+		panicked = false
+
+		return err
+	}
+}
+
 // InternalError returns status.Error with codes.Internal code.
 func InternalError() error {
 	// nolint: wrapcheck
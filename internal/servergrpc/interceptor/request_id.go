@@ -10,7 +10,13 @@ import (
 
 type ctxKey int
 
-const ctxRequestID ctxKey = iota
+const (
+	ctxRequestID ctxKey = iota
+	ctxIdentity
+	ctxTenant
+	ctxClientIP
+	ctxObservedRequest
+)
 
 // ErrGenerateRequestIDFailed contains error message about failed request ID generation.
 var ErrGenerateRequestIDFailed = errors.New("unable to generate a random UUID for chart ID")
@@ -29,6 +35,32 @@ func SetRequestID() grpc.UnaryServerInterceptor {
 	}
 }
 
+// SetRequestIDStream is a stream counterpart of SetRequestID.
+func SetRequestIDStream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		reqID, err := uuid.NewRandom()
+		if err != nil {
+			return ErrGenerateRequestIDFailed
+		}
+
+		return handler(srv, &serverStream{
+			ServerStream: ss,
+			ctx:          context.WithValue(ss.Context(), ctxRequestID, reqID.String()),
+		})
+	}
+}
+
+// serverStream wraps grpc.ServerStream to override its context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the overridden stream context.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
 // GetRequestID returns request ID from context.
 func GetRequestID(ctx context.Context) string {
 	if reqID, ok := ctx.Value(ctxRequestID).(string); ok {
@@ -0,0 +1,84 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/clientip"
+	"github.com/limpidchart/lc-api/internal/tenant"
+)
+
+// SetTenant resolves the request tenant from the caller identity or from the trusted metadata and saves it into context.
+// Trusted metadata is honored only if the request peer is a trusted proxy.
+// It returns codes.InvalidArgument status.Status if the trusted metadata contains a bad tenant
+// or disagrees with the identity tenant.
+func SetTenant(log *zerolog.Logger, bCon backend.ConnSupervisor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		newCtx, err := setTenant(ctx, log, bCon.Tenants(), bCon.ClientIPs())
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(newCtx, req)
+	}
+}
+
+// SetTenantStream is a stream counterpart of SetTenant.
+func SetTenantStream(log *zerolog.Logger, bCon backend.ConnSupervisor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		newCtx, err := setTenant(ss.Context(), log, bCon.Tenants(), bCon.ClientIPs())
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: newCtx})
+	}
+}
+
+// GetTenant returns the request tenant or an empty string for the default tenant.
+func GetTenant(ctx context.Context) string {
+	if tenant, ok := ctx.Value(ctxTenant).(string); ok {
+		return tenant
+	}
+
+	return ""
+}
+
+func setTenant(ctx context.Context, log *zerolog.Logger, tenants *tenant.Registry, clientIPs *clientip.Resolver) (context.Context, error) {
+	metadataValue := ""
+
+	if key := tenants.TrustedHeader(); key != "" {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if vals := md.Get(key); len(vals) > 0 {
+				metadataValue = vals[0]
+			}
+		}
+	}
+
+	fromTrustedProxy := false
+	if p, ok := peer.FromContext(ctx); ok {
+		fromTrustedProxy = clientIPs.IsTrustedProxy(p.Addr.String())
+	}
+
+	t, err := tenants.Resolve(GetIdentity(ctx), metadataValue, fromTrustedProxy)
+	if err != nil {
+		log.Warn().
+			Str(RequestIDLogKey, GetRequestID(ctx)).
+			Str(errKey, err.Error()).
+			Msg("Unable to resolve request tenant")
+
+		// nolint: wrapcheck
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	observeTenant(ctx, t)
+
+	return context.WithValue(ctx, ctxTenant, t), nil
+}
@@ -8,16 +8,27 @@ import (
 	"time"
 
 	"github.com/rs/zerolog"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 
+	"github.com/limpidchart/lc-api/internal/audit"
 	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/concurrency"
 	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/convert"
+	"github.com/limpidchart/lc-api/internal/cost"
 	"github.com/limpidchart/lc-api/internal/metric"
 	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/rendercache"
 	"github.com/limpidchart/lc-api/internal/renderer"
 	"github.com/limpidchart/lc-api/internal/servergrpc/interceptor"
+	"github.com/limpidchart/lc-api/internal/storage"
+	"github.com/limpidchart/lc-api/internal/tenant"
+	"github.com/limpidchart/lc-api/internal/webhook"
 )
 
 const name = "gRPC API"
@@ -25,31 +36,71 @@ const name = "gRPC API"
 // Server implements gRPC render.ChartAPIServer.
 type Server struct {
 	render.UnimplementedChartAPIServer
-	log                *zerolog.Logger
-	grpcServer         *grpc.Server
-	listener           *net.TCPListener
-	rendererClient     render.ChartRendererClient
-	rendererReqTimeout time.Duration
-	shutdownTimeout    time.Duration
+	log             *zerolog.Logger
+	grpcServer      *grpc.Server
+	listener        *net.TCPListener
+	renderers       *renderer.Pools
+	shutdownTimeout time.Duration
+	storage         storage.Storage
+	chartTTL        time.Duration
+	renderCache     *rendercache.Cache
+	renderCoalescer *renderer.Coalescer
+	renderQueue     *renderer.Queue
+	renderBatcher   *renderer.Batcher
+	webhookQueue    *webhook.Queue
+	tenants         *tenant.Registry
+	costs           *cost.Accountant
+	audit           *audit.Logger
 }
 
 // NewServer configures a new Server.
 func NewServer(log *zerolog.Logger, tcpList *net.TCPListener, bCon backend.ConnSupervisor, gRPCCfg config.GRPCConfig, pRec metric.PromRecorder) *Server {
-	grpcServer := grpc.NewServer(
+	serverOpts := []grpc.ServerOption{
 		grpc.ChainUnaryInterceptor(
 			interceptor.Recover(log),
 			interceptor.BackendCheck(log, bCon),
 			interceptor.SetRequestID(),
+			interceptor.SetClientIP(log, bCon),
 			interceptor.Observer(log, pRec),
+			interceptor.Authenticate(log, bCon),
+			interceptor.SetTenant(log, bCon),
+			interceptor.Authorize(log),
+			interceptor.RateLimit(log, bCon, pRec),
 		),
-	)
+		grpc.ChainStreamInterceptor(
+			interceptor.RecoverStream(log),
+			interceptor.BackendCheckStream(log, bCon),
+			interceptor.SetRequestIDStream(),
+			interceptor.SetClientIPStream(log, bCon),
+			interceptor.ObserverStream(log, pRec),
+			interceptor.AuthenticateStream(log, bCon),
+			interceptor.SetTenantStream(log, bCon),
+			interceptor.AuthorizeStream(log),
+			interceptor.RateLimitStream(log, bCon, pRec),
+		),
+	}
+
+	if serverCerts := bCon.ServerCertificates(); serverCerts != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(serverCerts.ServerConfig())))
+	}
+
+	grpcServer := grpc.NewServer(serverOpts...)
 	chartAPIServer := &Server{
-		log:                log,
-		grpcServer:         grpcServer,
-		shutdownTimeout:    time.Second * time.Duration(gRPCCfg.ShutdownTimeoutSeconds),
-		listener:           tcpList,
-		rendererClient:     bCon.RendererClient(),
-		rendererReqTimeout: bCon.RendererRequestTimeout(),
+		log:             log,
+		grpcServer:      grpcServer,
+		shutdownTimeout: time.Second * time.Duration(gRPCCfg.ShutdownTimeoutSeconds),
+		listener:        tcpList,
+		renderers:       bCon.Renderers(),
+		storage:         bCon.Storage(),
+		chartTTL:        bCon.ChartTTL(),
+		renderCache:     bCon.RenderCache(),
+		renderCoalescer: bCon.RenderCoalescer(),
+		renderQueue:     bCon.RenderQueue(),
+		renderBatcher:   bCon.RenderBatcher(),
+		webhookQueue:    bCon.WebhookQueue(),
+		tenants:         bCon.Tenants(),
+		costs:           bCon.Costs(),
+		audit:           bCon.Audit(),
 	}
 
 	render.RegisterChartAPIServer(grpcServer, chartAPIServer)
@@ -123,27 +174,223 @@ func (s *Server) Name() string {
 func (s *Server) CreateChart(ctx context.Context, req *render.CreateChartRequest) (*render.ChartReply, error) {
 	reqID := interceptor.GetRequestID(ctx)
 
-	res, err := renderer.CreateChart(ctx, renderer.CreateChartOpts{
-		RequestID:      reqID,
-		Request:        req,
-		RendererClient: s.rendererClient,
-		Timeout:        s.rendererReqTimeout,
+	// Spec is hashed before the request is changed by the chart creation.
+	specHash := audit.SpecHash(req)
+
+	res, err := renderer.CreateChart(ctx, s.createChartOpts(ctx, req))
+	s.auditCreateChart(ctx, specHash, res, err)
+
+	if err != nil {
+		return nil, s.createChartErr(reqID, err)
+	}
+
+	return res, nil
+}
+
+// CreateCharts implements render.ChartAPIServer.CreateCharts.
+//
+// nolint: wrapcheck
+func (s *Server) CreateCharts(req *render.CreateChartsRequest, stream render.ChartAPI_CreateChartsServer) error {
+	ctx := stream.Context()
+	reqID := interceptor.GetRequestID(ctx)
+
+	if err := s.renderBatcher.ValidateSize(len(req.Charts)); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	results := s.renderBatcher.CreateCharts(ctx, len(req.Charts), func(ctx context.Context, index int) (*render.ChartReply, error) {
+		specHash := audit.SpecHash(req.Charts[index])
+
+		res, err := renderer.CreateChart(ctx, s.createChartOpts(ctx, req.Charts[index]))
+		s.auditCreateChart(ctx, specHash, res, err)
+
+		return res, err
 	})
 
+	for res := range results {
+		reply := &render.CreateChartsReply{
+			RequestId: reqID,
+			Index:     int32(res.Index),
+			Chart:     res.Chart,
+		}
+
+		if res.Err != nil {
+			reply.ErrorMessage = status.Convert(s.createChartErr(reqID, res.Err)).Message()
+		}
+
+		if err := stream.Send(reply); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) createChartOpts(ctx context.Context, req *render.CreateChartRequest) renderer.CreateChartOpts {
+	reqTenant := interceptor.GetTenant(ctx)
+
+	return renderer.CreateChartOpts{
+		RequestID:   interceptor.GetRequestID(ctx),
+		Request:     req,
+		Tenant:      reqTenant,
+		Limits:      s.tenants.Limits(reqTenant),
+		CostLimits:  s.tenants.CostLimits(reqTenant),
+		Renderers:   s.renderers,
+		Timeout:     s.tenants.RendererTimeout(reqTenant, 0),
+		Storage:     s.storage,
+		ChartTTL:    s.chartTTL,
+		RenderCache: s.renderCache,
+		Coalescer:   s.renderCoalescer,
+		Queue:       s.renderQueue,
+		Webhooks:    s.webhookQueue,
+		Costs:       s.costs,
+	}
+}
+
+// auditCreateChart writes the chart creation into the audit log.
+func (s *Server) auditCreateChart(ctx context.Context, specHash string, res *render.ChartReply, err error) {
+	entry := interceptor.NewAuditEntry(ctx, audit.OperationCreateChart)
+	entry.SpecHash = specHash
+
+	if res != nil {
+		entry.ChartID = res.ChartId
+	}
+
+	s.recordAudit(entry, err)
+}
+
+// recordAudit writes the entry of the operation into the audit log.
+// Failed writes are logged since they can't fail the operation.
+func (s *Server) recordAudit(entry audit.Entry, opErr error) {
+	if err := s.audit.Record(entry, opErr); err != nil {
+		s.log.Error().Str(interceptor.RequestIDLogKey, entry.RequestID).Err(err).Msg("Unable to write audit log entry")
+	}
+}
+
+// createChartErr converts renderer.CreateChart error into status.Status error.
+//
+// nolint: wrapcheck
+func (s *Server) createChartErr(reqID string, err error) error {
 	switch {
-	case err == nil:
-		return res, nil
 	case errors.Is(err, renderer.ErrGenerateChartIDFailed):
-		return nil, interceptor.InternalError()
+		return interceptor.InternalError()
+	case errors.Is(err, renderer.ErrSaveChartFailed):
+		s.log.Error().Str(interceptor.RequestIDLogKey, reqID).Err(err).Msg("Unable to save chart")
+
+		return interceptor.InternalError()
 	case errors.Is(err, renderer.ErrCreateChartRequestCancelled):
-		return nil, status.Error(codes.Canceled, err.Error())
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, renderer.ErrRenderQueueFull), errors.Is(err, cost.ErrBudgetExhausted):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, concurrency.ErrLimitExceeded):
+		return limitExceededErr(err)
+	case errors.Is(err, renderer.ErrRendererUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
 	default:
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return status.Error(codes.InvalidArgument, err.Error())
 	}
 }
 
+// limitExceededErr converts the concurrency limit error into codes.ResourceExhausted status.Status
+// with errdetails.RetryInfo.
+//
+// nolint: wrapcheck
+func limitExceededErr(err error) error {
+	var limitErr *concurrency.LimitError
+	if !errors.As(err, &limitErr) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	detailed, detailsErr := status.New(codes.ResourceExhausted, err.Error()).WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(limitErr.RetryAfter),
+	})
+	if detailsErr != nil {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	return detailed.Err()
+}
+
 // GetChart implements render.ChartAPIServer.GetChart.
-// It returns codes.NotFound until storage is implemented.
-func (s *Server) GetChart(_ context.Context, req *render.GetChartRequest) (*render.ChartReply, error) {
-	return nil, status.Errorf(codes.NotFound, "chart %s is not found", req.ChartId)
+//
+// nolint: wrapcheck
+func (s *Server) GetChart(ctx context.Context, req *render.GetChartRequest) (*render.ChartReply, error) {
+	reqID := interceptor.GetRequestID(ctx)
+
+	res, err := s.storage.GetChart(ctx, interceptor.GetTenant(ctx), req.ChartId)
+
+	auditEntry := interceptor.NewAuditEntry(ctx, audit.OperationGetChart)
+	auditEntry.ChartID = req.ChartId
+	s.recordAudit(auditEntry, err)
+
+	switch {
+	case err == nil:
+		res.RequestId = reqID
+
+		return res, nil
+	case errors.Is(err, storage.ErrChartNotFound):
+		return nil, status.Errorf(codes.NotFound, "chart %s is not found", req.ChartId)
+	default:
+		s.log.Error().Str(interceptor.RequestIDLogKey, reqID).Err(err).Msg("Unable to get chart")
+
+		return nil, interceptor.InternalError()
+	}
+}
+
+// DeleteChart implements render.ChartAPIServer.DeleteChart.
+//
+// nolint: wrapcheck
+func (s *Server) DeleteChart(ctx context.Context, req *render.DeleteChartRequest) (*render.ChartReply, error) {
+	reqID := interceptor.GetRequestID(ctx)
+
+	res, err := s.storage.DeleteChart(ctx, interceptor.GetTenant(ctx), req.ChartId, time.Now().UTC())
+
+	auditEntry := interceptor.NewAuditEntry(ctx, audit.OperationDeleteChart)
+	auditEntry.ChartID = req.ChartId
+	s.recordAudit(auditEntry, err)
+
+	switch {
+	case err == nil:
+		res.RequestId = reqID
+
+		return res, nil
+	case errors.Is(err, storage.ErrChartNotFound):
+		return nil, status.Errorf(codes.NotFound, "chart %s is not found", req.ChartId)
+	default:
+		s.log.Error().Str(interceptor.RequestIDLogKey, reqID).Err(err).Msg("Unable to delete chart")
+
+		return nil, interceptor.InternalError()
+	}
+}
+
+// ListCharts implements render.ChartAPIServer.ListCharts.
+//
+// nolint: wrapcheck
+func (s *Server) ListCharts(ctx context.Context, req *render.ListChartsRequest) (*render.ListChartsReply, error) {
+	reqID := interceptor.GetRequestID(ctx)
+
+	listOpts := convert.ListChartsRequestToListOpts(req)
+	listOpts.Tenant = interceptor.GetTenant(ctx)
+
+	res, err := s.storage.ListCharts(ctx, listOpts)
+	s.recordAudit(interceptor.NewAuditEntry(ctx, audit.OperationListCharts), err)
+
+	switch {
+	case err == nil:
+		for _, chart := range res.Charts {
+			chart.RequestId = reqID
+		}
+
+		return &render.ListChartsReply{
+			RequestId:     reqID,
+			Charts:        res.Charts,
+			NextPageToken: res.NextPageToken,
+		}, nil
+	case errors.Is(err, storage.ErrBadPageSize), errors.Is(err, storage.ErrBadPageToken):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	default:
+		s.log.Error().Str(interceptor.RequestIDLogKey, reqID).Err(err).Msg("Unable to list charts")
+
+		return nil, interceptor.InternalError()
+	}
 }
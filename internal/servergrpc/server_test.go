@@ -2,38 +2,83 @@ package servergrpc_test
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"github.com/limpidchart/lc-api/internal/audit"
+	"github.com/limpidchart/lc-api/internal/auth"
 	"github.com/limpidchart/lc-api/internal/backend"
 	"github.com/limpidchart/lc-api/internal/config"
 	"github.com/limpidchart/lc-api/internal/metric"
 	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/renderer"
 	"github.com/limpidchart/lc-api/internal/servergrpc"
+	"github.com/limpidchart/lc-api/internal/servergrpc/interceptor"
 	"github.com/limpidchart/lc-api/internal/tcputils"
 	"github.com/limpidchart/lc-api/internal/testutils"
+	"github.com/limpidchart/lc-api/internal/tlsutils"
+	"github.com/limpidchart/lc-api/internal/webhook"
 )
 
 const (
 	testingChartAPIEnvTimeoutSecs  = 5
 	testingChartAPIEnvShutdownSecs = 1
+	testingChartAPIEnvChartTTLSecs = 3600
+
+	testingChartAPIEnvRenderCacheSize = 10
+
+	testingChartAPIEnvRenderQueueWorkers = 2
+	testingChartAPIEnvRenderQueueSize    = 10
+
+	testingChartAPIEnvBatchParallelism = 2
+	testingChartAPIEnvBatchMaxSize     = 5
+
+	testingChartAPIEnvWebhookSecret = "webhook-secret"
+
+	testingChartAPIEnvCostBudgetWindowSecs = 3600
 )
 
 type testingChartAPIEnv struct {
-	chartAPIServerConn *grpc.ClientConn
+	chartAPIServerConn  *grpc.ClientConn
+	chartRendererServer *testutils.TestingChartRendererServer
 }
 
 type testingChartAPIEnvOpts struct {
 	rendererFailMsg   string
+	rendererFailCode  codes.Code
+	rendererFailFirst int
+	rendererRetries   int
+	rendererLimit     int
 	rendererChartData []byte
 	rendererLatency   time.Duration
+	apiKeysPath       string
+	jwksPath          string
+	tenantHeader      string
+	rateLimitRules    string
+	costBudget        int
+	tls               config.TLSConfig
+	clientTLS         *tls.Config
+	clientIP          config.ClientIPConfig
+	auditLogPath      string
 }
 
 func newTestingChartAPIEnv(ctx context.Context, t *testing.T, opts testingChartAPIEnvOpts) *testingChartAPIEnv {
@@ -42,6 +87,8 @@ func newTestingChartAPIEnv(ctx context.Context, t *testing.T, opts testingChartA
 	chartRendererServer, err := testutils.NewTestingChartRendererServer(testutils.Opts{
 		ChartData: opts.rendererChartData,
 		FailMsg:   opts.rendererFailMsg,
+		FailCode:  opts.rendererFailCode,
+		FailFirst: opts.rendererFailFirst,
 		Latency:   opts.rendererLatency,
 	})
 	if err != nil {
@@ -63,17 +110,70 @@ func newTestingChartAPIEnv(ctx context.Context, t *testing.T, opts testingChartA
 			ShutdownTimeoutSeconds: testingChartAPIEnvShutdownSecs,
 		},
 		Renderer: config.RendererConfig{
-			Address:               chartRendererServer.Address(),
-			ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
-			RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
+			Address:                         chartRendererServer.Address(),
+			ConnTimeoutSeconds:              testutils.RendererConnTimeoutSecs,
+			RequestTimeoutSeconds:           testutils.RendererRequestTimeoutSecs,
+			RetryMaxAttempts:                opts.rendererRetries + 1,
+			RetryInitialBackoffMilliseconds: 1,
+			RetryMaxBackoffMilliseconds:     1,
+			RetryCodes:                      codes.Unavailable.String(),
+			RetryBudgetBurst:                opts.rendererRetries,
+			LimitInitial:                    opts.rendererLimit,
+			LimitMin:                        opts.rendererLimit,
+			LimitMax:                        opts.rendererLimit,
+			LimitLatencyMilliseconds:        testutils.RendererRequestTimeoutSecs * 1000,
+			LimitBackoffPercent:             50,
+			LimitRetryAfterSeconds:          1,
+		},
+		Storage: config.StorageConfig{
+			Kind:            config.StorageKindMemory,
+			ChartTTLSeconds: testingChartAPIEnvChartTTLSecs,
+		},
+		RenderCache: config.RenderCacheConfig{
+			Size:       testingChartAPIEnvRenderCacheSize,
+			TTLSeconds: testingChartAPIEnvChartTTLSecs,
+		},
+		RenderQueue: config.RenderQueueConfig{
+			Workers: testingChartAPIEnvRenderQueueWorkers,
+			Size:    testingChartAPIEnvRenderQueueSize,
+		},
+		Batch: config.BatchConfig{
+			Parallelism: testingChartAPIEnvBatchParallelism,
+			MaxSize:     testingChartAPIEnvBatchMaxSize,
+		},
+		Webhook: config.WebhookConfig{
+			Secret:                testingChartAPIEnvWebhookSecret,
+			Workers:               1,
+			QueueSize:             testingChartAPIEnvRenderQueueSize,
+			MaxAttempts:           1,
+			RequestTimeoutSeconds: 1,
+			DeadLetterPath:        filepath.Join(t.TempDir(), "dead-letter.ndjson"),
+		},
+		Auth: config.AuthConfig{
+			APIKeysPath: opts.apiKeysPath,
+			JWKSPath:    opts.jwksPath,
+		},
+		Tenant: config.TenantConfig{
+			TrustedHeader: opts.tenantHeader,
+		},
+		RateLimit: config.RateLimitConfig{
+			Key:        config.RateLimitKeyIP,
+			Operations: opts.rateLimitRules,
+		},
+		Cost: config.CostConfig{
+			TenantBudget:        opts.costBudget,
+			BudgetWindowSeconds: testingChartAPIEnvCostBudgetWindowSecs,
+		},
+		TLS:      opts.tls,
+		ClientIP: opts.clientIP,
+		Audit: config.AuditConfig{
+			LogPath:          opts.auditLogPath,
+			MaxSizeMegabytes: 1,
+			MaxBackups:       1,
 		},
 	}
 
-	b, err := backend.NewBackend(ctx, config.RendererConfig{
-		Address:               chartRendererServer.Address(),
-		ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
-		RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
-	})
+	b, err := backend.NewBackend(ctx, cfg, metric.NewEmptyRecorder())
 	if err != nil {
 		t.Fatalf("unable to configure backend: %s", err)
 	}
@@ -84,6 +184,27 @@ func newTestingChartAPIEnv(ctx context.Context, t *testing.T, opts testingChartA
 	}
 
 	chartAPIServer := servergrpc.NewServer(&log, tcpList, b, cfg.GRPC, metric.NewEmptyRecorder())
+	renderWorkers := renderer.NewWorkers(&log, b.RenderQueue(), cfg.RenderQueue)
+	webhookDispatcher, err := webhook.NewDispatcher(&log, b.WebhookQueue(), cfg.Webhook, metric.NewEmptyRecorder())
+	if err != nil {
+		t.Fatalf("unable to configure webhook dispatcher: %s", err)
+	}
+
+	go func() {
+		if serveErr := renderWorkers.Serve(ctx); serveErr != nil {
+			t.Errorf("unable to serve testing render workers: %s", serveErr)
+
+			return
+		}
+	}()
+
+	go func() {
+		if serveErr := webhookDispatcher.Serve(ctx); serveErr != nil {
+			t.Errorf("unable to serve testing webhook dispatcher: %s", serveErr)
+
+			return
+		}
+	}()
 
 	go func() {
 		if serveErr := chartAPIServer.Serve(ctx); serveErr != nil {
@@ -93,13 +214,19 @@ func newTestingChartAPIEnv(ctx context.Context, t *testing.T, opts testingChartA
 		}
 	}()
 
-	chartAPIServerConn, err := grpc.DialContext(ctx, chartAPIServer.Address(), grpc.WithInsecure(), grpc.WithBlock())
+	transportCreds := grpc.WithInsecure()
+	if opts.clientTLS != nil {
+		transportCreds = grpc.WithTransportCredentials(credentials.NewTLS(opts.clientTLS))
+	}
+
+	chartAPIServerConn, err := grpc.DialContext(ctx, chartAPIServer.Address(), transportCreds, grpc.WithBlock())
 	if err != nil {
 		t.Fatalf("unable to create connection to testing lc-api server: %s", err)
 	}
 
 	return &testingChartAPIEnv{
-		chartAPIServerConn: chartAPIServerConn,
+		chartAPIServerConn:  chartAPIServerConn,
+		chartRendererServer: chartRendererServer,
 	}
 }
 
@@ -132,11 +259,286 @@ func TestCreateChart_OK(t *testing.T) {
 	assert.NotEmpty(t, createChartReply.ChartId)
 	assert.Equal(t, render.ChartStatus_CREATED, createChartReply.ChartStatus)
 	assert.NotEmpty(t, createChartReply.CreatedAt)
-	assert.NotEmpty(t, createChartReply.DeletedAt)
-	assert.Equal(t, createChartReply.CreatedAt, createChartReply.DeletedAt) // should be equal when storage is not used
+	assert.Nil(t, createChartReply.DeletedAt)
+	assert.Equal(t, createChartReply.CreatedAt.AsTime().Add(time.Second*testingChartAPIEnvChartTTLSecs), createChartReply.ExpiresAt.AsTime())
 	assert.Equal(t, chartData, createChartReply.ChartData)
 }
 
+func TestCreateChart_RenderCache(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	chartData := []byte("chart svg")
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: chartData,
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+	newRequest := func() *render.CreateChartRequest {
+		return testutils.NewCreateChartRequest().
+			SetSizes().
+			SetBandBottomAxis().
+			SetLinearLeftAxis().
+			AddAreaView().
+			Unembed()
+	}
+
+	firstReply, firstErr := chartAPIClient.CreateChart(ctx, newRequest())
+	assert.NoError(t, firstErr)
+
+	// The same request should be served from the render cache.
+	secondReply, secondErr := chartAPIClient.CreateChart(ctx, newRequest())
+	assert.NoError(t, secondErr)
+	assert.NotEqual(t, firstReply.ChartId, secondReply.ChartId)
+	assert.Equal(t, chartData, secondReply.ChartData)
+	assert.Equal(t, 1, testingChartAPIEnv.chartRendererServer.Requests())
+
+	// Different request should be rendered.
+	_, thirdErr := chartAPIClient.CreateChart(ctx, testutils.NewCreateChartRequest().
+		SetTitle().
+		SetSizes().
+		SetBandBottomAxis().
+		SetLinearLeftAxis().
+		AddAreaView().
+		Unembed())
+	assert.NoError(t, thirdErr)
+	assert.Equal(t, 2, testingChartAPIEnv.chartRendererServer.Requests())
+}
+
+func TestCreateChart_Coalesce(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	chartData := []byte("chart svg")
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: chartData,
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 200,
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+
+	const callers = 5
+
+	wg := &sync.WaitGroup{}
+	replies := make([]*render.ChartReply, callers)
+	errs := make([]error, callers)
+
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			replies[i], errs[i] = chartAPIClient.CreateChart(ctx, testutils.NewCreateChartRequest().
+				SetSizes().
+				SetBandBottomAxis().
+				SetLinearLeftAxis().
+				AddLineView().
+				Unembed())
+		}(i)
+	}
+
+	wg.Wait()
+
+	chartIDs := make(map[string]struct{}, callers)
+	requestIDs := make(map[string]struct{}, callers)
+
+	for i := 0; i < callers; i++ {
+		assert.NoError(t, errs[i])
+		assert.Equal(t, chartData, replies[i].ChartData)
+
+		chartIDs[replies[i].ChartId] = struct{}{}
+		requestIDs[replies[i].RequestId] = struct{}{}
+	}
+
+	assert.Len(t, chartIDs, callers)
+	assert.Len(t, requestIDs, callers)
+	assert.Equal(t, 1, testingChartAPIEnv.chartRendererServer.Requests())
+}
+
+func TestCreateChart_Async(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name                 string
+		rendererFailMsg      string
+		expectedChartStatus  render.ChartStatus
+		expectedChartData    []byte
+		expectedErrorMessage string
+	}{
+		{
+			"created",
+			"",
+			render.ChartStatus_CREATED,
+			[]byte("chart svg"),
+			"",
+		},
+		{
+			"failed",
+			"bad chart",
+			render.ChartStatus_ERROR,
+			nil,
+			"rpc error: code = InvalidArgument desc = bad chart",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+			defer cancel()
+
+			testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+				rendererChartData: []byte("chart svg"),
+				rendererFailMsg:   tc.rendererFailMsg,
+				rendererLatency:   time.Millisecond * 100,
+			})
+
+			chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+
+			req := testutils.NewCreateChartRequest().
+				SetTitle().
+				SetSizes().
+				SetBandBottomAxis().
+				SetLinearLeftAxis().
+				AddAreaView().
+				Unembed()
+			req.Async = true
+
+			createChartReply, createChartErr := chartAPIClient.CreateChart(ctx, req)
+			assert.NoError(t, createChartErr)
+			assert.Equal(t, render.ChartStatus_PENDING, createChartReply.ChartStatus)
+			assert.Equal(t, req.Title, createChartReply.Title)
+			assert.Empty(t, createChartReply.ChartData)
+
+			var getChartReply *render.ChartReply
+
+			assert.Eventually(t, func() bool {
+				reply, err := chartAPIClient.GetChart(ctx, &render.GetChartRequest{ChartId: createChartReply.ChartId})
+				if err != nil || reply.ChartStatus == render.ChartStatus_PENDING {
+					return false
+				}
+
+				getChartReply = reply
+
+				return true
+			}, time.Second*2, time.Millisecond*50)
+
+			assert.Equal(t, tc.expectedChartStatus, getChartReply.ChartStatus)
+			assert.Equal(t, tc.expectedChartData, getChartReply.ChartData)
+			assert.Equal(t, tc.expectedErrorMessage, getChartReply.ErrorMessage)
+		})
+	}
+}
+
+func TestCreateChart_Callback(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	bodies := make(chan []byte, 1)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil || r.Header.Get(webhook.SignatureHeader) != webhook.Sign([]byte(testingChartAPIEnvWebhookSecret), body) {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		bodies <- body
+	}))
+	defer receiver.Close()
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: []byte("chart svg"),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+
+	newRequest := func(callbackURL string) *render.CreateChartRequest {
+		req := testutils.NewCreateChartRequest().
+			SetSizes().
+			SetBandBottomAxis().
+			SetLinearLeftAxis().
+			AddAreaView().
+			Unembed()
+		req.Async = true
+		req.CallbackUrl = callbackURL
+
+		return req
+	}
+
+	createChartReply, createChartErr := chartAPIClient.CreateChart(ctx, newRequest(receiver.URL))
+	assert.NoError(t, createChartErr)
+
+	select {
+	case body := <-bodies:
+		assert.Contains(t, string(body), `"chart_id":"`+createChartReply.ChartId+`"`)
+		assert.Contains(t, string(body), `"chart_status":"CREATED"`)
+	case <-ctx.Done():
+		t.Fatal("callback is not delivered")
+	}
+
+	_, badCallbackErr := chartAPIClient.CreateChart(ctx, newRequest("/charts"))
+	assert.Equal(t, codes.InvalidArgument, status.Code(badCallbackErr))
+}
+
+func TestCreateChart_ExpiresIn(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: []byte("chart svg"),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+
+	req := testutils.NewCreateChartRequest().
+		SetSizes().
+		SetBandBottomAxis().
+		SetLinearLeftAxis().
+		AddAreaView().
+		SetExpiresIn(time.Minute).
+		Unembed()
+
+	createChartReply, createChartErr := chartAPIClient.CreateChart(ctx, req)
+
+	assert.NoError(t, createChartErr)
+	assert.Equal(t, createChartReply.CreatedAt.AsTime().Add(time.Minute), createChartReply.ExpiresAt.AsTime())
+
+	badReq := testutils.NewCreateChartRequest().
+		SetSizes().
+		SetBandBottomAxis().
+		SetLinearLeftAxis().
+		AddAreaView().
+		SetExpiresIn(-time.Minute).
+		Unembed()
+
+	badReply, badErr := chartAPIClient.CreateChart(ctx, badReq)
+
+	assert.Equal(t, status.Error(codes.InvalidArgument, renderer.ErrBadExpiresIn.Error()).Error(), badErr.Error())
+	assert.Empty(t, badReply)
+}
+
 // nolint: paralleltest
 func TestCreateChart_ConvertErrs(t *testing.T) {
 	// nolint: govet
@@ -233,26 +635,1024 @@ func TestCreateChart_RendererTooLong(t *testing.T) {
 	assert.Empty(t, actualReply)
 }
 
-func TestGetChart_NotFound(t *testing.T) {
+func TestCreateChart_RendererRetried(t *testing.T) {
 	t.Parallel()
 
-	chartID := "uuid_1"
-	errMsg := "chart %s is not found"
-	expectedErr := status.Errorf(codes.NotFound, errMsg, chartID)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	chartData := []byte("chart svg")
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: chartData,
+		rendererFailMsg:   "connection reset by peer",
+		rendererFailCode:  codes.Unavailable,
+		rendererFailFirst: 2,
+		rendererRetries:   2,
+		rendererLatency:   time.Millisecond,
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+	req := testutils.NewCreateChartRequest().
+		SetSizes().
+		SetBandBottomAxis().
+		SetLinearLeftAxis().
+		AddAreaView().
+		Unembed()
+
+	createChartReply, createChartErr := chartAPIClient.CreateChart(ctx, req)
+
+	assert.NoError(t, createChartErr)
+	assert.Equal(t, chartData, createChartReply.ChartData)
+	assert.Equal(t, 3, testingChartAPIEnv.chartRendererServer.Requests())
+}
+
+func TestCreateChart_RendererUnavailable(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := status.Error(codes.Unavailable, "lc-renderer is unavailable: connection reset by peer")
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
 	defer cancel()
 
 	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
 		rendererChartData: nil,
-		rendererFailMsg:   "",
-		rendererLatency:   time.Second,
+		rendererFailMsg:   "connection reset by peer",
+		rendererFailCode:  codes.Unavailable,
+		rendererRetries:   1,
+		rendererLatency:   time.Millisecond,
 	})
 
 	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+	req := testutils.NewCreateChartRequest().
+		SetSizes().
+		SetBandBottomAxis().
+		SetLinearLeftAxis().
+		AddAreaView().
+		Unembed()
 
-	actualReply, actualErr := chartAPIClient.GetChart(ctx, testutils.GetChartRequest(chartID))
+	actualReply, actualErr := chartAPIClient.CreateChart(ctx, req)
 
 	assert.Equal(t, expectedErr.Error(), actualErr.Error())
 	assert.Empty(t, actualReply)
+	assert.Equal(t, 2, testingChartAPIEnv.chartRendererServer.Requests())
+}
+
+func TestCreateChart_RendererOverloaded(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: []byte("chart svg"),
+		rendererFailMsg:   "",
+		rendererLimit:     1,
+		rendererLatency:   time.Millisecond * 500,
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+
+	// The first chart takes the only lc-renderer slot.
+	firstErr := make(chan error, 1)
+
+	go func() {
+		req := testutils.NewCreateChartRequest().SetSizes().SetBandBottomAxis().SetLinearLeftAxis().AddAreaView().Unembed()
+
+		_, err := chartAPIClient.CreateChart(ctx, req)
+		firstErr <- err
+	}()
+
+	for testingChartAPIEnv.chartRendererServer.Requests() == 0 {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("chart is not rendered")
+		case <-time.After(time.Millisecond * 10):
+		}
+	}
+
+	// Another chart is shed since the wait queue is disabled.
+	req := testutils.NewCreateChartRequest().SetTitle().SetSizes().SetBandBottomAxis().SetLinearLeftAxis().AddAreaView().Unembed()
+
+	actualReply, actualErr := chartAPIClient.CreateChart(ctx, req)
+
+	st := status.Convert(actualErr)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Equal(t, "lc-renderer concurrency limit is exceeded", st.Message())
+	assert.Empty(t, actualReply)
+	assert.Equal(t, 1, testingChartAPIEnv.chartRendererServer.Requests())
+
+	if assert.Len(t, st.Details(), 1) {
+		retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+		if assert.True(t, ok) {
+			assert.Equal(t, time.Second, retryInfo.RetryDelay.AsDuration())
+		}
+	}
+
+	assert.NoError(t, <-firstErr)
+}
+
+func TestCreateCharts_OK(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	chartData := []byte("chart svg")
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: chartData,
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 50,
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+	req := &render.CreateChartsRequest{
+		Charts: []*render.CreateChartRequest{
+			testutils.NewCreateChartRequest().SetSizes().SetBandBottomAxis().SetLinearLeftAxis().AddAreaView().Unembed(),
+			testutils.NewCreateChartRequest().SetSizes().AddAreaView().Unembed(),
+			testutils.NewCreateChartRequest().SetTitle().SetSizes().SetBandBottomAxis().SetLinearLeftAxis().AddAreaView().Unembed(),
+		},
+	}
+
+	stream, err := chartAPIClient.CreateCharts(ctx, req)
+	if err != nil {
+		t.Fatalf("unable to create charts: %s", err)
+	}
+
+	replies := make(map[int32]*render.CreateChartsReply)
+
+	for {
+		reply, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("unable to receive create charts reply: %s", err)
+		}
+
+		replies[reply.Index] = reply
+	}
+
+	assert.Len(t, replies, len(req.Charts))
+
+	for _, idx := range []int32{0, 2} {
+		assert.NotEmpty(t, replies[idx].RequestId)
+		assert.Empty(t, replies[idx].ErrorMessage)
+		assert.Equal(t, render.ChartStatus_CREATED, replies[idx].Chart.ChartStatus)
+		assert.Equal(t, chartData, replies[idx].Chart.ChartData)
+	}
+
+	assert.Equal(t, replies[0].RequestId, replies[2].RequestId)
+	assert.NotEqual(t, replies[0].Chart.ChartId, replies[2].Chart.ChartId)
+	assert.Nil(t, replies[1].Chart)
+	assert.Equal(t, "unable to validate chart axes: chart axes are not specified", replies[1].ErrorMessage)
+}
+
+func TestCreateCharts_BadBatchSize(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: []byte("chart svg"),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+
+	tt := []struct {
+		name     string
+		size     int
+		expected string
+	}{
+		{
+			"empty_batch",
+			0,
+			"rpc error: code = InvalidArgument desc = bad batch size: batch should contain from 1 to 5 charts, got 0",
+		},
+		{
+			"too_big_batch",
+			testingChartAPIEnvBatchMaxSize + 1,
+			"rpc error: code = InvalidArgument desc = bad batch size: batch should contain from 1 to 5 charts, got 6",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			req := &render.CreateChartsRequest{}
+			for i := 0; i < tc.size; i++ {
+				req.Charts = append(req.Charts, testutils.NewCreateChartRequest().SetSizes().AddAreaView().Unembed())
+			}
+
+			stream, err := chartAPIClient.CreateCharts(ctx, req)
+			if err != nil {
+				t.Fatalf("unable to create charts: %s", err)
+			}
+
+			_, err = stream.Recv()
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	jwtKeys := testutils.NewJWTKeys(t)
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: []byte("chart svg"),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+		apiKeysPath:       testutils.APIKeysFile(t, map[string]string{"reporting": "reporting-key"}),
+		jwksPath:          jwtKeys.JWKSFile(t),
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+
+	validToken := jwtKeys.Token(t, auth.AlgRS256, map[string]interface{}{
+		"sub":   "billing",
+		"exp":   time.Now().Add(time.Minute).Unix(),
+		"scope": "charts:read charts:create",
+	})
+	expiredToken := jwtKeys.Token(t, auth.AlgRS256, map[string]interface{}{"sub": "billing", "exp": time.Now().Add(-time.Hour).Unix()})
+
+	tt := []struct {
+		name          string
+		apiKey        string
+		authorization string
+		expectedCode  codes.Code
+	}{
+		{"valid_api_key", "reporting-key", "", codes.NotFound},
+		{"bad_api_key", "billing-key", "", codes.Unauthenticated},
+		{"valid_bearer_token", "", "Bearer " + validToken, codes.NotFound},
+		{"expired_bearer_token", "", "Bearer " + expiredToken, codes.Unauthenticated},
+		{"no_credentials", "", "", codes.Unauthenticated},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			reqCtx := ctx
+			if tc.apiKey != "" {
+				reqCtx = metadata.AppendToOutgoingContext(reqCtx, interceptor.APIKeyMetadataKey, tc.apiKey)
+			}
+
+			if tc.authorization != "" {
+				reqCtx = metadata.AppendToOutgoingContext(reqCtx, interceptor.AuthorizationMetadataKey, tc.authorization)
+			}
+
+			_, err := chartAPIClient.GetChart(reqCtx, &render.GetChartRequest{ChartId: testutils.RandomUUID(t).String()})
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+
+			stream, err := chartAPIClient.CreateCharts(reqCtx, &render.CreateChartsRequest{})
+			if err != nil {
+				t.Fatalf("unable to create charts: %s", err)
+			}
+
+			_, err = stream.Recv()
+			if tc.expectedCode == codes.Unauthenticated {
+				assert.Equal(t, codes.Unauthenticated, status.Code(err))
+			} else {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	jwtKeys := testutils.NewJWTKeys(t)
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: []byte("chart svg"),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+		jwksPath:          jwtKeys.JWKSFile(t),
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+
+	token := jwtKeys.Token(t, auth.AlgEdDSA, map[string]interface{}{
+		"sub":   "reporting",
+		"exp":   time.Now().Add(time.Minute).Unix(),
+		"scope": "charts:read charts:list",
+	})
+	reqCtx := metadata.AppendToOutgoingContext(ctx, interceptor.AuthorizationMetadataKey, "Bearer "+token)
+
+	_, err := chartAPIClient.GetChart(reqCtx, &render.GetChartRequest{ChartId: testutils.RandomUUID(t).String()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = chartAPIClient.ListCharts(reqCtx, &render.ListChartsRequest{})
+	assert.NoError(t, err)
+
+	_, err = chartAPIClient.DeleteChart(reqCtx, &render.DeleteChartRequest{ChartId: testutils.RandomUUID(t).String()})
+	assertMissingScope(t, err, auth.ScopeChartsDelete)
+
+	_, err = chartAPIClient.CreateChart(reqCtx, testutils.NewCreateChartRequest().Unembed())
+	assertMissingScope(t, err, auth.ScopeChartsCreate)
+
+	stream, err := chartAPIClient.CreateCharts(reqCtx, &render.CreateChartsRequest{})
+	if err != nil {
+		t.Fatalf("unable to create charts: %s", err)
+	}
+
+	_, err = stream.Recv()
+	assertMissingScope(t, err, auth.ScopeChartsCreate)
+}
+
+func assertMissingScope(t *testing.T, err error, scope string) {
+	t.Helper()
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.PermissionDenied, st.Code())
+	assert.Equal(t, "caller doesn't have the required scope: "+scope, st.Message())
+
+	if assert.Len(t, st.Details(), 1) {
+		errInfo, ok := st.Details()[0].(*errdetails.ErrorInfo)
+		if assert.True(t, ok) {
+			assert.Equal(t, auth.ReasonMissingScope, errInfo.Reason)
+			assert.Equal(t, interceptor.ErrorInfoDomain, errInfo.Domain)
+			assert.Equal(t, map[string]string{"scope": scope}, errInfo.Metadata)
+		}
+	}
+}
+
+func TestTenantIsolation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: []byte("chart svg"),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+		tenantHeader:      "X-Tenant-Id",
+		clientIP:          config.ClientIPConfig{TrustedProxies: "127.0.0.1"},
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+	req := testutils.NewCreateChartRequest().
+		SetSizes().
+		SetBandBottomAxis().
+		SetLinearLeftAxis().
+		AddAreaView().
+		Unembed()
+
+	acmeCtx := metadata.AppendToOutgoingContext(ctx, "x-tenant-id", "acme")
+	globexCtx := metadata.AppendToOutgoingContext(ctx, "x-tenant-id", "globex")
+
+	createChartReply, createChartErr := chartAPIClient.CreateChart(acmeCtx, req)
+	if createChartErr != nil {
+		t.Fatalf("unable to create chart: %s", createChartErr)
+	}
+
+	assert.Equal(t, "acme", createChartReply.Tenant)
+
+	getChartReply, getChartErr := chartAPIClient.GetChart(acmeCtx, testutils.GetChartRequest(createChartReply.ChartId))
+	assert.NoError(t, getChartErr)
+	assert.Equal(t, createChartReply.ChartId, getChartReply.ChartId)
+
+	_, getChartErr = chartAPIClient.GetChart(globexCtx, testutils.GetChartRequest(createChartReply.ChartId))
+	assert.Equal(t, codes.NotFound, status.Code(getChartErr))
+
+	_, getChartErr = chartAPIClient.GetChart(ctx, testutils.GetChartRequest(createChartReply.ChartId))
+	assert.Equal(t, codes.NotFound, status.Code(getChartErr))
+
+	_, deleteChartErr := chartAPIClient.DeleteChart(globexCtx, &render.DeleteChartRequest{ChartId: createChartReply.ChartId})
+	assert.Equal(t, codes.NotFound, status.Code(deleteChartErr))
+
+	listChartsReply, listChartsErr := chartAPIClient.ListCharts(globexCtx, &render.ListChartsRequest{})
+	assert.NoError(t, listChartsErr)
+	assert.Empty(t, listChartsReply.Charts)
+
+	badTenantCtx := metadata.AppendToOutgoingContext(ctx, "x-tenant-id", "acme/globex")
+	_, listChartsErr = chartAPIClient.ListCharts(badTenantCtx, &render.ListChartsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(listChartsErr))
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: []byte("chart svg"),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+		rateLimitRules:    "ListCharts=1:1",
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+
+	_, listChartsErr := chartAPIClient.ListCharts(ctx, &render.ListChartsRequest{})
+	assert.NoError(t, listChartsErr)
+
+	_, listChartsErr = chartAPIClient.ListCharts(ctx, &render.ListChartsRequest{})
+
+	st := status.Convert(listChartsErr)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Equal(t, "too many requests, retry in 60 seconds", st.Message())
+
+	if assert.Len(t, st.Details(), 1) {
+		retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+		if assert.True(t, ok) {
+			assert.InDelta(t, time.Minute.Seconds(), retryInfo.RetryDelay.AsDuration().Seconds(), 1)
+		}
+	}
+
+	// Other methods are not limited.
+	_, getChartErr := chartAPIClient.GetChart(ctx, testutils.GetChartRequest(testutils.RandomUUID(t).String()))
+	assert.Equal(t, codes.NotFound, status.Code(getChartErr))
+}
+
+func TestCostBudget(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: []byte("chart svg"),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+		tenantHeader:      "X-Tenant-Id",
+		clientIP:          config.ClientIPConfig{TrustedProxies: "127.0.0.1"},
+		costBudget:        100,
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+	req := testutils.NewCreateChartRequest().
+		SetSizes().
+		SetBandBottomAxis().
+		SetLinearLeftAxis().
+		AddAreaView().
+		Unembed()
+
+	acmeCtx := metadata.AppendToOutgoingContext(ctx, "x-tenant-id", "acme")
+	globexCtx := metadata.AppendToOutgoingContext(ctx, "x-tenant-id", "globex")
+
+	createChartReply, createChartErr := chartAPIClient.CreateChart(acmeCtx, req)
+	if createChartErr != nil {
+		t.Fatalf("unable to create chart: %s", createChartErr)
+	}
+
+	// 1000x800 chart area costs 80 and the area view with 2 values costs 12.
+	assert.Equal(t, int64(92), createChartReply.Cost)
+
+	getChartReply, getChartErr := chartAPIClient.GetChart(acmeCtx, testutils.GetChartRequest(createChartReply.ChartId))
+	assert.NoError(t, getChartErr)
+	assert.Equal(t, int64(92), getChartReply.Cost)
+
+	// Charts from the render cache are not charged.
+	createChartReply, createChartErr = chartAPIClient.CreateChart(acmeCtx, req)
+	if assert.NoError(t, createChartErr) {
+		assert.Equal(t, int64(92), createChartReply.Cost)
+	}
+
+	// Title doesn't change the cost but the chart is not cached.
+	titledReq := testutils.NewCreateChartRequest().
+		SetTitle().
+		SetSizes().
+		SetBandBottomAxis().
+		SetLinearLeftAxis().
+		AddAreaView().
+		Unembed()
+
+	_, createChartErr = chartAPIClient.CreateChart(acmeCtx, titledReq)
+
+	st := status.Convert(createChartErr)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Equal(t, "render cost budget is exhausted: 92 of 100 is spent in the last 1h0m0s", st.Message())
+
+	// Other tenants have their own budgets.
+	_, createChartErr = chartAPIClient.CreateChart(globexCtx, titledReq)
+	assert.NoError(t, createChartErr)
+}
+
+func TestCostBudget_Refund(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: []byte("chart svg"),
+		rendererFailMsg:   "unable to render chart",
+		rendererFailCode:  codes.Internal,
+		rendererFailFirst: 1,
+		rendererLatency:   time.Millisecond * 10,
+		costBudget:        100,
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+	req := testutils.NewCreateChartRequest().
+		SetSizes().
+		SetBandBottomAxis().
+		SetLinearLeftAxis().
+		AddAreaView().
+		Unembed()
+
+	_, createChartErr := chartAPIClient.CreateChart(ctx, req)
+	assert.Error(t, createChartErr)
+
+	// Cost of the failed chart is refunded, so the budget still fits the next one.
+	createChartReply, createChartErr := chartAPIClient.CreateChart(ctx, req)
+	if assert.NoError(t, createChartErr) {
+		assert.Equal(t, int64(92), createChartReply.Cost)
+	}
+}
+
+func TestCostBudget_AsyncCached(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: []byte("chart svg"),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+		costBudget:        190,
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+	req := testutils.NewCreateChartRequest().
+		SetSizes().
+		SetBandBottomAxis().
+		SetLinearLeftAxis().
+		AddAreaView().
+		Unembed()
+
+	_, createChartErr := chartAPIClient.CreateChart(ctx, req)
+	if createChartErr != nil {
+		t.Fatalf("unable to create chart: %s", createChartErr)
+	}
+
+	// Asynchronous chart is charged once it's queued and refunded once it's taken from the render cache.
+	req.Async = true
+
+	createChartReply, createChartErr := chartAPIClient.CreateChart(ctx, req)
+	if createChartErr != nil {
+		t.Fatalf("unable to create chart: %s", createChartErr)
+	}
+
+	assert.Eventually(t, func() bool {
+		reply, err := chartAPIClient.GetChart(ctx, &render.GetChartRequest{ChartId: createChartReply.ChartId})
+
+		return err == nil && reply.ChartStatus == render.ChartStatus_CREATED
+	}, time.Second*2, time.Millisecond*50)
+
+	titledReq := testutils.NewCreateChartRequest().
+		SetTitle().
+		SetSizes().
+		SetBandBottomAxis().
+		SetLinearLeftAxis().
+		AddAreaView().
+		Unembed()
+
+	_, createChartErr = chartAPIClient.CreateChart(ctx, titledReq)
+	assert.NoError(t, createChartErr)
+}
+
+func TestMutualTLS(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	ca := testutils.NewTestingCA(t, "lc")
+	serverCertPath, serverKeyPath := ca.Issue(t, "lc-api")
+	clientCertPath, clientKeyPath := ca.Issue(t, "client")
+
+	clientCerts, err := tlsutils.LoadCertificates(clientCertPath, clientKeyPath, ca.CertPath)
+	if err != nil {
+		t.Fatalf("unable to load client certificates: %s", err)
+	}
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: []byte("chart svg"),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+		tls: config.TLSConfig{
+			CertPath:     serverCertPath,
+			KeyPath:      serverKeyPath,
+			ClientCAPath: ca.CertPath,
+		},
+		clientTLS: clientCerts.ClientConfig("127.0.0.1"),
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+
+	_, listChartsErr := chartAPIClient.ListCharts(ctx, &render.ListChartsRequest{})
+	assert.NoError(t, listChartsErr)
+}
+
+func TestClientIP_Deny(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: []byte("chart svg"),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+		clientIP: config.ClientIPConfig{
+			TrustedProxies: "127.0.0.1",
+			Deny:           "198.51.100.0/24",
+		},
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+
+	_, listChartsErr := chartAPIClient.ListCharts(metadata.AppendToOutgoingContext(ctx, "x-forwarded-for", "203.0.113.1"), &render.ListChartsRequest{})
+	assert.NoError(t, listChartsErr)
+
+	_, listChartsErr = chartAPIClient.ListCharts(metadata.AppendToOutgoingContext(ctx, "x-forwarded-for", "198.51.100.1"), &render.ListChartsRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(listChartsErr))
+	assert.Equal(t, "client IP is not allowed: 198.51.100.1 is denied", status.Convert(listChartsErr).Message())
+}
+
+func TestAuditLog(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	auditLogPath := filepath.Join(t.TempDir(), "audit.ndjson")
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: []byte("chart svg"),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+		apiKeysPath:       testutils.APIKeysFile(t, map[string]string{"reporting": "reporting-key"}),
+		tenantHeader:      "X-Tenant-Id",
+		clientIP:          config.ClientIPConfig{TrustedProxies: "127.0.0.1"},
+		auditLogPath:      auditLogPath,
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+	req := testutils.NewCreateChartRequest().
+		SetSizes().
+		SetBandBottomAxis().
+		SetLinearLeftAxis().
+		AddAreaView().
+		Unembed()
+
+	reqCtx := metadata.AppendToOutgoingContext(ctx, interceptor.APIKeyMetadataKey, "reporting-key", "x-tenant-id", "acme")
+
+	createChartReply, createChartErr := chartAPIClient.CreateChart(reqCtx, req)
+	if createChartErr != nil {
+		t.Fatalf("unable to create chart: %s", createChartErr)
+	}
+
+	_, getChartErr := chartAPIClient.GetChart(reqCtx, testutils.GetChartRequest(createChartReply.ChartId))
+	assert.NoError(t, getChartErr)
+
+	_, deleteChartErr := chartAPIClient.DeleteChart(reqCtx, &render.DeleteChartRequest{ChartId: createChartReply.ChartId})
+	assert.NoError(t, deleteChartErr)
+
+	missingChartID := testutils.RandomUUID(t).String()
+
+	_, getChartErr = chartAPIClient.GetChart(reqCtx, testutils.GetChartRequest(missingChartID))
+	assert.Equal(t, codes.NotFound, status.Code(getChartErr))
+
+	_, listChartsErr := chartAPIClient.ListCharts(reqCtx, &render.ListChartsRequest{})
+	assert.NoError(t, listChartsErr)
+
+	auditLog, err := os.ReadFile(auditLogPath)
+	if err != nil {
+		t.Fatalf("unable to read audit log: %s", err)
+	}
+
+	entries := []audit.Entry{}
+
+	for _, line := range strings.Split(strings.TrimSpace(string(auditLog)), "\n") {
+		entry := audit.Entry{}
+		if err = json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("unable to decode audit log entry: %s", err)
+		}
+
+		assert.Equal(t, "reporting", entry.Identity)
+		assert.Equal(t, "acme", entry.Tenant)
+		assert.Equal(t, audit.TransportGRPC, entry.Transport)
+		assert.NotEmpty(t, entry.RequestID)
+		assert.False(t, entry.Time.IsZero())
+
+		entries = append(entries, entry)
+	}
+
+	if len(entries) != 5 {
+		t.Fatalf("expected 5 audit log entries, got %d", len(entries))
+	}
+
+	assert.Equal(t, audit.OperationCreateChart, entries[0].Operation)
+	assert.Equal(t, createChartReply.ChartId, entries[0].ChartID)
+	assert.Equal(t, audit.SpecHash(req), entries[0].SpecHash)
+	assert.Equal(t, audit.OutcomeSuccess, entries[0].Outcome)
+
+	assert.Equal(t, audit.OperationGetChart, entries[1].Operation)
+	assert.Equal(t, createChartReply.ChartId, entries[1].ChartID)
+	assert.Equal(t, audit.OutcomeSuccess, entries[1].Outcome)
+
+	assert.Equal(t, audit.OperationDeleteChart, entries[2].Operation)
+	assert.Equal(t, createChartReply.ChartId, entries[2].ChartID)
+	assert.Equal(t, audit.OutcomeSuccess, entries[2].Outcome)
+
+	assert.Equal(t, audit.OperationGetChart, entries[3].Operation)
+	assert.Equal(t, missingChartID, entries[3].ChartID)
+	assert.Equal(t, audit.OutcomeFailure, entries[3].Outcome)
+	assert.Equal(t, "chart is not found", entries[3].Error)
+
+	assert.Equal(t, audit.OperationListCharts, entries[4].Operation)
+	assert.Equal(t, audit.OutcomeSuccess, entries[4].Outcome)
+}
+
+func TestGetChart_NotFound(t *testing.T) {
+	t.Parallel()
+
+	chartID := "uuid_1"
+	errMsg := "chart %s is not found"
+	expectedErr := status.Errorf(codes.NotFound, errMsg, chartID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: nil,
+		rendererFailMsg:   "",
+		rendererLatency:   time.Second,
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+
+	actualReply, actualErr := chartAPIClient.GetChart(ctx, testutils.GetChartRequest(chartID))
+
+	assert.Equal(t, expectedErr.Error(), actualErr.Error())
+	assert.Empty(t, actualReply)
+}
+
+func TestGetChart_OK(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	chartData := []byte("chart svg")
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: chartData,
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 100,
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+	req := testutils.NewCreateChartRequest().
+		SetSizes().
+		SetBandBottomAxis().
+		SetLinearLeftAxis().
+		AddAreaView().
+		Unembed()
+
+	createChartReply, createChartErr := chartAPIClient.CreateChart(ctx, req)
+	if createChartErr != nil {
+		t.Fatalf("unable to create chart: %s", createChartErr)
+	}
+
+	getChartReply, getChartErr := chartAPIClient.GetChart(ctx, testutils.GetChartRequest(createChartReply.ChartId))
+
+	assert.NoError(t, getChartErr)
+	assert.NotEmpty(t, getChartReply.RequestId)
+	assert.NotEqual(t, createChartReply.RequestId, getChartReply.RequestId)
+	assert.Equal(t, createChartReply.ChartId, getChartReply.ChartId)
+	assert.Equal(t, render.ChartStatus_CREATED, getChartReply.ChartStatus)
+	assert.Equal(t, createChartReply.CreatedAt.AsTime(), getChartReply.CreatedAt.AsTime())
+	assert.Nil(t, getChartReply.DeletedAt)
+	assert.Equal(t, chartData, getChartReply.ChartData)
+}
+
+func TestDeleteChart_OK(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: []byte("chart svg"),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+	req := testutils.NewCreateChartRequest().
+		SetSizes().
+		SetBandBottomAxis().
+		SetLinearLeftAxis().
+		AddAreaView().
+		Unembed()
+
+	createChartReply, createChartErr := chartAPIClient.CreateChart(ctx, req)
+	if createChartErr != nil {
+		t.Fatalf("unable to create chart: %s", createChartErr)
+	}
+
+	deleteChartReply, deleteChartErr := chartAPIClient.DeleteChart(ctx, &render.DeleteChartRequest{ChartId: createChartReply.ChartId})
+
+	assert.NoError(t, deleteChartErr)
+	assert.Equal(t, createChartReply.ChartId, deleteChartReply.ChartId)
+	assert.Equal(t, render.ChartStatus_DELETED, deleteChartReply.ChartStatus)
+	assert.NotNil(t, deleteChartReply.DeletedAt)
+	assert.Empty(t, deleteChartReply.ChartData)
+
+	getChartReply, getChartErr := chartAPIClient.GetChart(ctx, testutils.GetChartRequest(createChartReply.ChartId))
+
+	assert.NoError(t, getChartErr)
+	assert.Equal(t, render.ChartStatus_DELETED, getChartReply.ChartStatus)
+	assert.Equal(t, deleteChartReply.DeletedAt.AsTime(), getChartReply.DeletedAt.AsTime())
+	assert.Empty(t, getChartReply.ChartData)
+}
+
+func TestDeleteChart_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: []byte("chart svg"),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+	})
+
+	chartID := "c47b94e4-d6b4-4ab5-9ee4-e8e2ce8f9c5d"
+	expectedErr := status.Errorf(codes.NotFound, "chart %s is not found", chartID)
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+
+	actualReply, actualErr := chartAPIClient.DeleteChart(ctx, &render.DeleteChartRequest{ChartId: chartID})
+
+	assert.Equal(t, expectedErr.Error(), actualErr.Error())
+	assert.Empty(t, actualReply)
+}
+
+func TestListCharts_OK(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: []byte("chart svg"),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+	req := testutils.NewCreateChartRequest().
+		SetTitle().
+		SetSizes().
+		SetBandBottomAxis().
+		SetLinearLeftAxis().
+		AddAreaView().
+		Unembed()
+
+	chartIDs := make([]string, 0, 3)
+
+	for i := 0; i < 3; i++ {
+		createChartReply, createChartErr := chartAPIClient.CreateChart(ctx, req)
+		if createChartErr != nil {
+			t.Fatalf("unable to create chart: %s", createChartErr)
+		}
+
+		chartIDs = append(chartIDs, createChartReply.ChartId)
+	}
+
+	listed := make([]string, 0, len(chartIDs))
+	pageToken := ""
+
+	for {
+		listChartsReply, listChartsErr := chartAPIClient.ListCharts(ctx, &render.ListChartsRequest{
+			PageSize:      2,
+			PageToken:     pageToken,
+			ChartStatus:   render.ChartStatus_CREATED,
+			TitleContains: "chart",
+		})
+		if listChartsErr != nil {
+			t.Fatalf("unable to list charts: %s", listChartsErr)
+		}
+
+		assert.NotEmpty(t, listChartsReply.RequestId)
+
+		for _, chart := range listChartsReply.Charts {
+			assert.Equal(t, listChartsReply.RequestId, chart.RequestId)
+			assert.Equal(t, "Chart", chart.Title)
+			assert.Empty(t, chart.ChartData)
+
+			listed = append(listed, chart.ChartId)
+		}
+
+		pageToken = listChartsReply.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	assert.ElementsMatch(t, chartIDs, listed)
+}
+
+func TestListCharts_BadPageSize(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := status.Errorf(codes.InvalidArgument, "page size should be between 1 and 100")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	testingChartAPIEnv := newTestingChartAPIEnv(ctx, t, testingChartAPIEnvOpts{
+		rendererChartData: nil,
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond,
+	})
+
+	chartAPIClient := render.NewChartAPIClient(testingChartAPIEnv.chartAPIServerConn)
+
+	actualReply, actualErr := chartAPIClient.ListCharts(ctx, &render.ListChartsRequest{PageSize: 1000})
+
+	assert.Equal(t, expectedErr.Error(), actualErr.Error())
+	assert.Empty(t, actualReply)
+}
+
+func TestBackendCheck_Unhealthy(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingChartAPIEnvTimeoutSecs)
+	defer cancel()
+
+	b := backend.NewEmptyBackend(false)
+	chartID := testutils.RandomUUID(t).String()
+
+	if err := b.Storage().SaveChart(ctx, &render.ChartReply{ChartId: chartID, ChartStatus: render.ChartStatus_CREATED}); err != nil {
+		t.Fatalf("unable to save testing chart: %s", err)
+	}
+
+	tcpList, err := tcputils.Listener(tcputils.LocalhostWithRandomPort)
+	if err != nil {
+		t.Fatalf("failed to start lc-api gRPC TCP listener: %s", err)
+	}
+
+	log := zerolog.New(os.Stderr)
+	chartAPIServer := servergrpc.NewServer(&log, tcpList, b, config.GRPCConfig{
+		ShutdownTimeoutSeconds: testingChartAPIEnvShutdownSecs,
+	}, metric.NewEmptyRecorder())
+
+	go func() {
+		if serveErr := chartAPIServer.Serve(ctx); serveErr != nil {
+			t.Errorf("unable to serve testing chart API server: %s", serveErr)
+
+			return
+		}
+	}()
+
+	chartAPIServerConn, err := grpc.DialContext(ctx, chartAPIServer.Address(), grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		t.Fatalf("unable to create connection to testing lc-api server: %s", err)
+	}
+
+	chartAPIClient := render.NewChartAPIClient(chartAPIServerConn)
+
+	_, err = chartAPIClient.GetChart(ctx, testutils.GetChartRequest(chartID))
+	assert.NoError(t, err)
+
+	_, err = chartAPIClient.ListCharts(ctx, &render.ListChartsRequest{})
+	assert.NoError(t, err)
+
+	_, err = chartAPIClient.CreateChart(ctx, testutils.NewCreateChartRequest().SetSizes().AddAreaView().Unembed())
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	stream, err := chartAPIClient.CreateCharts(ctx, &render.CreateChartsRequest{})
+	if err != nil {
+		t.Fatalf("unable to create charts: %s", err)
+	}
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	_, err = chartAPIClient.DeleteChart(ctx, &render.DeleteChartRequest{ChartId: chartID})
+	assert.NoError(t, err)
 }
@@ -4,17 +4,24 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 
 	"github.com/limpidchart/lc-api/internal/backend"
 )
 
 const name = "gRPC healthcheck"
 
+// RendererPoolServicePrefix represents a prefix of the health check services of the lc-renderer pools.
+const RendererPoolServicePrefix = "lc-renderer/"
+
 // Server implements gRPC grpc_health_v1.HealthServer.
 type Server struct {
 	grpc_health_v1.UnimplementedHealthServer
@@ -26,7 +33,12 @@ type Server struct {
 
 // NewServer configures a new Server.
 func NewServer(log *zerolog.Logger, tcpList *net.TCPListener, bCon backend.ConnSupervisor) *Server {
-	grpcServer := grpc.NewServer()
+	serverOpts := []grpc.ServerOption{}
+	if serverCerts := bCon.ServerCertificates(); serverCerts != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(serverCerts.ServerConfig())))
+	}
+
+	grpcServer := grpc.NewServer(serverOpts...)
 	hcServer := &Server{
 		log:        log,
 		grpcServer: grpcServer,
@@ -77,14 +89,29 @@ func (s *Server) Name() string {
 }
 
 // Check implements grpc_health_v1.HealthServer.Check.
-func (s *Server) Check(_ context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
-	status := grpc_health_v1.HealthCheckResponse_SERVING
+// Empty service reports overall health, RendererPoolServicePrefix with a pool name reports health of the lc-renderer pool.
+//
+// nolint: wrapcheck
+func (s *Server) Check(_ context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	health := s.bCon.IsHealthy()
+	healthy := health.Healthy()
+
+	if req.Service != "" {
+		poolHealthy, ok := health[strings.TrimPrefix(req.Service, RendererPoolServicePrefix)]
+		if !ok || !strings.HasPrefix(req.Service, RendererPoolServicePrefix) {
+			return nil, status.Errorf(codes.NotFound, "unknown service %q", req.Service)
+		}
+
+		healthy = poolHealthy
+	}
+
+	hcStatus := grpc_health_v1.HealthCheckResponse_SERVING
 
-	if !s.bCon.IsHealthy() {
-		status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	if !healthy {
+		hcStatus = grpc_health_v1.HealthCheckResponse_NOT_SERVING
 	}
 
 	return &grpc_health_v1.HealthCheckResponse{
-		Status: status,
+		Status: hcStatus,
 	}, nil
 }
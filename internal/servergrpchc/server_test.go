@@ -9,10 +9,13 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 
 	"github.com/limpidchart/lc-api/internal/backend"
 	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/renderer"
 	"github.com/limpidchart/lc-api/internal/servergrpchc"
 	"github.com/limpidchart/lc-api/internal/tcputils"
 )
@@ -86,3 +89,61 @@ func TestCheck_Err(t *testing.T) {
 	assert.NoError(t, hcErr)
 	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, hcReply.Status)
 }
+
+func TestCheck_RendererPool(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name           string
+		healthy        bool
+		service        string
+		expectedStatus grpc_health_v1.HealthCheckResponse_ServingStatus
+		expectedCode   codes.Code
+	}{
+		{
+			name:           "healthy_pool",
+			healthy:        true,
+			service:        servergrpchc.RendererPoolServicePrefix + renderer.DefaultPool,
+			expectedStatus: grpc_health_v1.HealthCheckResponse_SERVING,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:           "unhealthy_pool",
+			healthy:        false,
+			service:        servergrpchc.RendererPoolServicePrefix + renderer.DefaultPool,
+			expectedStatus: grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:         "unknown_pool",
+			healthy:      true,
+			service:      servergrpchc.RendererPoolServicePrefix + "heavy",
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "pool_without_prefix",
+			healthy:      true,
+			service:      renderer.DefaultPool,
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingHCEnvTimeoutSecs)
+			defer cancel()
+
+			testingHCEnv := newTestingHC(ctx, t, tc.healthy)
+
+			hcClient := grpc_health_v1.NewHealthClient(testingHCEnv.hcServerConn)
+
+			hcReply, hcErr := hcClient.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: tc.service})
+
+			assert.Equal(t, tc.expectedCode, status.Code(hcErr))
+			assert.Equal(t, tc.expectedStatus, hcReply.GetStatus())
+		})
+	}
+}
@@ -14,5 +14,19 @@
 // Produces:
 //   - application/json
 //
+// Security:
+//   - api_key:
+//   - bearer:
+//
+// SecurityDefinitions:
+//   api_key:
+//     type: apiKey
+//     name: X-Api-Key
+//     in: header
+//   bearer:
+//     type: apiKey
+//     name: Authorization
+//     in: header
+//
 // swagger:meta
 package serverhttp
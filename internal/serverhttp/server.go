@@ -21,6 +21,9 @@ const (
 
 	// GroupCharts represents routing group pattern of the charts API.
 	GroupCharts = "/charts"
+
+	// GroupChartsBatch represents routing group pattern of the batch charts API.
+	GroupChartsBatch = "/charts:batch"
 )
 
 const name = "HTTP API"
@@ -33,15 +36,22 @@ type Server struct {
 }
 
 // NewServer configures a new Server.
+// It serves HTTPS if backend provides server certificates.
 func NewServer(log *zerolog.Logger, bCon backend.ConnSupervisor, httpCfg config.HTTPConfig, pRec metric.PromRecorder) *Server {
+	httpServer := &http.Server{
+		Addr:         httpCfg.Address,
+		ReadTimeout:  time.Duration(httpCfg.ReadTimeoutSeconds) * time.Second,
+		WriteTimeout: time.Duration(httpCfg.WriteTimeoutSeconds) * time.Second,
+		IdleTimeout:  time.Duration(httpCfg.IdleTimeoutSeconds) * time.Second,
+		Handler:      routes(log, bCon, pRec),
+	}
+
+	if serverCerts := bCon.ServerCertificates(); serverCerts != nil {
+		httpServer.TLSConfig = serverCerts.ServerConfig()
+	}
+
 	return &Server{
-		httpServer: &http.Server{
-			Addr:         httpCfg.Address,
-			ReadTimeout:  time.Duration(httpCfg.ReadTimeoutSeconds) * time.Second,
-			WriteTimeout: time.Duration(httpCfg.WriteTimeoutSeconds) * time.Second,
-			IdleTimeout:  time.Duration(httpCfg.IdleTimeoutSeconds) * time.Second,
-			Handler:      routes(log, bCon, pRec),
-		},
+		httpServer:      httpServer,
 		log:             log,
 		shutdownTimeout: time.Duration(httpCfg.ShutdownTimeoutSeconds) * time.Second,
 	}
@@ -55,7 +65,7 @@ func (s *Server) Serve(ctx context.Context) error {
 	go func() {
 		defer close(serveErr)
 
-		if err := s.httpServer.ListenAndServe(); err != nil {
+		if err := s.listenAndServe(); err != nil {
 			serveErr <- fmt.Errorf("unable to start lc-api HTTP server: %w", err)
 		}
 	}()
@@ -83,6 +93,17 @@ func (s *Server) Serve(ctx context.Context) error {
 	}
 }
 
+// listenAndServe serves HTTPS if TLS is configured, certificates are provided by the TLS configuration.
+func (s *Server) listenAndServe() error {
+	if s.httpServer.TLSConfig != nil {
+		// nolint: wrapcheck
+		return s.httpServer.ListenAndServeTLS("", "")
+	}
+
+	// nolint: wrapcheck
+	return s.httpServer.ListenAndServe()
+}
+
 // Address returns server address.
 func (s *Server) Address() string {
 	return s.httpServer.Addr
@@ -98,6 +119,7 @@ func routes(log *zerolog.Logger, bCon backend.ConnSupervisor, pRec metric.PromRe
 
 	r.Route(GroupV0, func(r chi.Router) {
 		r.Mount(GroupCharts, chart.Routes(log, bCon, pRec))
+		r.Mount(GroupChartsBatch, chart.BatchRoutes(log, bCon, pRec))
 	})
 
 	return r
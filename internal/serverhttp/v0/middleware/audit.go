@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/limpidchart/lc-api/internal/audit"
+)
+
+// NewAuditEntry returns an audit log entry of the operation with the request ID, caller identity,
+// tenant and client IP of the request.
+// Identity is empty if authentication is disabled or the request uses a signed URL.
+func NewAuditEntry(r *http.Request, operation string) audit.Entry {
+	entry := audit.Entry{
+		Operation: operation,
+		Transport: audit.TransportHTTP,
+		RequestID: GetRequestID(r.Context()),
+		Tenant:    GetTenant(r.Context()),
+		ClientIP:  peerIP(r),
+	}
+
+	if identity := GetIdentity(r.Context()); identity != nil {
+		entry.Identity = identity.Subject
+	}
+
+	return entry
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/rs/zerolog"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/view"
+)
+
+const (
+	// APIKeyHeader represents a header that contains API key.
+	APIKeyHeader = "X-Api-Key"
+
+	// AuthorizationHeader represents a header that contains bearer token.
+	AuthorizationHeader = "Authorization"
+)
+
+// Authenticate checks request credentials and saves the caller identity into the context.
+// Requests with verified signed URL claims don't need credentials.
+// It returns http.StatusUnauthorized if credentials are missing or not valid.
+func Authenticate(log *zerolog.Logger, bCon backend.ConnSupervisor) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authenticator := bCon.Authenticator()
+			if !authenticator.Enabled() || GetSignedURLClaims(r.Context()) != nil {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			identity, err := authenticator.Authenticate(credentialsFromRequest(r))
+			if err != nil {
+				log.Warn().
+					Str(RequestIDLogKey, GetRequestID(r.Context())).
+					Str(ipKey, peerIP(r)).
+					Err(err).
+					Msg("Unable to authenticate request")
+
+				MarshalJSON(w, http.StatusUnauthorized, view.NewError(fmt.Sprintf("%s: %s", http.StatusText(http.StatusUnauthorized), err)))
+
+				return
+			}
+
+			observeIdentity(r.Context(), identity)
+
+			ctx := context.WithValue(r.Context(), ctxIdentity, identity)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetIdentity returns the caller identity or nil if authentication is disabled.
+func GetIdentity(ctx context.Context) *auth.Identity {
+	if identity, ok := ctx.Value(ctxIdentity).(*auth.Identity); ok {
+		return identity
+	}
+
+	return nil
+}
+
+func credentialsFromRequest(r *http.Request) auth.Credentials {
+	return auth.Credentials{
+		APIKey:      r.Header.Get(APIKeyHeader),
+		BearerToken: auth.BearerToken(r.Header.Get(AuthorizationHeader)),
+	}
+}
@@ -0,0 +1,175 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/middleware"
+	"github.com/limpidchart/lc-api/internal/testutils"
+)
+
+type authBackend struct {
+	*backend.EmptyBackend
+	authenticator *auth.Authenticator
+}
+
+func (b *authBackend) Authenticator() *auth.Authenticator {
+	return b.authenticator
+}
+
+func TestAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	jwtKeys := testutils.NewJWTKeys(t)
+
+	authenticator, err := auth.NewAuthenticator(config.AuthConfig{
+		APIKeysPath: testutils.APIKeysFile(t, map[string]string{"reporting": "reporting-key"}),
+		JWKSPath:    jwtKeys.JWKSFile(t),
+	})
+	if err != nil {
+		t.Fatalf("unable to configure authenticator: %s", err)
+	}
+
+	logger := zerolog.New(os.Stdout)
+	router := chi.NewRouter()
+	router.Use(middleware.Authenticate(&logger, &authBackend{backend.NewEmptyBackend(true), authenticator}))
+	router.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(middleware.GetIdentity(r.Context()).Subject))
+	})
+
+	validToken := jwtKeys.Token(t, auth.AlgES256, map[string]interface{}{"sub": "billing", "exp": time.Now().Add(time.Minute).Unix()})
+	expiredToken := jwtKeys.Token(t, auth.AlgES256, map[string]interface{}{"sub": "billing", "exp": time.Now().Add(-time.Hour).Unix()})
+
+	tt := []struct {
+		name          string
+		apiKey        string
+		authorization string
+		expectedCode  int
+		expectedBody  string
+	}{
+		{
+			"valid_api_key",
+			"reporting-key",
+			"",
+			http.StatusOK,
+			"reporting",
+		},
+		{
+			"bad_api_key",
+			"billing-key",
+			"",
+			http.StatusUnauthorized,
+			`{"error":{"message":"Unauthorized: API key is not valid"}}` + "\n",
+		},
+		{
+			"valid_bearer_token",
+			"",
+			"Bearer " + validToken,
+			http.StatusOK,
+			"billing",
+		},
+		{
+			"lowercase_bearer_scheme",
+			"",
+			"bearer " + validToken,
+			http.StatusOK,
+			"billing",
+		},
+		{
+			"expired_bearer_token",
+			"",
+			"Bearer " + expiredToken,
+			http.StatusUnauthorized,
+			`{"error":{"message":"Unauthorized: bearer token is expired"}}` + "\n",
+		},
+		{
+			"basic_auth",
+			"",
+			"Basic cmVwb3J0aW5nOnNlY3JldA==",
+			http.StatusUnauthorized,
+			`{"error":{"message":"Unauthorized: credentials are not provided"}}` + "\n",
+		},
+		{
+			"no_credentials",
+			"",
+			"",
+			http.StatusUnauthorized,
+			`{"error":{"message":"Unauthorized: credentials are not provided"}}` + "\n",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+
+			r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+			if err != nil {
+				t.Fatalf("unable to make a test request: %s", err)
+			}
+
+			if tc.apiKey != "" {
+				r.Header.Set(middleware.APIKeyHeader, tc.apiKey)
+			}
+
+			if tc.authorization != "" {
+				r.Header.Set(middleware.AuthorizationHeader, tc.authorization)
+			}
+
+			router.ServeHTTP(w, r)
+
+			resp := w.Result()
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unable to read response body: %s", err)
+			}
+
+			resp.Body.Close()
+
+			assert.Equal(t, tc.expectedCode, resp.StatusCode)
+			assert.Equal(t, tc.expectedBody, string(body))
+		})
+	}
+}
+
+func TestAuthenticate_Disabled(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.New(os.Stdout)
+	router := chi.NewRouter()
+	router.Use(middleware.Authenticate(&logger, backend.NewEmptyBackend(true)))
+	router.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, middleware.GetIdentity(r.Context()))
+		w.WriteHeader(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+
+	r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+	if err != nil {
+		t.Fatalf("unable to make a test request: %s", err)
+	}
+
+	router.ServeHTTP(w, r)
+
+	resp := w.Result()
+
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
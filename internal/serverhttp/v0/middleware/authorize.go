@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/rs/zerolog"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/view"
+)
+
+// RequireScope checks that the caller identity is granted the scope.
+// It returns http.StatusForbidden with the machine-readable reason if the scope is missing.
+func RequireScope(log *zerolog.Logger, scope string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity := GetIdentity(r.Context())
+
+			if err := auth.Authorize(identity, scope); err != nil {
+				log.Warn().
+					Str(RequestIDLogKey, GetRequestID(r.Context())).
+					Str(IdentityLogKey, identity.Subject).
+					Str(scopeKey, scope).
+					Msg("Request is forbidden")
+
+				MarshalJSON(w, http.StatusForbidden, view.NewForbiddenError(auth.ReasonMissingScope, scope, fmt.Sprintf("%s: %s", http.StatusText(http.StatusForbidden), err)))
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/middleware"
+	"github.com/limpidchart/lc-api/internal/testutils"
+)
+
+func TestRequireScope(t *testing.T) {
+	t.Parallel()
+
+	jwtKeys := testutils.NewJWTKeys(t)
+
+	authenticator, err := auth.NewAuthenticator(config.AuthConfig{JWKSPath: jwtKeys.JWKSFile(t)})
+	if err != nil {
+		t.Fatalf("unable to configure authenticator: %s", err)
+	}
+
+	logger := zerolog.New(os.Stdout)
+	router := chi.NewRouter()
+	router.Use(middleware.Authenticate(&logger, &authBackend{backend.NewEmptyBackend(true), authenticator}))
+	router.
+		With(middleware.RequireScope(&logger, auth.ScopeChartsCreate)).
+		Post("/", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+
+	tt := []struct {
+		name         string
+		scope        string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			"granted",
+			"charts:read charts:create",
+			http.StatusCreated,
+			"",
+		},
+		{
+			"missing",
+			"charts:read charts:list",
+			http.StatusForbidden,
+			`{"error":{"reason":"MISSING_SCOPE","scope":"charts:create","message":"Forbidden: caller doesn't have the required scope: charts:create"}}` + "\n",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			token := jwtKeys.Token(t, auth.AlgES256, map[string]interface{}{
+				"sub":   "reporting",
+				"exp":   time.Now().Add(time.Minute).Unix(),
+				"scope": tc.scope,
+			})
+
+			w := httptest.NewRecorder()
+
+			r, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/", nil)
+			if err != nil {
+				t.Fatalf("unable to make a test request: %s", err)
+			}
+
+			r.Header.Set(middleware.AuthorizationHeader, "Bearer "+token)
+
+			router.ServeHTTP(w, r)
+
+			resp := w.Result()
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unable to read response body: %s", err)
+			}
+
+			resp.Body.Close()
+
+			assert.Equal(t, tc.expectedCode, resp.StatusCode)
+			assert.Equal(t, tc.expectedBody, string(body))
+		})
+	}
+}
+
+func TestRequireScope_AuthDisabled(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.New(os.Stdout)
+	router := chi.NewRouter()
+	router.Use(middleware.Authenticate(&logger, backend.NewEmptyBackend(true)))
+	router.
+		With(middleware.RequireScope(&logger, auth.ScopeChartsCreate)).
+		Post("/", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+
+	w := httptest.NewRecorder()
+
+	r, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/", nil)
+	if err != nil {
+		t.Fatalf("unable to make a test request: %s", err)
+	}
+
+	router.ServeHTTP(w, r)
+
+	resp := w.Result()
+
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
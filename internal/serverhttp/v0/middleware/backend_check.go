@@ -13,7 +13,7 @@ import (
 func BackendCheck(log *zerolog.Logger, bCon backend.ConnSupervisor) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if !bCon.IsHealthy() {
+			if !bCon.IsHealthy().Healthy() {
 				log.Error().Msg("Backend connections are not healthy")
 
 				MarshalJSON(w, http.StatusServiceUnavailable, view.NewError(http.StatusText(http.StatusServiceUnavailable)))
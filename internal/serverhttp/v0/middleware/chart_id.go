@@ -29,6 +29,8 @@ func RequireChartID(log *zerolog.Logger) func(next http.Handler) http.Handler {
 				return
 			}
 
+			observeChartID(r.Context(), chartID.String())
+
 			ctx := context.WithValue(r.Context(), ctxChartID, chartID.String())
 
 			next.ServeHTTP(w, r.WithContext(ctx))
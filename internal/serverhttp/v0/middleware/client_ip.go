@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/rs/zerolog"
+
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/view"
+)
+
+// SetClientIP resolves the client IP from the peer address and from the forwarded headers of trusted proxies
+// and saves it into the context.
+// It returns http.StatusForbidden if the client IP is denied.
+func SetClientIP(log *zerolog.Logger, bCon backend.ConnSupervisor) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientIPs := bCon.ClientIPs()
+			clientIP := clientIPs.Resolve(r.RemoteAddr, r.Header.Get(xRealIPHeader), r.Header.Get(xForwardedForHeader))
+
+			if err := clientIPs.Check(clientIP); err != nil {
+				log.Warn().
+					Str(RequestIDLogKey, GetRequestID(r.Context())).
+					Str(ipKey, clientIP).
+					Err(err).
+					Msg("Request is denied")
+
+				MarshalJSON(w, http.StatusForbidden, view.NewError(fmt.Sprintf("%s: %s", http.StatusText(http.StatusForbidden), err)))
+
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), ctxClientIP, clientIP)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetClientIP returns the client IP resolved by SetClientIP or an empty string if it's not resolved.
+func GetClientIP(ctx context.Context) string {
+	if clientIP, ok := ctx.Value(ctxClientIP).(string); ok {
+		return clientIP
+	}
+
+	return ""
+}
@@ -0,0 +1,118 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/clientip"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/middleware"
+)
+
+type clientIPBackend struct {
+	*backend.EmptyBackend
+	clientIPs *clientip.Resolver
+}
+
+func (b *clientIPBackend) ClientIPs() *clientip.Resolver {
+	return b.clientIPs
+}
+
+func TestSetClientIP(t *testing.T) {
+	t.Parallel()
+
+	clientIPs, err := clientip.NewResolver(config.ClientIPConfig{
+		TrustedProxies: "10.0.0.0/8",
+		Deny:           "198.51.100.0/24",
+	})
+	if err != nil {
+		t.Fatalf("unable to configure client IP resolver: %s", err)
+	}
+
+	logger := zerolog.New(os.Stdout)
+	router := chi.NewRouter()
+	router.Use(middleware.SetClientIP(&logger, &clientIPBackend{backend.NewEmptyBackend(true), clientIPs}))
+	router.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(middleware.GetClientIP(r.Context())))
+	})
+
+	tt := []struct {
+		name          string
+		remoteAddr    string
+		xForwardedFor string
+		expectedCode  int
+		expectedBody  string
+	}{
+		{
+			"direct_client",
+			"192.0.2.1:50000",
+			"",
+			http.StatusOK,
+			"192.0.2.1",
+		},
+		{
+			"spoofed_header",
+			"192.0.2.1:50000",
+			"203.0.113.1",
+			http.StatusOK,
+			"192.0.2.1",
+		},
+		{
+			"trusted_proxy",
+			"10.0.0.1:50000",
+			"203.0.113.1",
+			http.StatusOK,
+			"203.0.113.1",
+		},
+		{
+			"denied_client",
+			"10.0.0.1:50000",
+			"198.51.100.1",
+			http.StatusForbidden,
+			`{"error":{"message":"Forbidden: client IP is not allowed: 198.51.100.1 is denied"}}` + "\n",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+
+			r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+			if err != nil {
+				t.Fatalf("unable to make a test request: %s", err)
+			}
+
+			r.RemoteAddr = tc.remoteAddr
+
+			if tc.xForwardedFor != "" {
+				r.Header.Set("X-Forwarded-For", tc.xForwardedFor)
+			}
+
+			router.ServeHTTP(w, r)
+
+			resp := w.Result()
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unable to read response body: %s", err)
+			}
+
+			resp.Body.Close()
+
+			assert.Equal(t, tc.expectedCode, resp.StatusCode)
+			assert.Equal(t, tc.expectedBody, string(body))
+		})
+	}
+}
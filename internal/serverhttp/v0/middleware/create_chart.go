@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/rs/zerolog"
 
@@ -38,6 +39,15 @@ func RequireCreateChartParams(log *zerolog.Logger) func(next http.Handler) http.
 				return
 			}
 
+			async, err := asyncFromQuery(r)
+			if err != nil {
+				MarshalJSON(w, http.StatusBadRequest, view.NewError(fmt.Sprintf("Unable to use the provided create chart parameters: %s", err)))
+
+				return
+			}
+
+			createChartRequest.Async = async
+
 			ctx := context.WithValue(r.Context(), ctxCreateChartRequest, createChartRequest)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
@@ -45,6 +55,59 @@ func RequireCreateChartParams(log *zerolog.Logger) func(next http.Handler) http.
 	}
 }
 
+// RequireCreateChartsParams checks if provided create charts parameters body can be decoded and stores it in the context.
+// Every chart is converted separately so a bad chart doesn't fail the whole batch.
+func RequireCreateChartsParams(log *zerolog.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			createOptsJSON := []*view.CreateChartRequest{}
+
+			err := json.NewDecoder(r.Body).Decode(&createOptsJSON)
+			if err != nil {
+				msg := fmt.Sprintf("Unable to decode create charts JSON: %s", err)
+				log := log.With().Str(RequestIDLogKey, GetRequestID(r.Context())).Logger()
+
+				log.Warn().Msg(msg)
+
+				MarshalJSON(w, http.StatusBadRequest, view.NewError(msg))
+
+				return
+			}
+
+			async, err := asyncFromQuery(r)
+			if err != nil {
+				MarshalJSON(w, http.StatusBadRequest, view.NewError(fmt.Sprintf("Unable to use the provided create charts parameters: %s", err)))
+
+				return
+			}
+
+			for _, createChartJSON := range createOptsJSON {
+				if createChartJSON != nil {
+					createChartJSON.Async = async
+				}
+			}
+
+			ctx := context.WithValue(r.Context(), ctxCreateChartsRequest, createOptsJSON)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func asyncFromQuery(r *http.Request) (bool, error) {
+	rawAsync := r.URL.Query().Get(view.ParamAsync)
+	if rawAsync == "" {
+		return false, nil
+	}
+
+	async, err := strconv.ParseBool(rawAsync)
+	if err != nil {
+		return false, fmt.Errorf("%s value is bad: %w", view.ParamAsync, err)
+	}
+
+	return async, nil
+}
+
 // GetCreateChartRequest retrieves create chart request from context.
 func GetCreateChartRequest(ctx context.Context) *render.CreateChartRequest {
 	v, ok := ctx.Value(ctxCreateChartRequest).(*render.CreateChartRequest)
@@ -54,3 +117,13 @@ func GetCreateChartRequest(ctx context.Context) *render.CreateChartRequest {
 
 	return v
 }
+
+// GetCreateChartsRequest retrieves create charts request from context.
+func GetCreateChartsRequest(ctx context.Context) []*view.CreateChartRequest {
+	v, ok := ctx.Value(ctxCreateChartsRequest).([]*view.CreateChartRequest)
+	if !ok {
+		return nil
+	}
+
+	return v
+}
@@ -6,6 +6,12 @@ const (
 
 	// ChartIDLogKey represents a chart ID key logger field.
 	ChartIDLogKey = "chart_id"
+
+	// IdentityLogKey represents an authenticated caller identity key logger field.
+	IdentityLogKey = "identity"
+
+	// TenantLogKey represents a request tenant key logger field.
+	TenantLogKey = "tenant"
 )
 
 // Context keys.
@@ -15,4 +21,11 @@ const (
 	ctxRequestID ctxKey = iota
 	ctxChartID
 	ctxCreateChartRequest
+	ctxCreateChartsRequest
+	ctxListChartsRequest
+	ctxIdentity
+	ctxTenant
+	ctxSignedURLClaims
+	ctxClientIP
+	ctxObservedRequest
 )
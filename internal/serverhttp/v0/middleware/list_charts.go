@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/view"
+)
+
+// ErrUnknownChartStatus contains error message about unknown chart status.
+var ErrUnknownChartStatus = errors.New("unknown chart status")
+
+// RequireListChartsParams checks if provided list charts query parameters can be used and stores them in the context.
+func RequireListChartsParams(log *zerolog.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			listChartsRequest, err := listChartsRequestFromQuery(r.URL.Query())
+			if err != nil {
+				msg := fmt.Sprintf("Unable to use the provided list charts parameters: %s", err)
+				log := log.With().Str(RequestIDLogKey, GetRequestID(r.Context())).Logger()
+
+				log.Warn().Msg(msg)
+
+				MarshalJSON(w, http.StatusBadRequest, view.NewError(msg))
+
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), ctxListChartsRequest, listChartsRequest)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetListChartsRequest retrieves list charts request from context.
+func GetListChartsRequest(ctx context.Context) *render.ListChartsRequest {
+	v, ok := ctx.Value(ctxListChartsRequest).(*render.ListChartsRequest)
+	if !ok {
+		return nil
+	}
+
+	return v
+}
+
+func listChartsRequestFromQuery(query url.Values) (*render.ListChartsRequest, error) {
+	req := &render.ListChartsRequest{
+		PageToken:     query.Get(view.ParamPageToken),
+		TitleContains: query.Get(view.ParamTitle),
+	}
+
+	if rawPageSize := query.Get(view.ParamPageSize); rawPageSize != "" {
+		pageSize, err := strconv.ParseInt(rawPageSize, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s value is bad: %w", view.ParamPageSize, err)
+		}
+
+		req.PageSize = int32(pageSize)
+	}
+
+	createdAfter, err := timestampFromQuery(query, view.ParamCreatedAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	createdBefore, err := timestampFromQuery(query, view.ParamCreatedBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	req.CreatedAfter = createdAfter
+	req.CreatedBefore = createdBefore
+
+	if rawChartStatus := query.Get(view.ParamChartStatus); rawChartStatus != "" {
+		chartStatus, err := chartStatusFromQuery(rawChartStatus)
+		if err != nil {
+			return nil, fmt.Errorf("%s value is bad: %w", view.ParamChartStatus, err)
+		}
+
+		req.ChartStatus = chartStatus
+	}
+
+	return req, nil
+}
+
+func timestampFromQuery(query url.Values, param string) (*timestamppb.Timestamp, error) {
+	raw := query.Get(param)
+	if raw == "" {
+		return nil, nil
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s value is bad: %w", param, err)
+	}
+
+	return timestamppb.New(ts), nil
+}
+
+func chartStatusFromQuery(raw string) (render.ChartStatus, error) {
+	switch view.ChartStatus(raw) {
+	case view.ChartStatusCreated:
+		return render.ChartStatus_CREATED, nil
+	case view.ChartStatusError:
+		return render.ChartStatus_ERROR, nil
+	case view.ChartStatusDeleted:
+		return render.ChartStatus_DELETED, nil
+	case view.ChartStatusPending:
+		return render.ChartStatus_PENDING, nil
+	default:
+		return render.ChartStatus_UNSPECIFIED_STATUS, fmt.Errorf("%w: %s", ErrUnknownChartStatus, raw)
+	}
+}
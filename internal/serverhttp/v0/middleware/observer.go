@@ -1,14 +1,16 @@
 package middleware
 
 import (
+	"context"
+	"net"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/rs/zerolog"
 
+	"github.com/limpidchart/lc-api/internal/auth"
 	"github.com/limpidchart/lc-api/internal/metric"
 )
 
@@ -29,6 +31,9 @@ const (
 	pathKey         = "path"
 	bytesWrittenKey = "resp_bytes_written"
 	durationKey     = "duration"
+	scopeKey        = "scope"
+	operationKey    = "operation"
+	rateLimitKeyKey = "rate_limit_key"
 )
 
 const (
@@ -36,6 +41,13 @@ const (
 	warnCodesStart = 400
 )
 
+// observedRequest contains request details that are resolved by the middlewares called after RequestObserver.
+type observedRequest struct {
+	identity *auth.Identity
+	tenant   string
+	chartID  string
+}
+
 // RequestObserver handles observability (metrics and logging) for every request.
 func RequestObserver(log *zerolog.Logger, pRec metric.PromRecorder) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -43,8 +55,9 @@ func RequestObserver(log *zerolog.Logger, pRec metric.PromRecorder) func(next ht
 			ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
 
 			startTime := time.Now().UTC()
+			observed := &observedRequest{}
 
-			next.ServeHTTP(ww, r)
+			next.ServeHTTP(ww, r.WithContext(context.WithValue(r.Context(), ctxObservedRequest, observed)))
 
 			defer func() {
 				statusCode := ww.Status()
@@ -55,13 +68,13 @@ func RequestObserver(log *zerolog.Logger, pRec metric.PromRecorder) func(next ht
 
 				switch {
 				case statusCode >= errCodesStart:
-					logEvent := loggerFields(log.Error(), r, statusCode, bytesWritten, startTime, duration)
+					logEvent := loggerFields(log.Error(), r, observed, statusCode, bytesWritten, startTime, duration)
 					logEvent.Msg("")
 				case statusCode >= warnCodesStart:
-					logEvent := loggerFields(log.Warn(), r, statusCode, bytesWritten, startTime, duration)
+					logEvent := loggerFields(log.Warn(), r, observed, statusCode, bytesWritten, startTime, duration)
 					logEvent.Msg("")
 				default:
-					logEvent := loggerFields(log.Info(), r, statusCode, bytesWritten, startTime, duration)
+					logEvent := loggerFields(log.Info(), r, observed, statusCode, bytesWritten, startTime, duration)
 					logEvent.Msg("")
 				}
 			}()
@@ -69,7 +82,7 @@ func RequestObserver(log *zerolog.Logger, pRec metric.PromRecorder) func(next ht
 	}
 }
 
-func loggerFields(logEvent *zerolog.Event, r *http.Request, code, bytesWritten int, startTime time.Time, duration time.Duration) *zerolog.Event {
+func loggerFields(logEvent *zerolog.Event, r *http.Request, observed *observedRequest, code, bytesWritten int, startTime time.Time, duration time.Duration) *zerolog.Event {
 	event := logEvent.
 		Time(zerolog.TimestampFieldName, startTime).
 		Str(protocolKey, metric.ProtocolHTTP).
@@ -83,34 +96,52 @@ func loggerFields(logEvent *zerolog.Event, r *http.Request, code, bytesWritten i
 		Int(bytesWrittenKey, bytesWritten).
 		Dur(durationKey, duration)
 
-	chartID := GetChartID(r.Context())
-	if chartID == "" {
+	if observed.identity != nil {
+		event = event.Str(IdentityLogKey, observed.identity.Subject)
+	}
+
+	if observed.tenant != "" {
+		event = event.Str(TenantLogKey, observed.tenant)
+	}
+
+	if observed.chartID == "" {
 		return event
 	}
 
-	return event.Str(ChartIDLogKey, GetChartID(r.Context()))
+	return event.Str(ChartIDLogKey, observed.chartID)
 }
 
-func peerIP(r *http.Request) string {
-	realIP := r.Header.Get(xRealIPHeader)
-	forwardedFor := r.Header.Get(xForwardedForHeader)
-
-	if realIP == "" && forwardedFor == "" {
-		return ipFromRemoteAddr(r.RemoteAddr)
+// observeIdentity saves the caller identity for the RequestObserver log fields.
+func observeIdentity(ctx context.Context, identity *auth.Identity) {
+	if observed, ok := ctx.Value(ctxObservedRequest).(*observedRequest); ok {
+		observed.identity = identity
 	}
+}
 
-	if forwardedFor == "" {
-		return realIP
+// observeTenant saves the request tenant for the RequestObserver log fields.
+func observeTenant(ctx context.Context, tenant string) {
+	if observed, ok := ctx.Value(ctxObservedRequest).(*observedRequest); ok {
+		observed.tenant = tenant
 	}
+}
 
-	return strings.Split(forwardedFor, ",")[0]
+// observeChartID saves the chart ID for the RequestObserver log fields.
+func observeChartID(ctx context.Context, chartID string) {
+	if observed, ok := ctx.Value(ctxObservedRequest).(*observedRequest); ok {
+		observed.chartID = chartID
+	}
 }
 
-func ipFromRemoteAddr(s string) string {
-	idx := strings.LastIndex(s, ":")
-	if idx == -1 {
-		return s
+// peerIP returns the client IP resolved by SetClientIP or the peer address without port.
+func peerIP(r *http.Request) string {
+	if clientIP := GetClientIP(r.Context()); clientIP != "" {
+		return clientIP
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
 	}
 
-	return s[:idx]
+	return host
 }
@@ -0,0 +1,94 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/middleware"
+	"github.com/limpidchart/lc-api/internal/testutils"
+)
+
+func TestRequestObserver_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	authenticator, err := auth.NewAuthenticator(config.AuthConfig{
+		APIKeysPath: testutils.APIKeysFile(t, map[string]string{"reporting": "reporting-key"}),
+	})
+	if err != nil {
+		t.Fatalf("unable to configure authenticator: %s", err)
+	}
+
+	tt := []struct {
+		name             string
+		apiKey           string
+		expectedCode     int
+		expectedIdentity string
+	}{
+		{
+			"authenticated",
+			"reporting-key",
+			http.StatusOK,
+			"reporting",
+		},
+		{
+			"rejected",
+			"billing-key",
+			http.StatusUnauthorized,
+			"",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logs := &bytes.Buffer{}
+			logger := zerolog.New(logs)
+			router := chi.NewRouter()
+			router.Use(
+				middleware.RequestObserver(&logger, metric.NewEmptyRecorder()),
+				middleware.Authenticate(&logger, &authBackend{backend.NewEmptyBackend(true), authenticator}),
+			)
+			router.Get("/", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+
+			r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+			if err != nil {
+				t.Fatalf("unable to make a test request: %s", err)
+			}
+
+			r.Header.Set(middleware.APIKeyHeader, tc.apiKey)
+
+			router.ServeHTTP(w, r)
+
+			logged := struct {
+				Code     int    `json:"code"`
+				Identity string `json:"identity"`
+			}{}
+			lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+			if err := json.Unmarshal([]byte(lines[len(lines)-1]), &logged); err != nil {
+				t.Fatalf("unable to unmarshal observed request log: %s", err)
+			}
+
+			assert.Equal(t, tc.expectedCode, logged.Code)
+			assert.Equal(t, tc.expectedIdentity, logged.Identity)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rs/zerolog"
+
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/ratelimit"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/view"
+)
+
+// RetryAfterHeader contains amount of seconds after which a rate limited request can be retried.
+const RetryAfterHeader = "Retry-After"
+
+// RateLimit takes a token from the caller bucket of the operation.
+// It returns http.StatusTooManyRequests with Retry-After header if the bucket is empty.
+func RateLimit(log *zerolog.Logger, bCon backend.ConnSupervisor, pRec metric.PromRecorder, operation string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limiter := bCon.RateLimiter()
+			if !limiter.Enabled() {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			key := limiter.Key(GetIdentity(r.Context()), GetTenant(r.Context()), peerIP(r))
+
+			if ok, retryAfter := limiter.Allow(operation, key); !ok {
+				retryAfterSecs := ratelimit.RetryAfterSeconds(retryAfter)
+
+				log.Warn().
+					Str(RequestIDLogKey, GetRequestID(r.Context())).
+					Str(operationKey, operation).
+					Str(rateLimitKeyKey, key.Kind).
+					Msg("Request is rate limited")
+
+				pRec.RateLimitedRequests().WithLabelValues(metric.ProtocolHTTP, operation, key.Kind).Inc()
+
+				w.Header().Set(RetryAfterHeader, strconv.Itoa(retryAfterSecs))
+				MarshalJSON(w, http.StatusTooManyRequests, view.NewError(fmt.Sprintf("Too many requests, retry in %d seconds", retryAfterSecs)))
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
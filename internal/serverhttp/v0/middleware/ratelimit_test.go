@@ -0,0 +1,94 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/ratelimit"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/middleware"
+)
+
+type rateLimitBackend struct {
+	*backend.EmptyBackend
+	rateLimiter *ratelimit.Limiter
+}
+
+func (b *rateLimitBackend) RateLimiter() *ratelimit.Limiter {
+	return b.rateLimiter
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	rateLimiter, err := ratelimit.NewLimiter(config.RateLimitConfig{
+		Key:        config.RateLimitKeyIP,
+		PerMinute:  2,
+		Burst:      2,
+		Operations: "",
+	})
+	if err != nil {
+		t.Fatalf("unable to configure rate limiter: %s", err)
+	}
+
+	pRec := metric.NewEmptyRecorder()
+	logger := zerolog.New(os.Stdout)
+	router := chi.NewRouter()
+	router.Use(middleware.RateLimit(&logger, &rateLimitBackend{backend.NewEmptyBackend(true), rateLimiter}, pRec, ratelimit.OperationListCharts))
+	router.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	do := func(remoteAddr string) *http.Response {
+		w := httptest.NewRecorder()
+
+		r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+		if err != nil {
+			t.Fatalf("unable to make a test request: %s", err)
+		}
+
+		r.RemoteAddr = remoteAddr
+
+		router.ServeHTTP(w, r)
+
+		return w.Result()
+	}
+
+	for i := 0; i < 2; i++ {
+		resp := do("10.0.0.1:50000")
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// Client IP doesn't depend on the client port.
+	resp := do("10.0.0.1:50001")
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unable to read response body: %s", err)
+	}
+
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "30", resp.Header.Get(middleware.RetryAfterHeader))
+	assert.Equal(t, `{"error":{"message":"Too many requests, retry in 30 seconds"}}`+"\n", string(body))
+	assert.Equal(t, float64(1), testutil.ToFloat64(pRec.RateLimitedRequests().WithLabelValues(metric.ProtocolHTTP, ratelimit.OperationListCharts, config.RateLimitKeyIP)))
+
+	resp = do("10.0.0.2:50000")
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
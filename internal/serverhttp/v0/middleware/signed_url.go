@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/view"
+	"github.com/limpidchart/lc-api/internal/signedurl"
+)
+
+// VerifyImageSignature checks signature and expiration of the signed chart image URL and saves its claims into the context.
+// Requests without signature are passed as is, they should be authenticated by Authenticate.
+// It returns http.StatusForbidden if the signature is not valid or the URL is expired.
+func VerifyImageSignature(log *zerolog.Logger, bCon backend.ConnSupervisor) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			if query.Get(signedurl.ParamSignature) == "" {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			claims, err := bCon.SignedURLs().Verify(chi.URLParam(r, view.ParamChartID), query, time.Now())
+			if err != nil {
+				log.Warn().
+					Str(RequestIDLogKey, GetRequestID(r.Context())).
+					Str(ipKey, peerIP(r)).
+					Err(err).
+					Msg("Unable to verify signed URL")
+
+				MarshalJSON(w, http.StatusForbidden, view.NewError(fmt.Sprintf("%s: %s", http.StatusText(http.StatusForbidden), err)))
+
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), ctxSignedURLClaims, claims)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetSignedURLClaims returns claims of the signed URL or nil if the request isn't signed.
+func GetSignedURLClaims(ctx context.Context) *signedurl.Claims {
+	if claims, ok := ctx.Value(ctxSignedURLClaims).(*signedurl.Claims); ok {
+		return claims
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/rs/zerolog"
+
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/view"
+)
+
+// SetTenant resolves the request tenant from the caller identity or from the trusted header and saves it into the context.
+// Trusted header is honored only if the request peer is a trusted proxy.
+// Requests with verified signed URL claims use the signed tenant.
+// It returns http.StatusBadRequest if the trusted header contains a bad tenant or disagrees with the identity tenant.
+func SetTenant(log *zerolog.Logger, bCon backend.ConnSupervisor) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if claims := GetSignedURLClaims(r.Context()); claims != nil {
+				observeTenant(r.Context(), claims.Tenant)
+
+				ctx := context.WithValue(r.Context(), ctxTenant, claims.Tenant)
+
+				next.ServeHTTP(w, r.WithContext(ctx))
+
+				return
+			}
+
+			tenants := bCon.Tenants()
+
+			headerValue := ""
+			if header := tenants.TrustedHeader(); header != "" {
+				headerValue = r.Header.Get(header)
+			}
+
+			tenant, err := tenants.Resolve(GetIdentity(r.Context()), headerValue, bCon.ClientIPs().IsTrustedProxy(r.RemoteAddr))
+			if err != nil {
+				log.Warn().
+					Str(RequestIDLogKey, GetRequestID(r.Context())).
+					Err(err).
+					Msg("Unable to resolve request tenant")
+
+				MarshalJSON(w, http.StatusBadRequest, view.NewError(fmt.Sprintf("Unable to use the provided tenant: %s", err)))
+
+				return
+			}
+
+			observeTenant(r.Context(), tenant)
+
+			ctx := context.WithValue(r.Context(), ctxTenant, tenant)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetTenant returns the request tenant or an empty string for the default tenant.
+func GetTenant(ctx context.Context) string {
+	if tenant, ok := ctx.Value(ctxTenant).(string); ok {
+		return tenant
+	}
+
+	return ""
+}
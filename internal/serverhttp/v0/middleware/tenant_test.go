@@ -0,0 +1,128 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/clientip"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/middleware"
+	"github.com/limpidchart/lc-api/internal/tenant"
+)
+
+const testingProxyAddr = "10.0.0.1:1234"
+
+type tenantBackend struct {
+	*backend.EmptyBackend
+	tenants   *tenant.Registry
+	clientIPs *clientip.Resolver
+}
+
+func (b *tenantBackend) Tenants() *tenant.Registry {
+	return b.tenants
+}
+
+func (b *tenantBackend) ClientIPs() *clientip.Resolver {
+	return b.clientIPs
+}
+
+func TestSetTenant(t *testing.T) {
+	t.Parallel()
+
+	tenants, err := tenant.NewRegistry(config.TenantConfig{TrustedHeader: "X-Tenant-Id"})
+	if err != nil {
+		t.Fatalf("unable to configure tenant registry: %s", err)
+	}
+
+	clientIPs, err := clientip.NewResolver(config.ClientIPConfig{TrustedProxies: "10.0.0.0/8"})
+	if err != nil {
+		t.Fatalf("unable to configure client IP resolver: %s", err)
+	}
+
+	logger := zerolog.New(os.Stdout)
+	router := chi.NewRouter()
+	router.Use(middleware.SetTenant(&logger, &tenantBackend{backend.NewEmptyBackend(true), tenants, clientIPs}))
+	router.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(middleware.GetTenant(r.Context())))
+	})
+
+	tt := []struct {
+		name         string
+		tenant       string
+		remoteAddr   string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			"header_tenant",
+			"acme",
+			testingProxyAddr,
+			http.StatusOK,
+			"acme",
+		},
+		{
+			"header_tenant_untrusted_proxy",
+			"acme",
+			"192.0.2.1:1234",
+			http.StatusOK,
+			"",
+		},
+		{
+			"default_tenant",
+			"",
+			testingProxyAddr,
+			http.StatusOK,
+			"",
+		},
+		{
+			"bad_tenant",
+			"acme globex",
+			testingProxyAddr,
+			http.StatusBadRequest,
+			`{"error":{"message":"Unable to use the provided tenant: tenant should contain from 1 to 64 letters, digits, dots, dashes or underscores"}}` + "\n",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+
+			r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+			if err != nil {
+				t.Fatalf("unable to make a test request: %s", err)
+			}
+
+			r.RemoteAddr = tc.remoteAddr
+
+			if tc.tenant != "" {
+				r.Header.Set("X-Tenant-Id", tc.tenant)
+			}
+
+			router.ServeHTTP(w, r)
+
+			resp := w.Result()
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unable to read response body: %s", err)
+			}
+
+			resp.Body.Close()
+
+			assert.Equal(t, tc.expectedCode, resp.StatusCode)
+			assert.Equal(t, tc.expectedBody, string(body))
+		})
+	}
+}
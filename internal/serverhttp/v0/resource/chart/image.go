@@ -0,0 +1,208 @@
+package chart
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+const (
+	svgContentType = "image/svg+xml"
+
+	// imageMaxAge represents the longest duration the chart image can be cached by clients.
+	// It's limited since charts can be deleted, ETag allows cheap revalidation after that.
+	imageMaxAge = time.Hour
+
+	acceptHeader       = "Accept"
+	cacheControlHeader = "Cache-Control"
+	contentTypeHeader  = "Content-Type"
+	etagHeader         = "ETag"
+	ifNoneMatchHeader  = "If-None-Match"
+)
+
+const (
+	imageSizeSmall  = "small"
+	imageSizeMedium = "medium"
+	imageSizeLarge  = "large"
+)
+
+// imageSizes contains size variants of the chart image in ascending order.
+var imageSizes = []string{imageSizeSmall, imageSizeMedium, imageSizeLarge}
+
+// imageSizeWidths contains widths of the chart image size variants, heights are scaled proportionally.
+var imageSizeWidths = map[string]int{
+	imageSizeSmall:  320,
+	imageSizeMedium: 640,
+	imageSizeLarge:  1280,
+}
+
+var (
+	svgRootRegexp    = regexp.MustCompile(`<svg\b[^>]*>`)
+	svgWidthRegexp   = regexp.MustCompile(`\swidth="([0-9.]+)(px)?"`)
+	svgHeightRegexp  = regexp.MustCompile(`\sheight="([0-9.]+)(px)?"`)
+	svgViewBoxRegexp = regexp.MustCompile(`\sviewBox="`)
+)
+
+// Chart image.
+//
+// swagger:response chartImage
+type ChartImage struct {
+	// Raw SVG chart image.
+	//
+	// in: body
+	// swagger:file
+	Body []byte
+}
+
+// acceptsSVG reports if the client asked for the raw SVG chart image.
+func acceptsSVG(r *http.Request) bool {
+	for _, accepted := range strings.Split(r.Header.Get(acceptHeader), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && mediaType == svgContentType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateImageSize checks that the size is one of the image size variants or is empty for the original image.
+func validateImageSize(size string) error {
+	if _, ok := imageSizeWidths[size]; ok || size == "" {
+		return nil
+	}
+
+	return fmt.Errorf("unknown image size %q, it should be one of: %s", size, strings.Join(imageSizes, ", "))
+}
+
+// resizeChartImage scales the root element of the SVG chart image to the width of the size variant keeping its aspect ratio.
+// Original sizes are kept in the viewBox, so the image content is scaled too.
+func resizeChartImage(chartData []byte, size string) ([]byte, error) {
+	width, ok := imageSizeWidths[size]
+	if !ok {
+		return chartData, nil
+	}
+
+	rootLoc := svgRootRegexp.FindIndex(chartData)
+	if rootLoc == nil {
+		return nil, errors.New("image doesn't have SVG root element")
+	}
+
+	root := string(chartData[rootLoc[0]:rootLoc[1]])
+
+	origWidth, origHeight, err := svgSizes(root)
+	if err != nil {
+		return nil, err
+	}
+
+	height := origHeight * float64(width) / origWidth
+
+	resized := svgWidthRegexp.ReplaceAllLiteralString(root, fmt.Sprintf(` width="%d"`, width))
+	resized = svgHeightRegexp.ReplaceAllLiteralString(resized, fmt.Sprintf(` height="%s"`, formatSVGLength(height)))
+
+	if !svgViewBoxRegexp.MatchString(resized) {
+		closing := ">"
+		if strings.HasSuffix(resized, "/>") {
+			closing = "/>"
+		}
+
+		resized = fmt.Sprintf(`%s viewBox="0 0 %s %s"%s`, strings.TrimSuffix(resized, closing), formatSVGLength(origWidth), formatSVGLength(origHeight), closing)
+	}
+
+	res := make([]byte, 0, len(chartData)+len(resized)-len(root))
+	res = append(res, chartData[:rootLoc[0]]...)
+	res = append(res, resized...)
+	res = append(res, chartData[rootLoc[1]:]...)
+
+	return res, nil
+}
+
+func svgSizes(root string) (float64, float64, error) {
+	widthMatch := svgWidthRegexp.FindStringSubmatch(root)
+	heightMatch := svgHeightRegexp.FindStringSubmatch(root)
+
+	if widthMatch == nil || heightMatch == nil {
+		return 0, 0, errors.New("SVG root element doesn't have width and height")
+	}
+
+	width, err := strconv.ParseFloat(widthMatch[1], 64)
+	if err != nil || width <= 0 {
+		return 0, 0, fmt.Errorf("unable to use SVG width %q", widthMatch[1])
+	}
+
+	height, err := strconv.ParseFloat(heightMatch[1], 64)
+	if err != nil || height <= 0 {
+		return 0, 0, fmt.Errorf("unable to use SVG height %q", heightMatch[1])
+	}
+
+	return width, height, nil
+}
+
+func formatSVGLength(length float64) string {
+	// nolint: gomnd
+	return strconv.FormatFloat(math.Round(length*100)/100, 'f', -1, 64)
+}
+
+// writeChartImage writes the raw chart image with caching headers.
+// It replies with 304 if the client already has the same image.
+func writeChartImage(w http.ResponseWriter, r *http.Request, statusCode int, chartReply *render.ChartReply, chartData []byte) {
+	etag := chartImageETag(chartData)
+
+	w.Header().Set(etagHeader, etag)
+	w.Header().Set(cacheControlHeader, fmt.Sprintf("private, max-age=%d", chartImageMaxAgeSecs(chartReply, time.Now().UTC())))
+
+	if statusCode == http.StatusOK && etagMatches(r.Header.Get(ifNoneMatchHeader), etag) {
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	w.Header().Set(contentTypeHeader, svgContentType)
+	w.WriteHeader(statusCode)
+
+	// nolint: errcheck
+	w.Write(chartData)
+}
+
+func chartImageETag(chartData []byte) string {
+	sum := sha256.Sum256(chartData)
+
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+func chartImageMaxAgeSecs(chartReply *render.ChartReply, now time.Time) int64 {
+	maxAge := imageMaxAge
+
+	if chartReply.ExpiresAt != nil {
+		if untilExpiration := chartReply.ExpiresAt.AsTime().Sub(now); untilExpiration < maxAge {
+			maxAge = untilExpiration
+		}
+	}
+
+	if maxAge < 0 {
+		return 0
+	}
+
+	return int64(maxAge / time.Second)
+}
+
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+
+	return false
+}
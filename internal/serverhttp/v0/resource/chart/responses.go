@@ -1,10 +1,11 @@
 package chart
 
 import (
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/limpidchart/lc-api/internal/convert"
 	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
 	"github.com/limpidchart/lc-api/internal/serverhttp/v0/view"
 )
@@ -21,28 +22,13 @@ type Chart struct {
 	}
 }
 
-// NewCreatedChartFromReply returns a new created chart representation from protobuf reply.
-func NewCreatedChartFromReply(chartReply *render.ChartReply) *Chart {
-	createdAt := chartReply.CreatedAt.AsTime()
-	deletedAt := chartReply.DeletedAt.AsTime()
-
-	chartData := string(chartReply.ChartData)
-	if len(chartReply.ChartData) != 0 {
-		chartData = base64.StdEncoding.EncodeToString(chartReply.ChartData)
-	}
-
+// NewChartFromReply returns a new chart representation from protobuf reply.
+func NewChartFromReply(chartReply *render.ChartReply) *Chart {
 	return &Chart{
 		Body: struct {
 			Chart *view.ChartReply `json:"chart"`
 		}{
-			Chart: &view.ChartReply{
-				RequestID:   chartReply.RequestId,
-				ChartID:     chartReply.ChartId,
-				ChartStatus: view.ChartStatusCreated.String(),
-				CreatedAt:   &createdAt,
-				DeletedAt:   &deletedAt,
-				ChartData:   chartData,
-			},
+			Chart: convert.ChartReplyToJSON(chartReply),
 		},
 	}
 }
@@ -56,3 +42,138 @@ func (r *Chart) MarshalJSON() ([]byte, error) {
 
 	return res, nil
 }
+
+// ChartsList representation.
+//
+// swagger:response chartsListRepr
+type ChartsList struct {
+	// Charts list representation.
+	//
+	// in: body
+	Body struct {
+		// ID of the request.
+		//
+		// swagger:strfmt uuid4
+		RequestID string `json:"request_id"`
+
+		// Charts sorted by creation timestamp from the newest to the oldest.
+		// Charts don't contain chart_data, it can be retrieved by chart ID.
+		Charts []*view.ChartReply `json:"charts"`
+
+		// Token to retrieve the next page.
+		// It's empty if there are no more charts.
+		NextPageToken string `json:"next_page_token"`
+	}
+}
+
+// NewChartsListFromReply returns a new charts list representation from protobuf reply.
+func NewChartsListFromReply(listChartsReply *render.ListChartsReply) *ChartsList {
+	charts := make([]*view.ChartReply, 0, len(listChartsReply.Charts))
+
+	for _, chartReply := range listChartsReply.Charts {
+		charts = append(charts, convert.ChartReplyToJSON(chartReply))
+	}
+
+	return &ChartsList{
+		Body: struct {
+			RequestID     string             `json:"request_id"`
+			Charts        []*view.ChartReply `json:"charts"`
+			NextPageToken string             `json:"next_page_token"`
+		}{
+			RequestID:     listChartsReply.RequestId,
+			Charts:        charts,
+			NextPageToken: listChartsReply.NextPageToken,
+		},
+	}
+}
+
+// MarshalJSON implements the json.Marshaller interface.
+func (r *ChartsList) MarshalJSON() ([]byte, error) {
+	res, err := json.Marshal(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal charts list body into JSON: %w", err)
+	}
+
+	return res, nil
+}
+
+// CreateChartsResult representation.
+//
+// Reply is a stream of newline delimited JSON objects, one per chart in completion order.
+//
+// swagger:response createChartsResultRepr
+type CreateChartsResult struct {
+	// Create charts result representation.
+	//
+	// in: body
+	Body *view.CreateChartsReply
+}
+
+// NewCreateChartsResult returns a new create charts result representation.
+func NewCreateChartsResult(reqID string, index int, chartReply *render.ChartReply, errMsg string) *CreateChartsResult {
+	res := &CreateChartsResult{
+		Body: &view.CreateChartsReply{
+			RequestID:    reqID,
+			Index:        index,
+			ErrorMessage: errMsg,
+		},
+	}
+
+	if chartReply != nil {
+		res.Body.Chart = convert.ChartReplyToJSON(chartReply)
+	}
+
+	return res
+}
+
+// MarshalJSON implements the json.Marshaller interface.
+func (r *CreateChartsResult) MarshalJSON() ([]byte, error) {
+	res, err := json.Marshal(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal create charts result body into JSON: %w", err)
+	}
+
+	return res, nil
+}
+
+// SignedChartImage representation.
+//
+// swagger:response signedChartImageRepr
+type SignedChartImage struct {
+	// Signed chart image URL representation.
+	//
+	// in: body
+	Body struct {
+		// ID of the request.
+		//
+		// swagger:strfmt uuid4
+		RequestID string `json:"request_id"`
+
+		// Chart image URL that can be used without API credentials until it's expired.
+		// It's relative to the API address unless the signed URLs base is configured.
+		URL string `json:"url"`
+
+		// Signed URL expiration timestamp.
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+}
+
+// NewSignedChartImage returns a new signed chart image URL representation.
+func NewSignedChartImage(reqID, url string, expiresAt time.Time) *SignedChartImage {
+	res := &SignedChartImage{}
+	res.Body.RequestID = reqID
+	res.Body.URL = url
+	res.Body.ExpiresAt = expiresAt.UTC()
+
+	return res
+}
+
+// MarshalJSON implements the json.Marshaller interface.
+func (r *SignedChartImage) MarshalJSON() ([]byte, error) {
+	res, err := json.Marshal(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal signed chart image body into JSON: %w", err)
+	}
+
+	return res, nil
+}
@@ -14,11 +14,12 @@ import (
 	"github.com/limpidchart/lc-api/internal/serverhttp/v0/view"
 )
 
-func TestNewCreatedChartFromReply(t *testing.T) {
+func TestNewChartFromReply(t *testing.T) {
 	t.Parallel()
 
 	reqID := "red_id_1"
 	chartID := "chart_id_1"
+	title := "chart_title_1"
 	data := []byte("svg_chart_data_1")
 	ts := time.Date(2021, 7, 22, 16, 58, 56, 0, time.UTC)
 
@@ -33,6 +34,7 @@ func TestNewCreatedChartFromReply(t *testing.T) {
 				CreatedAt:   &ts,
 				DeletedAt:   &ts,
 				ChartData:   base64.StdEncoding.EncodeToString(data),
+				Title:       title,
 			},
 		},
 	}
@@ -44,9 +46,36 @@ func TestNewCreatedChartFromReply(t *testing.T) {
 		CreatedAt:   timestamppb.New(ts),
 		DeletedAt:   timestamppb.New(ts),
 		ChartData:   data,
+		Title:       title,
 	}
 
-	assert.Equal(t, expected, chart.NewCreatedChartFromReply(reply))
+	assert.Equal(t, expected, chart.NewChartFromReply(reply))
+}
+
+func TestNewChartsListFromReply(t *testing.T) {
+	t.Parallel()
+
+	reqID := "red_id_1"
+	ts := time.Date(2021, 7, 22, 16, 58, 56, 0, time.UTC)
+
+	reply := &render.ListChartsReply{
+		RequestId: reqID,
+		Charts: []*render.ChartReply{
+			{
+				RequestId:   reqID,
+				ChartId:     "chart_id_1",
+				ChartStatus: render.ChartStatus_CREATED,
+				CreatedAt:   timestamppb.New(ts),
+				DeletedAt:   timestamppb.New(ts),
+				Title:       "chart_title_1",
+			},
+		},
+		NextPageToken: "next_page_token_1",
+	}
+
+	actual, err := json.Marshal(chart.NewChartsListFromReply(reply))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"request_id":"red_id_1","charts":[{"request_id":"red_id_1","chart_id":"chart_id_1","chart_status":"CREATED","created_at":"2021-07-22T16:58:56Z","deleted_at":"2021-07-22T16:58:56Z","chart_data":"","title":"chart_title_1","expires_at":null,"error_message":"","tenant":"","cost":0}],"next_page_token":"next_page_token_1"}`, string(actual))
 }
 
 func TestChartMarshalJSON(t *testing.T) {
@@ -72,10 +101,11 @@ func TestChartMarshalJSON(t *testing.T) {
 						CreatedAt:   &ts,
 						DeletedAt:   &ts,
 						ChartData:   "svg_chart_data_2",
+						Title:       "chart_title_2",
 					},
 				},
 			},
-			[]byte(`{"chart":{"request_id":"req_id_3","chart_id":"chart_id_2","chart_status":"CREATED","created_at":"2021-02-04T08:16:32.000000064Z","deleted_at":"2021-02-04T08:16:32.000000064Z","chart_data":"svg_chart_data_2"}}`),
+			[]byte(`{"chart":{"request_id":"req_id_3","chart_id":"chart_id_2","chart_status":"CREATED","created_at":"2021-02-04T08:16:32.000000064Z","deleted_at":"2021-02-04T08:16:32.000000064Z","chart_data":"svg_chart_data_2","title":"chart_title_2","expires_at":null,"error_message":"","tenant":"","cost":0}}`),
 		},
 		{
 			"failed_chart",
@@ -90,10 +120,11 @@ func TestChartMarshalJSON(t *testing.T) {
 						CreatedAt:   nil,
 						DeletedAt:   nil,
 						ChartData:   "",
+						Title:       "",
 					},
 				},
 			},
-			[]byte(`{"chart":{"request_id":"req_id_4","chart_id":"","chart_status":"ERROR","created_at":null,"deleted_at":null,"chart_data":""}}`),
+			[]byte(`{"chart":{"request_id":"req_id_4","chart_id":"","chart_status":"ERROR","created_at":null,"deleted_at":null,"chart_data":"","title":"","expires_at":null,"error_message":"","tenant":"","cost":0}}`),
 		},
 	}
 
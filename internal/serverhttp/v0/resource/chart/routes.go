@@ -2,46 +2,123 @@ package chart
 
 import (
 	"compress/flate"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/rs/zerolog"
 
+	"github.com/limpidchart/lc-api/internal/audit"
+	"github.com/limpidchart/lc-api/internal/auth"
 	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/concurrency"
+	"github.com/limpidchart/lc-api/internal/convert"
+	"github.com/limpidchart/lc-api/internal/cost"
 	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/ratelimit"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
 	"github.com/limpidchart/lc-api/internal/renderer"
 	"github.com/limpidchart/lc-api/internal/serverhttp/v0/middleware"
 	"github.com/limpidchart/lc-api/internal/serverhttp/v0/view"
+	"github.com/limpidchart/lc-api/internal/signedurl"
+	"github.com/limpidchart/lc-api/internal/storage"
 )
 
-const applicationJSONContentType = "application/json"
+const (
+	applicationJSONContentType = "application/json"
+	ndjsonContentType          = "application/x-ndjson"
+)
+
+// errChartImageNotFound contains error message about chart that doesn't have an image since it's deleted or not rendered yet.
+var errChartImageNotFound = errors.New("chart image is not found")
 
 // Routes implements HTTP handler for charts requests.
 func Routes(log *zerolog.Logger, bCon backend.ConnSupervisor, pRec metric.PromRecorder) http.Handler {
-	r := chi.NewRouter().
-		With(middleware.Recover(log)).
-		With(chimiddleware.Compress(flate.BestCompression, applicationJSONContentType)).
-		With(middleware.BackendCheck(log, bCon)).
-		With(middleware.SetRequestID(log)).
-		With(middleware.RequestObserver(log, pRec))
+	r := chi.NewRouter()
+
+	r.Use(
+		middleware.Recover(log),
+		chimiddleware.Compress(flate.BestCompression, applicationJSONContentType, svgContentType),
+		middleware.SetRequestID(log),
+		middleware.SetClientIP(log, bCon),
+		middleware.RequestObserver(log, pRec),
+	)
+
+	// swagger:route GET /charts/{chart_id}/image Charts getChartImage
+	//
+	// Get raw chart image by ID
+	//
+	// Requests with the signed URL parameters from signChartImage don't need API credentials.
+	//
+	// Schemes: http, https
+	//
+	// Produces:
+	//   - image/svg+xml
+	//
+	// Responses:
+	//   default: error
+	//   403: forbiddenError
+	//   200: chartImage
+	//   404: notFoundError
+	r.
+		With(
+			middleware.VerifyImageSignature(log, bCon),
+			middleware.Authenticate(log, bCon),
+			middleware.SetTenant(log, bCon),
+			middleware.RequireScope(log, auth.ScopeChartsRead),
+			middleware.RateLimit(log, bCon, pRec, ratelimit.OperationGetChart),
+			middleware.RequireChartID(log),
+		).
+		Get(fmt.Sprintf("/{%s}/image", view.ParamChartID), getChartImageHandler(log, bCon))
+
+	r.Group(func(r chi.Router) {
+		r.Use(
+			middleware.Authenticate(log, bCon),
+			middleware.SetTenant(log, bCon),
+		)
 
+		chartRoutes(log, bCon, pRec, r)
+	})
+
+	return r
+}
+
+func chartRoutes(log *zerolog.Logger, bCon backend.ConnSupervisor, pRec metric.PromRecorder, r chi.Router) {
 	// swagger:route POST /charts Charts createChart
 	//
 	// Create a new chart
 	//
+	// Raw SVG chart image is returned instead of JSON if the request has "Accept: image/svg+xml" header.
+	// Chart with PENDING status is returned right away if the request has "async=true" query parameter,
+	// it's rendered in background and can be polled by its ID.
+	//
 	// Schemes: http, https
 	//
 	// Produces:
 	//   - application/json
+	//   - image/svg+xml
 	//
 	// Responses:
 	//   default: error
+	//   403: forbiddenError
 	//   201: chartRepr
+	//   202: chartRepr
 	r.
-		With(middleware.RequireCreateChartParams(log)).
+		With(
+			middleware.BackendCheck(log, bCon),
+			middleware.RequireScope(log, auth.ScopeChartsCreate),
+			middleware.RateLimit(log, bCon, pRec, ratelimit.OperationCreateChart),
+			middleware.RequireCreateChartParams(log),
+		).
 		Post("/", createChartHandler(log, bCon))
 
 	// swagger:route GET /charts/{chart_id} Charts getChart
@@ -55,11 +132,64 @@ func Routes(log *zerolog.Logger, bCon backend.ConnSupervisor, pRec metric.PromRe
 	//
 	// Responses:
 	//   default: error
+	//   403: forbiddenError
 	//   200: chartRepr
 	//   404: notFoundError
 	r.
-		With(middleware.RequireChartID(log)).
-		Get(fmt.Sprintf("/{%s}", view.ParamChartID), getChartHandler(log))
+		With(
+			middleware.RequireScope(log, auth.ScopeChartsRead),
+			middleware.RateLimit(log, bCon, pRec, ratelimit.OperationGetChart),
+			middleware.RequireChartID(log),
+		).
+		Get(fmt.Sprintf("/{%s}", view.ParamChartID), getChartHandler(log, bCon))
+
+	// swagger:route POST /charts/{chart_id}/image:sign Charts signChartImage
+	//
+	// Sign chart image URL
+	//
+	// Signed URL of the raw chart image can be used without API credentials until it's expired.
+	//
+	// Schemes: http, https
+	//
+	// Produces:
+	//   - application/json
+	//
+	// Responses:
+	//   default: error
+	//   403: forbiddenError
+	//   201: signedChartImageRepr
+	//   404: notFoundError
+	r.
+		With(
+			middleware.RequireScope(log, auth.ScopeChartsRead),
+			middleware.RateLimit(log, bCon, pRec, ratelimit.OperationGetChart),
+			middleware.RequireChartID(log),
+		).
+		Post(fmt.Sprintf("/{%s}/image:sign", view.ParamChartID), signChartImageHandler(log, bCon))
+
+	// swagger:route DELETE /charts/{chart_id} Charts deleteChart
+	//
+	// Delete chart by ID
+	//
+	// Chart data is removed immediately and the chart tombstone is returned.
+	//
+	// Schemes: http, https
+	//
+	// Produces:
+	//   - application/json
+	//
+	// Responses:
+	//   default: error
+	//   403: forbiddenError
+	//   200: chartRepr
+	//   404: notFoundError
+	r.
+		With(
+			middleware.RequireScope(log, auth.ScopeChartsDelete),
+			middleware.RateLimit(log, bCon, pRec, ratelimit.OperationDeleteChart),
+			middleware.RequireChartID(log),
+		).
+		Delete(fmt.Sprintf("/{%s}", view.ParamChartID), deleteChartHandler(log, bCon))
 
 	// swagger:route GET /charts Charts listCharts
 	//
@@ -72,7 +202,54 @@ func Routes(log *zerolog.Logger, bCon backend.ConnSupervisor, pRec metric.PromRe
 	//
 	// Responses:
 	//   default: error
-	r.Get("/", listChartsHandler())
+	//   403: forbiddenError
+	//   200: chartsListRepr
+	r.
+		With(
+			middleware.RequireScope(log, auth.ScopeChartsList),
+			middleware.RateLimit(log, bCon, pRec, ratelimit.OperationListCharts),
+			middleware.RequireListChartsParams(log),
+		).
+		Get("/", listChartsHandler(log, bCon))
+}
+
+// BatchRoutes implements HTTP handler for batch charts requests.
+func BatchRoutes(log *zerolog.Logger, bCon backend.ConnSupervisor, pRec metric.PromRecorder) http.Handler {
+	r := chi.NewRouter()
+
+	r.Use(
+		middleware.Recover(log),
+		middleware.SetRequestID(log),
+		middleware.SetClientIP(log, bCon),
+		middleware.RequestObserver(log, pRec),
+		middleware.Authenticate(log, bCon),
+		middleware.SetTenant(log, bCon),
+	)
+
+	// swagger:route POST /charts:batch Charts createCharts
+	//
+	// Create charts from the batch
+	//
+	// Charts are created concurrently and their results are streamed as newline delimited JSON in completion order.
+	// Every result has index of the chart in the request body and either the created chart or the error message.
+	//
+	// Schemes: http, https
+	//
+	// Produces:
+	//   - application/x-ndjson
+	//
+	// Responses:
+	//   default: error
+	//   403: forbiddenError
+	//   200: createChartsResultRepr
+	r.
+		With(
+			middleware.BackendCheck(log, bCon),
+			middleware.RequireScope(log, auth.ScopeChartsCreate),
+			middleware.RateLimit(log, bCon, pRec, ratelimit.OperationCreateCharts),
+			middleware.RequireCreateChartsParams(log),
+		).
+		Post("/", createChartsHandler(log, bCon))
 
 	return r
 }
@@ -91,32 +268,367 @@ func createChartHandler(log *zerolog.Logger, b backend.ConnSupervisor) func(w ht
 			return
 		}
 
-		res, err := renderer.CreateChart(r.Context(), renderer.CreateChartOpts{
-			RequestID:      reqID,
-			Request:        createChartRequest,
-			RendererClient: b.RendererClient(),
-			Timeout:        b.RendererRequestTimeout(),
+		// Spec is hashed before the request is changed by the chart creation.
+		specHash := audit.SpecHash(createChartRequest)
+
+		res, err := renderer.CreateChart(r.Context(), createChartOpts(r.Context(), createChartRequest, b))
+		auditCreateChart(&log, r, b, specHash, res, err)
+
+		switch {
+		case err == nil && createChartRequest.Async:
+			w.Header().Set("Location", path.Join(r.URL.Path, res.ChartId))
+			middleware.MarshalJSON(w, http.StatusAccepted, NewChartFromReply(res))
+		case err == nil && acceptsSVG(r):
+			w.Header().Set("Location", path.Join(r.URL.Path, res.ChartId))
+			writeChartImage(w, r, http.StatusCreated, res, res.ChartData)
+		case err == nil:
+			middleware.MarshalJSON(w, http.StatusCreated, NewChartFromReply(res))
+		default:
+			var limitErr *concurrency.LimitError
+			if errors.As(err, &limitErr) {
+				w.Header().Set(middleware.RetryAfterHeader, strconv.Itoa(limitErr.RetryAfterSeconds()))
+			}
+
+			statusCode, msg := createChartErr(&log, err)
+			if statusCode == http.StatusInternalServerError {
+				http.Error(w, msg, statusCode)
+
+				return
+			}
+
+			middleware.MarshalJSON(w, statusCode, view.NewError(msg))
+		}
+	}
+}
+
+func createChartsHandler(log *zerolog.Logger, b backend.ConnSupervisor) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqID := middleware.GetRequestID(r.Context())
+		log := log.With().Str(middleware.RequestIDLogKey, reqID).Logger()
+
+		createChartsRequest := middleware.GetCreateChartsRequest(r.Context())
+		if createChartsRequest == nil {
+			log.Error().Msg("create charts request is empty after middlewares validation")
+
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		if err := b.RenderBatcher().ValidateSize(len(createChartsRequest)); err != nil {
+			middleware.MarshalJSON(w, http.StatusBadRequest, view.NewError(fmt.Sprintf("Unable to use the provided create charts parameters: %s", err)))
+
+			return
+		}
+
+		results := b.RenderBatcher().CreateCharts(r.Context(), len(createChartsRequest), func(ctx context.Context, index int) (*render.ChartReply, error) {
+			createChartRequest, err := convert.JSONToCreateChartRequest(createChartsRequest[index])
+			if err != nil {
+				paramsErr := &createChartParamsError{err: err}
+				recordAudit(&log, b, middleware.NewAuditEntry(r, audit.OperationCreateChart), paramsErr)
+
+				return nil, paramsErr
+			}
+
+			createChartRequest.Async = createChartsRequest[index].Async
+
+			specHash := audit.SpecHash(createChartRequest)
+
+			res, err := renderer.CreateChart(ctx, createChartOpts(ctx, createChartRequest, b))
+			auditCreateChart(&log, r, b, specHash, res, err)
+
+			return res, err
 		})
 
+		w.Header().Set("Content-Type", ndjsonContentType)
+		w.WriteHeader(http.StatusOK)
+
+		flusher, _ := w.(http.Flusher)
+		enc := json.NewEncoder(w)
+
+		for res := range results {
+			errMsg := ""
+			if res.Err != nil {
+				_, errMsg = createChartErr(&log, res.Err)
+			}
+
+			if err := enc.Encode(NewCreateChartsResult(reqID, res.Index, res.Chart, errMsg)); err != nil {
+				log.Warn().Err(err).Msg("unable to write create charts result")
+
+				return
+			}
+
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+func createChartOpts(ctx context.Context, createChartRequest *render.CreateChartRequest, b backend.ConnSupervisor) renderer.CreateChartOpts {
+	tenant := middleware.GetTenant(ctx)
+
+	return renderer.CreateChartOpts{
+		RequestID:   middleware.GetRequestID(ctx),
+		Request:     createChartRequest,
+		Tenant:      tenant,
+		Limits:      b.Tenants().Limits(tenant),
+		CostLimits:  b.Tenants().CostLimits(tenant),
+		Renderers:   b.Renderers(),
+		Timeout:     b.Tenants().RendererTimeout(tenant, 0),
+		Storage:     b.Storage(),
+		ChartTTL:    b.ChartTTL(),
+		RenderCache: b.RenderCache(),
+		Coalescer:   b.RenderCoalescer(),
+		Queue:       b.RenderQueue(),
+		Webhooks:    b.WebhookQueue(),
+		Costs:       b.Costs(),
+	}
+}
+
+// auditCreateChart writes the chart creation into the audit log.
+func auditCreateChart(log *zerolog.Logger, r *http.Request, b backend.ConnSupervisor, specHash string, res *render.ChartReply, err error) {
+	entry := middleware.NewAuditEntry(r, audit.OperationCreateChart)
+	entry.SpecHash = specHash
+
+	if res != nil {
+		entry.ChartID = res.ChartId
+	}
+
+	recordAudit(log, b, entry, err)
+}
+
+// recordAudit writes the entry of the operation into the audit log.
+// Failed writes are logged since they can't fail the operation.
+func recordAudit(log *zerolog.Logger, b backend.ConnSupervisor, entry audit.Entry, opErr error) {
+	if err := b.Audit().Record(entry, opErr); err != nil {
+		log.Error().Err(err).Msg("unable to write audit log entry")
+	}
+}
+
+// auditChart writes the operation with the chart into the audit log.
+func auditChart(log *zerolog.Logger, r *http.Request, b backend.ConnSupervisor, operation, chartID string, opErr error) {
+	entry := middleware.NewAuditEntry(r, operation)
+	entry.ChartID = chartID
+
+	recordAudit(log, b, entry, opErr)
+}
+
+// createChartParamsError represents a chart from the batch that can't be converted into create chart request.
+type createChartParamsError struct {
+	err error
+}
+
+func (e *createChartParamsError) Error() string {
+	return fmt.Sprintf("Unable to use the provided create chart parameters: %s", e.err)
+}
+
+// createChartErr logs renderer.CreateChart error and returns HTTP status code and message that describe it.
+func createChartErr(log *zerolog.Logger, err error) (int, string) {
+	var paramsErr *createChartParamsError
+
+	switch {
+	case errors.As(err, &paramsErr):
+		return http.StatusBadRequest, paramsErr.Error()
+	case errors.Is(err, renderer.ErrGenerateChartIDFailed):
+		log.Error().Err(err).Msg(fmt.Sprintf("unable to generate a random UUID for %s", view.ParamChartID))
+
+		return http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError)
+	case errors.Is(err, renderer.ErrSaveChartFailed):
+		log.Error().Err(err).Msg("unable to save chart")
+
+		return http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError)
+	case errors.Is(err, renderer.ErrCreateChartRequestCancelled):
+		msg := "Renderer request timed-out"
+		log.Warn().Msg(msg)
+
+		return http.StatusRequestTimeout, msg
+	case errors.Is(err, renderer.ErrRenderQueueFull):
+		msg := "Render queue is full, try again later"
+		log.Warn().Msg(msg)
+
+		return http.StatusServiceUnavailable, msg
+	case errors.Is(err, concurrency.ErrLimitExceeded):
+		msg := "Renderer is overloaded, try again later"
+		log.Warn().Msg(msg)
+
+		return http.StatusServiceUnavailable, msg
+	case errors.Is(err, renderer.ErrRendererUnavailable):
+		msg := fmt.Sprintf("Unable to render a chart: %s", err.Error())
+		log.Warn().Msg(msg)
+
+		return http.StatusServiceUnavailable, msg
+	case errors.Is(err, cost.ErrBudgetExhausted):
+		msg := fmt.Sprintf("Unable to render a chart: %s", err.Error())
+		log.Warn().Msg(msg)
+
+		return http.StatusTooManyRequests, msg
+	default:
+		msg := fmt.Sprintf("Unable to render a chart: %s", err.Error())
+		log.Warn().Msg(msg)
+
+		return http.StatusBadRequest, msg
+	}
+}
+
+func getChartHandler(log *zerolog.Logger, b backend.ConnSupervisor) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqID := middleware.GetRequestID(r.Context())
+		log := log.With().Str(middleware.RequestIDLogKey, reqID).Logger()
+
+		chartID := middleware.GetChartID(r.Context())
+		if chartID == "" {
+			log.Error().Msg("unable to get chart_id from context")
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		res, err := b.Storage().GetChart(r.Context(), middleware.GetTenant(r.Context()), chartID)
+		auditChart(&log, r, b, audit.OperationGetChart, chartID, err)
+
 		switch {
 		case err == nil:
-			middleware.MarshalJSON(w, http.StatusCreated, NewCreatedChartFromReply(res))
-		case errors.Is(err, renderer.ErrGenerateChartIDFailed):
-			log.Error().Err(err).Msg(fmt.Sprintf("unable to generate a random UUID for %s", view.ParamChartID))
+			res.RequestId = reqID
+			middleware.MarshalJSON(w, http.StatusOK, NewChartFromReply(res))
+		case errors.Is(err, storage.ErrChartNotFound):
+			middleware.MarshalJSON(w, http.StatusNotFound, view.NewNotFoundError("chart", chartID))
+		default:
+			log.Error().Err(err).Msg("unable to get chart")
 			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-		case errors.Is(err, renderer.ErrCreateChartRequestCancelled):
-			msg := "Renderer request timed-out"
+		}
+	}
+}
+
+func getChartImageHandler(log *zerolog.Logger, b backend.ConnSupervisor) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqID := middleware.GetRequestID(r.Context())
+		log := log.With().Str(middleware.RequestIDLogKey, reqID).Logger()
+
+		chartID := middleware.GetChartID(r.Context())
+		if chartID == "" {
+			log.Error().Msg("unable to get chart_id from context")
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		size := r.URL.Query().Get(signedurl.ParamSize)
+		if err := validateImageSize(size); err != nil {
+			msg := fmt.Sprintf("Unable to use the provided image size: %s", err)
 			log.Warn().Msg(msg)
-			middleware.MarshalJSON(w, http.StatusRequestTimeout, view.NewError(msg))
+			middleware.MarshalJSON(w, http.StatusBadRequest, view.NewError(msg))
+
+			return
+		}
+
+		res, err := b.Storage().GetChart(r.Context(), middleware.GetTenant(r.Context()), chartID)
+
+		auditErr := err
+		if err == nil && res.ChartStatus != render.ChartStatus_CREATED {
+			auditErr = errChartImageNotFound
+		}
+
+		auditChart(&log, r, b, audit.OperationGetChartImage, chartID, auditErr)
+
+		switch {
+		case err == nil && res.ChartStatus == render.ChartStatus_CREATED:
+			chartData, resizeErr := resizeChartImage(res.ChartData, size)
+			if resizeErr != nil {
+				log.Error().Err(resizeErr).Msg("unable to resize chart image")
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+				return
+			}
+
+			writeChartImage(w, r, http.StatusOK, res, chartData)
+		case err == nil, errors.Is(err, storage.ErrChartNotFound):
+			// Deleted charts don't have images anymore.
+			middleware.MarshalJSON(w, http.StatusNotFound, view.NewNotFoundError("chart", chartID))
 		default:
-			msg := fmt.Sprintf("Unable to render a chart: %s", err.Error())
+			log.Error().Err(err).Msg("unable to get chart")
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+	}
+}
+
+func signChartImageHandler(log *zerolog.Logger, b backend.ConnSupervisor) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqID := middleware.GetRequestID(r.Context())
+		log := log.With().Str(middleware.RequestIDLogKey, reqID).Logger()
+
+		chartID := middleware.GetChartID(r.Context())
+		if chartID == "" {
+			log.Error().Msg("unable to get chart_id from context")
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		signOpts := view.SignChartImageRequest{}
+
+		if err := json.NewDecoder(r.Body).Decode(&signOpts); err != nil && !errors.Is(err, io.EOF) {
+			msg := fmt.Sprintf("Unable to decode sign chart image JSON: %s", err)
+			log.Warn().Msg(msg)
+			middleware.MarshalJSON(w, http.StatusBadRequest, view.NewError(msg))
+
+			return
+		}
+
+		if err := validateImageSize(signOpts.Image.Size); err != nil {
+			msg := fmt.Sprintf("Unable to use the provided image size: %s", err)
+			log.Warn().Msg(msg)
+			middleware.MarshalJSON(w, http.StatusBadRequest, view.NewError(msg))
+
+			return
+		}
+
+		tenant := middleware.GetTenant(r.Context())
+
+		res, err := b.Storage().GetChart(r.Context(), tenant, chartID)
+
+		switch {
+		case errors.Is(err, storage.ErrChartNotFound), err == nil && res.ChartStatus == render.ChartStatus_DELETED:
+			auditChart(&log, r, b, audit.OperationSignChartImage, chartID, errChartImageNotFound)
+
+			// Deleted charts don't have images anymore.
+			middleware.MarshalJSON(w, http.StatusNotFound, view.NewNotFoundError("chart", chartID))
+
+			return
+		case err != nil:
+			auditChart(&log, r, b, audit.OperationSignChartImage, chartID, err)
+
+			log.Error().Err(err).Msg("unable to get chart")
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		ttl := time.Duration(0)
+		if signOpts.Image.ExpiresIn != nil {
+			ttl = time.Duration(*signOpts.Image.ExpiresIn) * time.Second
+		}
+
+		signer := b.SignedURLs()
+
+		query, expiresAt, err := signer.Sign(chartID, signedurl.Claims{Tenant: tenant, Size: signOpts.Image.Size}, ttl, time.Now())
+		auditChart(&log, r, b, audit.OperationSignChartImage, chartID, err)
+
+		switch {
+		case err == nil:
+			imageURL := signer.BaseURL() + strings.TrimSuffix(r.URL.Path, ":sign") + "?" + query.Encode()
+			middleware.MarshalJSON(w, http.StatusCreated, NewSignedChartImage(reqID, imageURL, expiresAt))
+		case errors.Is(err, signedurl.ErrDisabled):
+			middleware.MarshalJSON(w, http.StatusNotImplemented, view.NewError(fmt.Sprintf("Unable to sign chart image URL: %s", err)))
+		default:
+			msg := fmt.Sprintf("Unable to sign chart image URL: %s", err)
 			log.Warn().Msg(msg)
 			middleware.MarshalJSON(w, http.StatusBadRequest, view.NewError(msg))
 		}
 	}
 }
 
-func getChartHandler(log *zerolog.Logger) func(w http.ResponseWriter, r *http.Request) {
+func deleteChartHandler(log *zerolog.Logger, b backend.ConnSupervisor) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		reqID := middleware.GetRequestID(r.Context())
 		log := log.With().Str(middleware.RequestIDLogKey, reqID).Logger()
@@ -129,14 +641,60 @@ func getChartHandler(log *zerolog.Logger) func(w http.ResponseWriter, r *http.Re
 			return
 		}
 
-		// We return 404 until storage backend is implemented.
-		middleware.MarshalJSON(w, http.StatusNotFound, view.NewNotFoundError("chart", chartID))
+		res, err := b.Storage().DeleteChart(r.Context(), middleware.GetTenant(r.Context()), chartID, time.Now().UTC())
+		auditChart(&log, r, b, audit.OperationDeleteChart, chartID, err)
+
+		switch {
+		case err == nil:
+			res.RequestId = reqID
+			middleware.MarshalJSON(w, http.StatusOK, NewChartFromReply(res))
+		case errors.Is(err, storage.ErrChartNotFound):
+			middleware.MarshalJSON(w, http.StatusNotFound, view.NewNotFoundError("chart", chartID))
+		default:
+			log.Error().Err(err).Msg("unable to delete chart")
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
 	}
 }
 
-func listChartsHandler() func(w http.ResponseWriter, r *http.Request) {
+func listChartsHandler(log *zerolog.Logger, b backend.ConnSupervisor) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// We return error until auth is implemented.
-		middleware.MarshalJSON(w, http.StatusNotImplemented, view.NewError("List of charts handler is not implemented yet"))
+		reqID := middleware.GetRequestID(r.Context())
+		log := log.With().Str(middleware.RequestIDLogKey, reqID).Logger()
+
+		listChartsRequest := middleware.GetListChartsRequest(r.Context())
+		if listChartsRequest == nil {
+			log.Error().Msg("list charts request is empty after middlewares validation")
+
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		listOpts := convert.ListChartsRequestToListOpts(listChartsRequest)
+		listOpts.Tenant = middleware.GetTenant(r.Context())
+
+		res, err := b.Storage().ListCharts(r.Context(), listOpts)
+		recordAudit(&log, b, middleware.NewAuditEntry(r, audit.OperationListCharts), err)
+
+		switch {
+		case err == nil:
+			for _, chart := range res.Charts {
+				chart.RequestId = reqID
+			}
+
+			middleware.MarshalJSON(w, http.StatusOK, NewChartsListFromReply(&render.ListChartsReply{
+				RequestId:     reqID,
+				Charts:        res.Charts,
+				NextPageToken: res.NextPageToken,
+			}))
+		case errors.Is(err, storage.ErrBadPageSize), errors.Is(err, storage.ErrBadPageToken):
+			msg := fmt.Sprintf("Unable to use the provided list charts parameters: %s", err)
+			log.Warn().Msg(msg)
+			middleware.MarshalJSON(w, http.StatusBadRequest, view.NewError(msg))
+		default:
+			log.Error().Err(err).Msg("unable to list charts")
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
 	}
 }
@@ -0,0 +1,105 @@
+package chart_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/serverhttp"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/middleware"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/resource/chart"
+	"github.com/limpidchart/lc-api/internal/testutils"
+)
+
+func TestRoutes_ReadOnlyAPIKey(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingRendererEnvTimeoutSecs)
+	defer cancel()
+
+	tre := newTestingRendererEnv(ctx, t, testingRendererEnvOpts{
+		rendererChartData: []byte(`<svg></svg>`),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+	})
+
+	apiKeysPath := filepath.Join(t.TempDir(), "api-keys")
+	apiKeys := fmt.Sprintf("reporting %s charts:read,charts:list\n", auth.HashAPIKey("reporting-key"))
+
+	if err := os.WriteFile(apiKeysPath, []byte(apiKeys), 0o600); err != nil {
+		t.Fatalf("unable to write API keys file: %s", err)
+	}
+
+	b, err := backend.NewBackend(ctx, config.Config{
+		Renderer: config.RendererConfig{
+			Address:               tre.address(),
+			ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
+			RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
+		},
+		Storage:   config.StorageConfig{Kind: config.StorageKindMemory},
+		Batch:     config.BatchConfig{Parallelism: 1, MaxSize: 1},
+		Auth:      config.AuthConfig{APIKeysPath: apiKeysPath},
+		RateLimit: config.RateLimitConfig{Key: config.RateLimitKeyIP},
+	}, metric.NewEmptyRecorder())
+	if err != nil {
+		t.Fatalf("unable to configure backend: %s", err)
+	}
+
+	log := zerolog.New(os.Stderr)
+	router := chi.NewRouter()
+	router.Route(serverhttp.GroupV0, func(router chi.Router) {
+		router.Mount(serverhttp.GroupCharts, chart.Routes(&log, b, metric.NewEmptyRecorder()))
+		router.Mount(serverhttp.GroupChartsBatch, chart.BatchRoutes(&log, b, metric.NewEmptyRecorder()))
+	})
+
+	chartURL := strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupCharts, "/", testutils.RandomUUID(t).String()}, "")
+
+	tt := []struct {
+		name         string
+		method       string
+		url          string
+		expectedCode int
+	}{
+		{"list_charts", http.MethodGet, strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupCharts}, ""), http.StatusOK},
+		{"get_chart", http.MethodGet, chartURL, http.StatusNotFound},
+		{"get_chart_image", http.MethodGet, chartURL + "/image", http.StatusNotFound},
+		{"delete_chart", http.MethodDelete, chartURL, http.StatusForbidden},
+		{"create_chart", http.MethodPost, strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupCharts}, ""), http.StatusForbidden},
+		{"create_charts", http.MethodPost, strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupChartsBatch}, ""), http.StatusForbidden},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			r, err := http.NewRequestWithContext(ctx, tc.method, tc.url, strings.NewReader(`{}`))
+			if err != nil {
+				t.Fatalf("unable to prepare HTTP request: %s", err)
+			}
+
+			r.Header.Set(middleware.APIKeyHeader, "reporting-key")
+
+			router.ServeHTTP(w, r)
+
+			resp := w.Result()
+			resp.Body.Close()
+
+			assert.Equal(t, tc.expectedCode, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package chart_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/serverhttp"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/resource/chart"
+	"github.com/limpidchart/lc-api/internal/testutils"
+)
+
+func TestRoutes_UnhealthyBackend(t *testing.T) {
+	t.Parallel()
+
+	b := backend.NewEmptyBackend(false)
+	chartID := testutils.RandomUUID(t).String()
+
+	err := b.Storage().SaveChart(context.Background(), &render.ChartReply{
+		RequestId:   testutils.RandomUUID(t).String(),
+		ChartId:     chartID,
+		ChartStatus: render.ChartStatus_CREATED,
+		CreatedAt:   timestamppb.New(time.Now()),
+		ChartData:   []byte(`<svg>vertical_and_line</svg>`),
+	})
+	if err != nil {
+		t.Fatalf("unable to save testing chart: %s", err)
+	}
+
+	log := zerolog.New(os.Stderr)
+	router := chi.NewRouter()
+	router.Route(serverhttp.GroupV0, func(router chi.Router) {
+		router.Mount(serverhttp.GroupCharts, chart.Routes(&log, b, metric.NewEmptyRecorder()))
+		router.Mount(serverhttp.GroupChartsBatch, chart.BatchRoutes(&log, b, metric.NewEmptyRecorder()))
+	})
+
+	chartURL := strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupCharts, "/", chartID}, "")
+
+	// Cases aren't parallel, so the chart is deleted only after it has been read.
+	tt := []struct {
+		name         string
+		method       string
+		url          string
+		expectedCode int
+	}{
+		{"list_charts", http.MethodGet, strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupCharts}, ""), http.StatusOK},
+		{"get_chart", http.MethodGet, chartURL, http.StatusOK},
+		{"get_chart_image", http.MethodGet, chartURL + "/image", http.StatusOK},
+		{"create_chart", http.MethodPost, strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupCharts}, ""), http.StatusServiceUnavailable},
+		{"create_charts", http.MethodPost, strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupChartsBatch}, ""), http.StatusServiceUnavailable},
+		{"delete_chart", http.MethodDelete, chartURL, http.StatusOK},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			r, err := http.NewRequestWithContext(context.Background(), tc.method, tc.url, strings.NewReader(`{}`))
+			if err != nil {
+				t.Fatalf("unable to prepare HTTP request: %s", err)
+			}
+
+			router.ServeHTTP(w, r)
+
+			resp := w.Result()
+			resp.Body.Close()
+
+			assert.Equal(t, tc.expectedCode, resp.StatusCode)
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/limpidchart/lc-api/internal/backend"
 	"github.com/limpidchart/lc-api/internal/config"
 	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/renderer"
 	"github.com/limpidchart/lc-api/internal/serverhttp"
 	"github.com/limpidchart/lc-api/internal/serverhttp/v0/resource/chart"
 	"github.com/limpidchart/lc-api/internal/serverhttp/v0/view"
@@ -120,11 +121,15 @@ func TestCreateChart_VerticalAndLineOK(t *testing.T) {
 		rendererLatency:   time.Millisecond * 100,
 	})
 
-	b, err := backend.NewBackend(ctx, config.RendererConfig{
-		Address:               tre.address(),
-		ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
-		RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
-	})
+	b, err := backend.NewBackend(ctx, config.Config{
+		Renderer: config.RendererConfig{
+			Address:               tre.address(),
+			ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
+			RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
+		},
+		Storage:   config.StorageConfig{Kind: config.StorageKindMemory},
+		RateLimit: config.RateLimitConfig{Key: config.RateLimitKeyIP},
+	}, metric.NewEmptyRecorder())
 	if err != nil {
 		t.Fatalf("unable to configure backend: %s", err)
 	}
@@ -169,8 +174,7 @@ func TestCreateChart_VerticalAndLineOK(t *testing.T) {
 	assert.NotEmpty(t, respBody.Chart.RequestID)
 	assert.NotEmpty(t, respBody.Chart.ChartID)
 	assert.NotEmpty(t, respBody.Chart.CreatedAt)
-	assert.NotEmpty(t, respBody.Chart.DeletedAt)
-	assert.Equal(t, respBody.Chart.CreatedAt, respBody.Chart.DeletedAt) // equal until the storage backend is implemented
+	assert.Nil(t, respBody.Chart.DeletedAt)
 	assert.Equal(t, view.ChartStatusCreated.String(), respBody.Chart.ChartStatus)
 	assert.Equal(t, chartDataEncoded, respBody.Chart.ChartData)
 }
@@ -190,11 +194,15 @@ func TestCreateChart_VerticalAndLineOKGZIP(t *testing.T) {
 		rendererLatency:   time.Millisecond * 100,
 	})
 
-	b, err := backend.NewBackend(ctx, config.RendererConfig{
-		Address:               tre.address(),
-		ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
-		RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
-	})
+	b, err := backend.NewBackend(ctx, config.Config{
+		Renderer: config.RendererConfig{
+			Address:               tre.address(),
+			ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
+			RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
+		},
+		Storage:   config.StorageConfig{Kind: config.StorageKindMemory},
+		RateLimit: config.RateLimitConfig{Key: config.RateLimitKeyIP},
+	}, metric.NewEmptyRecorder())
 	if err != nil {
 		t.Fatalf("unable to configure backend: %s", err)
 	}
@@ -246,12 +254,72 @@ func TestCreateChart_VerticalAndLineOKGZIP(t *testing.T) {
 	assert.NotEmpty(t, respBody.Chart.RequestID)
 	assert.NotEmpty(t, respBody.Chart.ChartID)
 	assert.NotEmpty(t, respBody.Chart.CreatedAt)
-	assert.NotEmpty(t, respBody.Chart.DeletedAt)
-	assert.Equal(t, respBody.Chart.CreatedAt, respBody.Chart.DeletedAt) // equal until the storage backend is implemented
+	assert.Nil(t, respBody.Chart.DeletedAt)
 	assert.Equal(t, view.ChartStatusCreated.String(), respBody.Chart.ChartStatus)
 	assert.Equal(t, chartDataEncoded, respBody.Chart.ChartData)
 }
 
+func TestCreateChart_AcceptSVG(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingRendererEnvTimeoutSecs)
+	defer cancel()
+
+	chartData := []byte(`<svg>vertical_and_line</svg>`)
+
+	tre := newTestingRendererEnv(ctx, t, testingRendererEnvOpts{
+		rendererChartData: chartData,
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+	})
+
+	b, err := backend.NewBackend(ctx, config.Config{
+		Renderer: config.RendererConfig{
+			Address:               tre.address(),
+			ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
+			RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
+		},
+		Storage:   config.StorageConfig{Kind: config.StorageKindMemory},
+		RateLimit: config.RateLimitConfig{Key: config.RateLimitKeyIP},
+	}, metric.NewEmptyRecorder())
+	if err != nil {
+		t.Fatalf("unable to configure backend: %s", err)
+	}
+
+	log := zerolog.New(os.Stderr)
+	router := chi.NewRouter()
+	router.Route(serverhttp.GroupV0, func(router chi.Router) {
+		router.Mount(serverhttp.GroupCharts, chart.Routes(&log, b, metric.NewEmptyRecorder()))
+	})
+
+	w := httptest.NewRecorder()
+	url := strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupCharts}, "")
+
+	r, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader(verticalAndLineChartRequest(t)))
+	if err != nil {
+		t.Fatalf("unable to prepare HTTP request: %s", err)
+	}
+
+	r.Header.Set("Accept", "image/svg+xml;q=0.9, application/json;q=0.5")
+
+	router.ServeHTTP(w, r)
+
+	resp := w.Result()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unable to read response body: %s", err)
+	}
+
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "image/svg+xml", resp.Header.Get("Content-Type"))
+	assert.NotEmpty(t, resp.Header.Get("ETag"))
+	assert.Regexp(t, `^/v0/charts/[0-9a-f-]{36}$`, resp.Header.Get("Location"))
+	assert.Equal(t, chartData, body)
+}
+
 func TestCreateChart_ErrTimeout(t *testing.T) {
 	t.Parallel()
 
@@ -266,11 +334,15 @@ func TestCreateChart_ErrTimeout(t *testing.T) {
 		rendererLatency:   time.Minute,
 	})
 
-	b, err := backend.NewBackend(ctx, config.RendererConfig{
-		Address:               tre.address(),
-		ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
-		RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
-	})
+	b, err := backend.NewBackend(ctx, config.Config{
+		Renderer: config.RendererConfig{
+			Address:               tre.address(),
+			ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
+			RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
+		},
+		Storage:   config.StorageConfig{Kind: config.StorageKindMemory},
+		RateLimit: config.RateLimitConfig{Key: config.RateLimitKeyIP},
+	}, metric.NewEmptyRecorder())
 	if err != nil {
 		t.Fatalf("unable to configure backend: %s", err)
 	}
@@ -305,6 +377,108 @@ func TestCreateChart_ErrTimeout(t *testing.T) {
 	assert.Equal(t, `{"error":{"message":"Renderer request timed-out"}}`+"\n", string(body))
 }
 
+func TestCreateChart_ErrOverloaded(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingRendererEnvTimeoutSecs)
+	defer cancel()
+
+	tre := newTestingRendererEnv(ctx, t, testingRendererEnvOpts{
+		rendererChartData: []byte("wont_happen"),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Minute,
+	})
+
+	b, err := backend.NewBackend(ctx, config.Config{
+		Renderer: config.RendererConfig{
+			Address:                  tre.address(),
+			ConnTimeoutSeconds:       testutils.RendererConnTimeoutSecs,
+			RequestTimeoutSeconds:    testutils.RendererRequestTimeoutSecs,
+			LimitInitial:             1,
+			LimitMin:                 1,
+			LimitMax:                 1,
+			LimitLatencyMilliseconds: 1000,
+			LimitBackoffPercent:      50,
+			LimitRetryAfterSeconds:   2,
+		},
+		Storage:   config.StorageConfig{Kind: config.StorageKindMemory},
+		RateLimit: config.RateLimitConfig{Key: config.RateLimitKeyIP},
+	}, metric.NewEmptyRecorder())
+	if err != nil {
+		t.Fatalf("unable to configure backend: %s", err)
+	}
+
+	log := zerolog.New(os.Stderr)
+	router := chi.NewRouter()
+	router.Route(serverhttp.GroupV0, func(router chi.Router) {
+		router.Mount(serverhttp.GroupCharts, chart.Routes(&log, b, metric.NewEmptyRecorder()))
+	})
+
+	url := strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupCharts}, "")
+
+	// The first chart takes the only lc-renderer slot until it times out.
+	firstBody := verticalAndLineChartRequest(t)
+	firstDone := make(chan struct{})
+
+	go func() {
+		defer close(firstDone)
+
+		r, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader(firstBody))
+		if err != nil {
+			t.Errorf("unable to prepare HTTP request: %s", err)
+
+			return
+		}
+
+		router.ServeHTTP(httptest.NewRecorder(), r)
+	}()
+
+	for tre.chartRendererServer.Requests() == 0 {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("chart is not rendered")
+		case <-time.After(time.Millisecond * 10):
+		}
+	}
+
+	// Another chart is shed since the wait queue is disabled.
+	secondBody, err := json.Marshal(testutils.NewJSONCreateChartRequest().
+		SetSizes().
+		SetMargins().
+		SetBandBottomAxis().
+		SetLinearLeftAxis().
+		AddLineView().
+		Unembed())
+	if err != nil {
+		t.Fatalf("unable to marshal request body: %s", err)
+	}
+
+	w := httptest.NewRecorder()
+
+	r, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader(secondBody))
+	if err != nil {
+		t.Fatalf("unable to prepare HTTP request: %s", err)
+	}
+
+	router.ServeHTTP(w, r)
+
+	resp := w.Result()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unable to read response body: %s", err)
+	}
+
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	assert.Equal(t, `{"error":{"message":"Renderer is overloaded, try again later"}}`+"\n", string(body))
+	assert.Equal(t, 1, tre.chartRendererServer.Requests())
+
+	<-firstDone
+}
+
 func TestCreateChart_ErrNoAxes(t *testing.T) {
 	t.Parallel()
 
@@ -319,11 +493,15 @@ func TestCreateChart_ErrNoAxes(t *testing.T) {
 		rendererLatency:   time.Minute,
 	})
 
-	b, err := backend.NewBackend(ctx, config.RendererConfig{
-		Address:               tre.address(),
-		ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
-		RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
-	})
+	b, err := backend.NewBackend(ctx, config.Config{
+		Renderer: config.RendererConfig{
+			Address:               tre.address(),
+			ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
+			RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
+		},
+		Storage:   config.StorageConfig{Kind: config.StorageKindMemory},
+		RateLimit: config.RateLimitConfig{Key: config.RateLimitKeyIP},
+	}, metric.NewEmptyRecorder())
 	if err != nil {
 		t.Fatalf("unable to configure backend: %s", err)
 	}
@@ -372,11 +550,15 @@ func TestCreateChart_ErrBadJSON(t *testing.T) {
 		rendererLatency:   time.Minute,
 	})
 
-	b, err := backend.NewBackend(ctx, config.RendererConfig{
-		Address:               tre.address(),
-		ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
-		RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
-	})
+	b, err := backend.NewBackend(ctx, config.Config{
+		Renderer: config.RendererConfig{
+			Address:               tre.address(),
+			ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
+			RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
+		},
+		Storage:   config.StorageConfig{Kind: config.StorageKindMemory},
+		RateLimit: config.RateLimitConfig{Key: config.RateLimitKeyIP},
+	}, metric.NewEmptyRecorder())
 	if err != nil {
 		t.Fatalf("unable to configure backend: %s", err)
 	}
@@ -410,3 +592,86 @@ func TestCreateChart_ErrBadJSON(t *testing.T) {
 	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
 	assert.Equal(t, `{"error":{"message":"Unable to decode create chart JSON: unexpected EOF"}}`+"\n", string(body))
 }
+
+func TestCreateChart_Async(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingRendererEnvTimeoutSecs)
+	defer cancel()
+
+	tre := newTestingRendererEnv(ctx, t, testingRendererEnvOpts{
+		rendererChartData: []byte(`<svg>async</svg>`),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 100,
+	})
+
+	renderQueueCfg := config.RenderQueueConfig{Workers: 1, Size: 1}
+
+	b, err := backend.NewBackend(ctx, config.Config{
+		Renderer: config.RendererConfig{
+			Address:               tre.address(),
+			ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
+			RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
+		},
+		Storage:     config.StorageConfig{Kind: config.StorageKindMemory},
+		RenderQueue: renderQueueCfg,
+		RateLimit:   config.RateLimitConfig{Key: config.RateLimitKeyIP},
+	}, metric.NewEmptyRecorder())
+	if err != nil {
+		t.Fatalf("unable to configure backend: %s", err)
+	}
+
+	log := zerolog.New(os.Stderr)
+
+	go func() {
+		if serveErr := renderer.NewWorkers(&log, b.RenderQueue(), renderQueueCfg).Serve(ctx); serveErr != nil {
+			t.Errorf("unable to serve render workers: %s", serveErr)
+		}
+	}()
+
+	router := chi.NewRouter()
+	router.Route(serverhttp.GroupV0, func(router chi.Router) {
+		router.Mount(serverhttp.GroupCharts, chart.Routes(&log, b, metric.NewEmptyRecorder()))
+	})
+
+	doRequest := func(method, url string, body []byte) (*http.Response, *view.ChartReply) {
+		w := httptest.NewRecorder()
+
+		r, err := http.NewRequestWithContext(context.Background(), method, url, bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("unable to prepare HTTP request: %s", err)
+		}
+
+		router.ServeHTTP(w, r)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		chartResp := struct {
+			Chart *view.ChartReply `json:"chart"`
+		}{}
+
+		if err := json.NewDecoder(resp.Body).Decode(&chartResp); err != nil {
+			t.Fatalf("unable to decode response body: %s", err)
+		}
+
+		return resp, chartResp.Chart
+	}
+
+	url := strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupCharts}, "")
+
+	resp, pending := doRequest(http.MethodPost, url+"?async=true", verticalAndLineChartRequest(t))
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, url+"/"+pending.ChartID, resp.Header.Get("Location"))
+	assert.Equal(t, view.ChartStatusPending.String(), pending.ChartStatus)
+	assert.Empty(t, pending.ChartData)
+
+	assert.Eventually(t, func() bool {
+		_, created := doRequest(http.MethodGet, resp.Header.Get("Location"), nil)
+
+		return created.ChartStatus == view.ChartStatusCreated.String() && created.ChartData == "PHN2Zz5hc3luYzwvc3ZnPg=="
+	}, time.Second*2, time.Millisecond*50)
+
+	resp, _ = doRequest(http.MethodPost, url+"?async=maybe", verticalAndLineChartRequest(t))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
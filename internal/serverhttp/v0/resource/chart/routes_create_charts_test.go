@@ -0,0 +1,184 @@
+package chart_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/serverhttp"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/resource/chart"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/view"
+	"github.com/limpidchart/lc-api/internal/testutils"
+)
+
+func TestCreateCharts(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingRendererEnvTimeoutSecs)
+	defer cancel()
+
+	tre := newTestingRendererEnv(ctx, t, testingRendererEnvOpts{
+		rendererChartData: []byte(`<svg>batch</svg>`),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 50,
+	})
+
+	b, err := backend.NewBackend(ctx, config.Config{
+		Renderer: config.RendererConfig{
+			Address:               tre.address(),
+			ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
+			RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
+		},
+		Storage:   config.StorageConfig{Kind: config.StorageKindMemory},
+		Batch:     config.BatchConfig{Parallelism: 2, MaxSize: 3},
+		RateLimit: config.RateLimitConfig{Key: config.RateLimitKeyIP},
+	}, metric.NewEmptyRecorder())
+	if err != nil {
+		t.Fatalf("unable to configure backend: %s", err)
+	}
+
+	log := zerolog.New(os.Stderr)
+	router := chi.NewRouter()
+	router.Route(serverhttp.GroupV0, func(router chi.Router) {
+		router.Mount(serverhttp.GroupCharts, chart.Routes(&log, b, metric.NewEmptyRecorder()))
+		router.Mount(serverhttp.GroupChartsBatch, chart.BatchRoutes(&log, b, metric.NewEmptyRecorder()))
+	})
+
+	reqBody := []json.RawMessage{
+		verticalAndLineChartRequest(t),
+		noAxesChartRequest(t),
+		verticalAndLineChartRequest(t),
+	}
+
+	reqBodyJSON, err := json.Marshal(reqBody)
+	if err != nil {
+		t.Fatalf("unable to marshal request body: %s", err)
+	}
+
+	w := httptest.NewRecorder()
+	url := strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupChartsBatch}, "")
+
+	r, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader(reqBodyJSON))
+	if err != nil {
+		t.Fatalf("unable to prepare HTTP request: %s", err)
+	}
+
+	router.ServeHTTP(w, r)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	results := make(map[int]*view.CreateChartsReply)
+	scanner := bufio.NewScanner(resp.Body)
+
+	for scanner.Scan() {
+		res := &view.CreateChartsReply{}
+		if err := json.Unmarshal(scanner.Bytes(), res); err != nil {
+			t.Fatalf("unable to unmarshal the response line: %s", err)
+		}
+
+		results[res.Index] = res
+	}
+
+	assert.Len(t, results, len(reqBody))
+
+	for _, idx := range []int{0, 2} {
+		assert.NotEmpty(t, results[idx].RequestID)
+		assert.Empty(t, results[idx].ErrorMessage)
+		assert.Equal(t, view.ChartStatusCreated.String(), results[idx].Chart.ChartStatus)
+		assert.Equal(t, "PHN2Zz5iYXRjaDwvc3ZnPg==", results[idx].Chart.ChartData)
+	}
+
+	assert.NotEqual(t, results[0].Chart.ChartID, results[2].Chart.ChartID)
+	assert.Nil(t, results[1].Chart)
+	assert.Equal(t, "Unable to render a chart: unable to validate chart axes: chart axes are not specified", results[1].ErrorMessage)
+}
+
+func TestCreateCharts_BadRequest(t *testing.T) {
+	t.Parallel()
+
+	log := zerolog.New(os.Stderr)
+	router := chi.NewRouter()
+	router.Route(serverhttp.GroupV0, func(router chi.Router) {
+		router.Mount(serverhttp.GroupChartsBatch, chart.BatchRoutes(&log, backend.NewEmptyBackend(true), metric.NewEmptyRecorder()))
+	})
+
+	tt := []struct {
+		name     string
+		url      string
+		body     string
+		expected string
+	}{
+		{
+			"not_array",
+			"",
+			`{"chart":{}}`,
+			`{"error":{"message":"Unable to decode create charts JSON: json: cannot unmarshal object into Go value of type []*view.CreateChartRequest"}}`,
+		},
+		{
+			"empty_batch",
+			"",
+			`[]`,
+			`{"error":{"message":"Unable to use the provided create charts parameters: bad batch size: batch should contain from 1 to 1 charts, got 0"}}`,
+		},
+		{
+			"too_big_batch",
+			"",
+			`[{},{}]`,
+			`{"error":{"message":"Unable to use the provided create charts parameters: bad batch size: batch should contain from 1 to 1 charts, got 2"}}`,
+		},
+		{
+			"bad_async",
+			"?async=maybe",
+			`[{}]`,
+			`{"error":{"message":"Unable to use the provided create charts parameters: async value is bad: strconv.ParseBool: parsing \"maybe\": invalid syntax"}}`,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			url := strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupChartsBatch, tc.url}, "")
+
+			r, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("unable to prepare HTTP request: %s", err)
+			}
+
+			router.ServeHTTP(w, r)
+
+			resp := w.Result()
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unable to read response body: %s", err)
+			}
+
+			resp.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			assert.JSONEq(t, tc.expected, string(body))
+		})
+	}
+}
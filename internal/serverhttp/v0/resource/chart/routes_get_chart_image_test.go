@@ -0,0 +1,157 @@
+package chart_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/serverhttp"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/resource/chart"
+	"github.com/limpidchart/lc-api/internal/testutils"
+)
+
+func TestGetChartImage(t *testing.T) {
+	t.Parallel()
+
+	b := backend.NewEmptyBackend(true)
+	ts := time.Now().UTC()
+	chartData := []byte(`<svg>vertical_and_line</svg>`)
+	etag := `"edd3a8d18bf500cb970293d7e7a6b447ee85957b6ddd3b380f856a2302917fdb"`
+
+	createdChartID := testutils.RandomUUID(t).String()
+	expiringChartID := testutils.RandomUUID(t).String()
+	deletedChartID := testutils.RandomUUID(t).String()
+
+	for _, chartReply := range []*render.ChartReply{
+		{
+			ChartId:     createdChartID,
+			ChartStatus: render.ChartStatus_CREATED,
+			CreatedAt:   timestamppb.New(ts),
+			ChartData:   chartData,
+		},
+		{
+			ChartId:     expiringChartID,
+			ChartStatus: render.ChartStatus_CREATED,
+			CreatedAt:   timestamppb.New(ts),
+			ChartData:   chartData,
+			ExpiresAt:   timestamppb.New(ts.Add(time.Minute * 10)),
+		},
+		{
+			ChartId:     deletedChartID,
+			ChartStatus: render.ChartStatus_DELETED,
+			CreatedAt:   timestamppb.New(ts),
+			DeletedAt:   timestamppb.New(ts),
+		},
+	} {
+		if err := b.Storage().SaveChart(context.Background(), chartReply); err != nil {
+			t.Fatalf("unable to save testing chart: %s", err)
+		}
+	}
+
+	log := zerolog.New(os.Stderr)
+	router := chi.NewRouter()
+	router.Route(serverhttp.GroupV0, func(router chi.Router) {
+		router.Mount(serverhttp.GroupCharts, chart.Routes(&log, b, metric.NewEmptyRecorder()))
+	})
+
+	tt := []struct {
+		name                 string
+		chartID              string
+		ifNoneMatch          string
+		expectedCode         int
+		expectedContentType  string
+		expectedCacheControl string // regexp
+		expectedBody         []byte
+	}{
+		{
+			"created",
+			createdChartID,
+			"",
+			http.StatusOK,
+			"image/svg+xml",
+			`^private, max-age=3600$`,
+			chartData,
+		},
+		{
+			"not_modified",
+			createdChartID,
+			`"other", ` + etag,
+			http.StatusNotModified,
+			"",
+			`^private, max-age=3600$`,
+			[]byte{},
+		},
+		{
+			"expiring",
+			expiringChartID,
+			"",
+			http.StatusOK,
+			"image/svg+xml",
+			`^private, max-age=(5\d\d|600)$`, // max age depends on the current time
+			chartData,
+		},
+		{
+			"deleted",
+			deletedChartID,
+			"",
+			http.StatusNotFound,
+			"application/json",
+			"",
+			[]byte(fmt.Sprintf(`{"error":{"id":"%s","message":"chart not found"}}`+"\n", deletedChartID)),
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			url := fmt.Sprintf("%s%s/%s/image", serverhttp.GroupV0, serverhttp.GroupCharts, tc.chartID)
+
+			r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+			if err != nil {
+				t.Fatalf("unable to prepare HTTP request: %s", err)
+			}
+
+			if tc.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+
+			router.ServeHTTP(w, r)
+
+			resp := w.Result()
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unable to read response body: %s", err)
+			}
+
+			resp.Body.Close()
+
+			assert.Equal(t, tc.expectedCode, resp.StatusCode)
+			assert.Equal(t, tc.expectedContentType, resp.Header.Get("Content-Type"))
+			assert.Equal(t, tc.expectedBody, body)
+
+			if tc.expectedCode == http.StatusNotFound {
+				return
+			}
+
+			assert.Equal(t, etag, resp.Header.Get("ETag"))
+			assert.Regexp(t, tc.expectedCacheControl, resp.Header.Get("Cache-Control"))
+		})
+	}
+}
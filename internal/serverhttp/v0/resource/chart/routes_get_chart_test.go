@@ -2,24 +2,93 @@ package chart_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/limpidchart/lc-api/internal/backend"
 	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
 	"github.com/limpidchart/lc-api/internal/serverhttp"
 	"github.com/limpidchart/lc-api/internal/serverhttp/v0/resource/chart"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/view"
 	"github.com/limpidchart/lc-api/internal/testutils"
 )
 
+func TestGetChart_OK(t *testing.T) {
+	t.Parallel()
+
+	b := backend.NewEmptyBackend(true)
+	chartID := testutils.RandomUUID(t).String()
+	ts := time.Date(2021, 8, 22, 10, 20, 30, 0, time.UTC)
+
+	err := b.Storage().SaveChart(context.Background(), &render.ChartReply{
+		RequestId:   testutils.RandomUUID(t).String(),
+		ChartId:     chartID,
+		ChartStatus: render.ChartStatus_CREATED,
+		CreatedAt:   timestamppb.New(ts),
+		DeletedAt:   timestamppb.New(ts),
+		ChartData:   []byte(`<svg>vertical_and_line</svg>`),
+	})
+	if err != nil {
+		t.Fatalf("unable to save testing chart: %s", err)
+	}
+
+	log := zerolog.New(os.Stderr)
+	router := chi.NewRouter()
+	router.Route(serverhttp.GroupV0, func(router chi.Router) {
+		router.Mount(serverhttp.GroupCharts, chart.Routes(&log, b, metric.NewEmptyRecorder()))
+	})
+
+	w := httptest.NewRecorder()
+	url := fmt.Sprintf("%s%s/%s", serverhttp.GroupV0, serverhttp.GroupCharts, chartID)
+
+	r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("unable to prepare HTTP request: %s", err)
+	}
+
+	router.ServeHTTP(w, r)
+
+	resp := w.Result()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unable to read response body: %s", err)
+	}
+
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	type respChart struct {
+		Chart *view.ChartReply `json:"chart"`
+	}
+
+	respBody := respChart{}
+
+	if err = json.Unmarshal(body, &respBody); err != nil {
+		t.Fatalf("unable to unmarshal the response body: %s", err)
+	}
+
+	assert.NotEmpty(t, respBody.Chart.RequestID)
+	assert.Equal(t, chartID, respBody.Chart.ChartID)
+	assert.Equal(t, view.ChartStatusCreated.String(), respBody.Chart.ChartStatus)
+	assert.Equal(t, ts, *respBody.Chart.CreatedAt)
+	assert.Equal(t, "PHN2Zz52ZXJ0aWNhbF9hbmRfbGluZTwvc3ZnPg==", respBody.Chart.ChartData)
+}
+
 func TestGetChart_NotFound(t *testing.T) {
 	t.Parallel()
 
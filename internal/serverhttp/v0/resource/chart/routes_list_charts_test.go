@@ -2,34 +2,71 @@ package chart_test
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/limpidchart/lc-api/internal/backend"
 	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
 	"github.com/limpidchart/lc-api/internal/serverhttp"
 	"github.com/limpidchart/lc-api/internal/serverhttp/v0/resource/chart"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/view"
+	"github.com/limpidchart/lc-api/internal/testutils"
 )
 
-func TestListCharts_Unimplemented(t *testing.T) {
-	t.Parallel()
+type respChartsList struct {
+	RequestID     string             `json:"request_id"`
+	Charts        []*view.ChartReply `json:"charts"`
+	NextPageToken string             `json:"next_page_token"`
+}
 
-	log := zerolog.New(os.Stderr)
-	router := chi.NewRouter()
-	router.Route(serverhttp.GroupV0, func(router chi.Router) {
-		router.Mount(serverhttp.GroupCharts, chart.Routes(&log, backend.NewEmptyBackend(true), metric.NewEmptyRecorder()))
-	})
+func listChartsBackend(t *testing.T) (*backend.EmptyBackend, []string) {
+	t.Helper()
+
+	b := backend.NewEmptyBackend(true)
+	ts := time.Date(2021, 8, 22, 10, 0, 0, 0, time.UTC)
+	titles := []string{"Sales 2020", "Costs 2020", "Sales 2021"}
+	chartIDs := make([]string, 0, len(titles))
+
+	for i, title := range titles {
+		chartID := testutils.RandomUUID(t).String()
+
+		err := b.Storage().SaveChart(context.Background(), &render.ChartReply{
+			RequestId:   testutils.RandomUUID(t).String(),
+			ChartId:     chartID,
+			ChartStatus: render.ChartStatus_CREATED,
+			CreatedAt:   timestamppb.New(ts.Add(time.Duration(i) * time.Minute)),
+			DeletedAt:   timestamppb.New(ts.Add(time.Duration(i) * time.Minute)),
+			ChartData:   []byte("<svg></svg>"),
+			Title:       title,
+		})
+		if err != nil {
+			t.Fatalf("unable to save testing chart: %s", err)
+		}
+
+		chartIDs = append(chartIDs, chartID)
+	}
+
+	return b, chartIDs
+}
+
+func listCharts(t *testing.T, router chi.Router, query string) (*http.Response, []byte) {
+	t.Helper()
 
 	w := httptest.NewRecorder()
-	url := strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupCharts}, "")
+	url := strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupCharts, query}, "")
 
 	r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
 	if err != nil {
@@ -47,7 +84,121 @@ func TestListCharts_Unimplemented(t *testing.T) {
 
 	resp.Body.Close()
 
-	assert.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+	return resp, body
+}
+
+func TestListCharts_OK(t *testing.T) {
+	t.Parallel()
+
+	b, chartIDs := listChartsBackend(t)
+
+	log := zerolog.New(os.Stderr)
+	router := chi.NewRouter()
+	router.Route(serverhttp.GroupV0, func(router chi.Router) {
+		router.Mount(serverhttp.GroupCharts, chart.Routes(&log, b, metric.NewEmptyRecorder()))
+	})
+
+	resp, body := listCharts(t, router, "?page_size=1&title=sales&chart_status=CREATED&created_after=2021-08-22T10:00:00Z")
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
 	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
-	assert.Equal(t, `{"error":{"message":"List of charts handler is not implemented yet"}}`+"\n", string(body))
+
+	firstPage := respChartsList{}
+
+	if err := json.Unmarshal(body, &firstPage); err != nil {
+		t.Fatalf("unable to unmarshal the response body: %s", err)
+	}
+
+	assert.NotEmpty(t, firstPage.RequestID)
+	assert.NotEmpty(t, firstPage.NextPageToken)
+	assert.Len(t, firstPage.Charts, 1)
+	assert.Equal(t, chartIDs[2], firstPage.Charts[0].ChartID)
+	assert.Equal(t, "Sales 2021", firstPage.Charts[0].Title)
+	assert.Equal(t, firstPage.RequestID, firstPage.Charts[0].RequestID)
+	assert.Empty(t, firstPage.Charts[0].ChartData)
+
+	resp, body = listCharts(t, router, fmt.Sprintf("?page_size=1&title=sales&page_token=%s", firstPage.NextPageToken))
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	secondPage := respChartsList{}
+
+	if err := json.Unmarshal(body, &secondPage); err != nil {
+		t.Fatalf("unable to unmarshal the response body: %s", err)
+	}
+
+	assert.Empty(t, secondPage.NextPageToken)
+	assert.Len(t, secondPage.Charts, 1)
+	assert.Equal(t, chartIDs[0], secondPage.Charts[0].ChartID)
+}
+
+func TestListCharts_Empty(t *testing.T) {
+	t.Parallel()
+
+	log := zerolog.New(os.Stderr)
+	router := chi.NewRouter()
+	router.Route(serverhttp.GroupV0, func(router chi.Router) {
+		router.Mount(serverhttp.GroupCharts, chart.Routes(&log, backend.NewEmptyBackend(true), metric.NewEmptyRecorder()))
+	})
+
+	resp, body := listCharts(t, router, "")
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Contains(t, string(body), `"charts":[],"next_page_token":""}`)
+}
+
+func TestListCharts_BadParams(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name         string
+		query        string
+		expectedBody string
+	}{
+		{
+			"bad_page_size",
+			"?page_size=many",
+			`{"error":{"message":"Unable to use the provided list charts parameters: page_size value is bad: strconv.ParseInt: parsing \"many\": invalid syntax"}}` + "\n",
+		},
+		{
+			"too_big_page_size",
+			"?page_size=1000",
+			`{"error":{"message":"Unable to use the provided list charts parameters: page size should be between 1 and 100"}}` + "\n",
+		},
+		{
+			"bad_page_token",
+			"?page_token=abc",
+			`{"error":{"message":"Unable to use the provided list charts parameters: page token is not valid"}}` + "\n",
+		},
+		{
+			"bad_created_after",
+			"?created_after=yesterday",
+			`{"error":{"message":"Unable to use the provided list charts parameters: created_after value is bad: parsing time \"yesterday\" as \"2006-01-02T15:04:05.999999999Z07:00\": cannot parse \"yesterday\" as \"2006\""}}` + "\n",
+		},
+		{
+			"bad_chart_status",
+			"?chart_status=DONE",
+			`{"error":{"message":"Unable to use the provided list charts parameters: chart_status value is bad: unknown chart status: DONE"}}` + "\n",
+		},
+	}
+
+	log := zerolog.New(os.Stderr)
+	router := chi.NewRouter()
+	router.Route(serverhttp.GroupV0, func(router chi.Router) {
+		router.Mount(serverhttp.GroupCharts, chart.Routes(&log, backend.NewEmptyBackend(true), metric.NewEmptyRecorder()))
+	})
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp, body := listCharts(t, router, tc.query)
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+			assert.Equal(t, tc.expectedBody, string(body))
+		})
+	}
 }
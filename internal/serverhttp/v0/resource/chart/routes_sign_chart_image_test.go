@@ -0,0 +1,245 @@
+package chart_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/serverhttp"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/middleware"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/resource/chart"
+	"github.com/limpidchart/lc-api/internal/testutils"
+)
+
+type testingSignedChartImage struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func TestRoutes_SignedChartImage(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingRendererEnvTimeoutSecs)
+	defer cancel()
+
+	tre := newTestingRendererEnv(ctx, t, testingRendererEnvOpts{
+		rendererChartData: []byte(`<svg></svg>`),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+	})
+
+	apiKeysPath := filepath.Join(t.TempDir(), "api-keys")
+	apiKeys := fmt.Sprintf("reporting %s charts:read\n", auth.HashAPIKey("reporting-key"))
+
+	if err := os.WriteFile(apiKeysPath, []byte(apiKeys), 0o600); err != nil {
+		t.Fatalf("unable to write API keys file: %s", err)
+	}
+
+	b, err := backend.NewBackend(ctx, config.Config{
+		Renderer: config.RendererConfig{
+			Address:               tre.address(),
+			ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
+			RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
+		},
+		Storage: config.StorageConfig{Kind: config.StorageKindMemory},
+		Auth:    config.AuthConfig{APIKeysPath: apiKeysPath},
+		Tenant: config.TenantConfig{
+			TrustedHeader: testingTenantHeader,
+		},
+		RateLimit: config.RateLimitConfig{Key: config.RateLimitKeyIP},
+		SignedURL: config.SignedURLConfig{
+			Keys:              "v1:0123456789abcdef",
+			DefaultTTLSeconds: 600,
+			MaxTTLSeconds:     3600,
+		},
+		ClientIP: config.ClientIPConfig{TrustedProxies: testingTrustedProxies},
+	}, metric.NewEmptyRecorder())
+	if err != nil {
+		t.Fatalf("unable to configure backend: %s", err)
+	}
+
+	createdChartID := testutils.RandomUUID(t).String()
+	deletedChartID := testutils.RandomUUID(t).String()
+
+	for _, chartReply := range []*render.ChartReply{
+		{
+			ChartId:     createdChartID,
+			ChartStatus: render.ChartStatus_CREATED,
+			CreatedAt:   timestamppb.Now(),
+			ChartData:   []byte(`<svg height="300" width="600" xmlns="http://www.w3.org/2000/svg"><rect width="10"/></svg>`),
+			Tenant:      "acme",
+		},
+		{
+			ChartId:     deletedChartID,
+			ChartStatus: render.ChartStatus_DELETED,
+			CreatedAt:   timestamppb.Now(),
+			DeletedAt:   timestamppb.Now(),
+			Tenant:      "acme",
+		},
+	} {
+		if err = b.Storage().SaveChart(ctx, chartReply); err != nil {
+			t.Fatalf("unable to save testing chart: %s", err)
+		}
+	}
+
+	log := zerolog.New(os.Stderr)
+	router := chi.NewRouter()
+	router.Route(serverhttp.GroupV0, func(router chi.Router) {
+		router.Mount(serverhttp.GroupCharts, chart.Routes(&log, b, metric.NewEmptyRecorder()))
+	})
+
+	chartsURL := strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupCharts}, "")
+
+	do := func(t *testing.T, method, target, body string, authenticated bool) (int, string) {
+		t.Helper()
+
+		w := httptest.NewRecorder()
+
+		r, reqErr := http.NewRequestWithContext(ctx, method, target, strings.NewReader(body))
+		if reqErr != nil {
+			t.Fatalf("unable to prepare HTTP request: %s", reqErr)
+		}
+
+		r.RemoteAddr = testingProxyAddr
+
+		if authenticated {
+			r.Header.Set(middleware.APIKeyHeader, "reporting-key")
+			r.Header.Set(testingTenantHeader, "acme")
+		}
+
+		router.ServeHTTP(w, r)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		respBody, readErr := io.ReadAll(resp.Body)
+		if readErr != nil {
+			t.Fatalf("unable to read response body: %s", readErr)
+		}
+
+		return resp.StatusCode, string(respBody)
+	}
+
+	code, body := do(t, http.MethodPost, chartsURL+"/"+createdChartID+"/image:sign", `{"image": {"size": "small", "expires_in": 60}}`, true)
+	assert.Equal(t, http.StatusCreated, code)
+
+	signed := testingSignedChartImage{}
+	if err = json.Unmarshal([]byte(body), &signed); err != nil {
+		t.Fatalf("unable to decode signed chart image: %s", err)
+	}
+
+	assert.WithinDuration(t, time.Now().Add(time.Minute), signed.ExpiresAt, time.Second*2)
+
+	signedURL, err := url.Parse(signed.URL)
+	if err != nil {
+		t.Fatalf("unable to parse signed URL: %s", err)
+	}
+
+	assert.Equal(t, chartsURL+"/"+createdChartID+"/image", signedURL.Path)
+
+	tamper := func(param, value string) string {
+		query := signedURL.Query()
+		if value == "" {
+			query.Del(param)
+		} else {
+			query.Set(param, value)
+		}
+
+		return signedURL.Path + "?" + query.Encode()
+	}
+
+	t.Run("signed_url", func(t *testing.T) {
+		code, body := do(t, http.MethodGet, signed.URL, "", false)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, `<svg height="160" width="320" xmlns="http://www.w3.org/2000/svg" viewBox="0 0 600 300"><rect width="10"/></svg>`, body)
+	})
+
+	t.Run("tampered_size", func(t *testing.T) {
+		code, body := do(t, http.MethodGet, tamper("size", "large"), "", false)
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.Equal(t, `{"error":{"message":"Forbidden: bad signed URL signature: signature mismatch"}}`+"\n", body)
+	})
+
+	t.Run("tampered_tenant", func(t *testing.T) {
+		code, _ := do(t, http.MethodGet, tamper("tenant", ""), "", false)
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+
+	t.Run("unsigned_url", func(t *testing.T) {
+		code, _ := do(t, http.MethodGet, chartsURL+"/"+createdChartID+"/image", "", false)
+		assert.Equal(t, http.StatusUnauthorized, code)
+	})
+
+	t.Run("authenticated_size", func(t *testing.T) {
+		code, body := do(t, http.MethodGet, chartsURL+"/"+createdChartID+"/image?size=medium", "", true)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, `<svg height="320" width="640" xmlns="http://www.w3.org/2000/svg" viewBox="0 0 600 300"><rect width="10"/></svg>`, body)
+	})
+
+	t.Run("authenticated_bad_size", func(t *testing.T) {
+		code, body := do(t, http.MethodGet, chartsURL+"/"+createdChartID+"/image?size=huge", "", true)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, `{"error":{"message":"Unable to use the provided image size: unknown image size \"huge\", it should be one of: small, medium, large"}}`+"\n", body)
+	})
+
+	t.Run("sign_unauthenticated", func(t *testing.T) {
+		code, _ := do(t, http.MethodPost, chartsURL+"/"+createdChartID+"/image:sign", `{}`, false)
+		assert.Equal(t, http.StatusUnauthorized, code)
+	})
+
+	t.Run("sign_default_ttl", func(t *testing.T) {
+		code, body := do(t, http.MethodPost, chartsURL+"/"+createdChartID+"/image:sign", "", true)
+		assert.Equal(t, http.StatusCreated, code)
+		assert.Contains(t, body, `"url":"/v0/charts/`+createdChartID+`/image?exp=`)
+	})
+
+	t.Run("sign_bad_ttl", func(t *testing.T) {
+		code, body := do(t, http.MethodPost, chartsURL+"/"+createdChartID+"/image:sign", `{"image": {"expires_in": 7200}}`, true)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, `{"error":{"message":"Unable to sign chart image URL: bad signed URL TTL: it should be from 1s to 1h0m0s"}}`+"\n", body)
+	})
+
+	t.Run("sign_deleted_chart", func(t *testing.T) {
+		code, _ := do(t, http.MethodPost, chartsURL+"/"+deletedChartID+"/image:sign", `{}`, true)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("sign_other_tenant_chart", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		r, reqErr := http.NewRequestWithContext(ctx, http.MethodPost, chartsURL+"/"+createdChartID+"/image:sign", strings.NewReader(`{}`))
+		if reqErr != nil {
+			t.Fatalf("unable to prepare HTTP request: %s", reqErr)
+		}
+
+		r.RemoteAddr = testingProxyAddr
+		r.Header.Set(middleware.APIKeyHeader, "reporting-key")
+		r.Header.Set(testingTenantHeader, "globex")
+
+		router.ServeHTTP(w, r)
+
+		resp := w.Result()
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,282 @@
+package chart_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/backend"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/serverhttp"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/middleware"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/resource/chart"
+	"github.com/limpidchart/lc-api/internal/testutils"
+)
+
+const (
+	testingTenantHeader   = "X-Tenant-Id"
+	testingTrustedProxies = "10.0.0.0/8"
+	testingProxyAddr      = "10.0.0.1:1234"
+	testingClientAddr     = "192.0.2.1:1234"
+)
+
+func TestRoutes_TenantIsolation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingRendererEnvTimeoutSecs)
+	defer cancel()
+
+	tre := newTestingRendererEnv(ctx, t, testingRendererEnvOpts{
+		rendererChartData: []byte(`<svg></svg>`),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+	})
+
+	overridesPath := filepath.Join(t.TempDir(), "tenants.json")
+	if err := os.WriteFile(overridesPath, []byte(`{"narrow": {"max_width": 800}, "cheap": {"max_request_cost": 1}, "broke": {"cost_budget": 1}}`), 0o600); err != nil {
+		t.Fatalf("unable to write tenant overrides file: %s", err)
+	}
+
+	b, err := backend.NewBackend(ctx, config.Config{
+		Renderer: config.RendererConfig{
+			Address:               tre.address(),
+			ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
+			RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
+		},
+		Storage: config.StorageConfig{Kind: config.StorageKindMemory},
+		Tenant: config.TenantConfig{
+			TrustedHeader: testingTenantHeader,
+			OverridesPath: overridesPath,
+		},
+		RateLimit: config.RateLimitConfig{Key: config.RateLimitKeyIP},
+		Cost:      config.CostConfig{BudgetWindowSeconds: 3600},
+		ClientIP: config.ClientIPConfig{
+			TrustedProxies: testingTrustedProxies,
+		},
+	}, metric.NewEmptyRecorder())
+	if err != nil {
+		t.Fatalf("unable to configure backend: %s", err)
+	}
+
+	log := zerolog.New(os.Stderr)
+	router := chi.NewRouter()
+	router.Route(serverhttp.GroupV0, func(router chi.Router) {
+		router.Mount(serverhttp.GroupCharts, chart.Routes(&log, b, metric.NewEmptyRecorder()))
+	})
+
+	chartsURL := strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupCharts}, "")
+
+	do := func(method, url, tenant string, body []byte) (int, []byte) {
+		w := httptest.NewRecorder()
+
+		r, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("unable to prepare HTTP request: %s", err)
+		}
+
+		r.RemoteAddr = testingProxyAddr
+		r.Header.Set("Content-Type", "application/json")
+
+		if tenant != "" {
+			r.Header.Set(testingTenantHeader, tenant)
+		}
+
+		router.ServeHTTP(w, r)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("unable to read response body: %s", err)
+		}
+
+		return resp.StatusCode, respBody
+	}
+
+	code, body := do(http.MethodPost, chartsURL, "acme", verticalAndLineChartRequest(t))
+	if !assert.Equal(t, http.StatusCreated, code, string(body)) {
+		return
+	}
+
+	created := struct {
+		Chart struct {
+			ChartID string `json:"chart_id"`
+			Tenant  string `json:"tenant"`
+			Cost    int64  `json:"cost"`
+		} `json:"chart"`
+	}{}
+	if err := json.Unmarshal(body, &created); err != nil {
+		t.Fatalf("unable to unmarshal created chart: %s", err)
+	}
+
+	assert.Equal(t, "acme", created.Chart.Tenant)
+	assert.NotZero(t, created.Chart.Cost)
+
+	chartURL := strings.Join([]string{chartsURL, "/", created.Chart.ChartID}, "")
+
+	code, _ = do(http.MethodGet, chartURL, "acme", nil)
+	assert.Equal(t, http.StatusOK, code)
+
+	code, _ = do(http.MethodGet, chartURL, "globex", nil)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, _ = do(http.MethodGet, chartURL, "", nil)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, _ = do(http.MethodDelete, chartURL, "globex", nil)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, body = do(http.MethodGet, chartsURL, "globex", nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, string(body), `"charts":[]`)
+
+	code, body = do(http.MethodGet, chartsURL, "acme", nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, string(body), created.Chart.ChartID)
+
+	wideWidth := 1000
+	wideReq := testutils.NewJSONCreateChartRequest().
+		SetSizes().
+		SetBandBottomAxis().
+		SetLinearLeftAxis().
+		AddVerticalBarView().
+		Unembed()
+	wideReq.Chart.Sizes.Width = &wideWidth
+
+	wideReqJSON, err := json.Marshal(wideReq)
+	if err != nil {
+		t.Fatalf("unable to marshal request body: %s", err)
+	}
+
+	code, body = do(http.MethodPost, chartsURL, "narrow", wideReqJSON)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, string(body), "chart size exceeds the limit: max width is 800")
+
+	code, body = do(http.MethodPost, chartsURL, "cheap", verticalAndLineChartRequest(t))
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, string(body), "chart render cost exceeds the limit")
+
+	code, body = do(http.MethodPost, chartsURL, "broke", verticalAndLineChartRequest(t))
+	assert.Equal(t, http.StatusTooManyRequests, code)
+	assert.Contains(t, string(body), "render cost budget is exhausted")
+
+	code, body = do(http.MethodGet, chartsURL, "bad tenant", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, string(body), "Unable to use the provided tenant")
+}
+
+func TestRoutes_TenantHeaderSpoofing(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*testingRendererEnvTimeoutSecs)
+	defer cancel()
+
+	tre := newTestingRendererEnv(ctx, t, testingRendererEnvOpts{
+		rendererChartData: []byte(`<svg></svg>`),
+		rendererFailMsg:   "",
+		rendererLatency:   time.Millisecond * 10,
+	})
+
+	b, err := backend.NewBackend(ctx, config.Config{
+		Renderer: config.RendererConfig{
+			Address:               tre.address(),
+			ConnTimeoutSeconds:    testutils.RendererConnTimeoutSecs,
+			RequestTimeoutSeconds: testutils.RendererRequestTimeoutSecs,
+		},
+		Storage: config.StorageConfig{Kind: config.StorageKindMemory},
+		Auth: config.AuthConfig{
+			APIKeysPath: testutils.APIKeysFile(t, map[string]string{"reporting": "reporting-key"}),
+		},
+		Tenant: config.TenantConfig{
+			TrustedHeader: testingTenantHeader,
+		},
+		RateLimit: config.RateLimitConfig{Key: config.RateLimitKeyIP},
+		ClientIP: config.ClientIPConfig{
+			TrustedProxies: testingTrustedProxies,
+		},
+	}, metric.NewEmptyRecorder())
+	if err != nil {
+		t.Fatalf("unable to configure backend: %s", err)
+	}
+
+	log := zerolog.New(os.Stderr)
+	router := chi.NewRouter()
+	router.Route(serverhttp.GroupV0, func(router chi.Router) {
+		router.Mount(serverhttp.GroupCharts, chart.Routes(&log, b, metric.NewEmptyRecorder()))
+	})
+
+	chartsURL := strings.Join([]string{serverhttp.GroupV0, serverhttp.GroupCharts}, "")
+
+	do := func(method, url, remoteAddr string, body []byte) (int, []byte) {
+		w := httptest.NewRecorder()
+
+		r, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("unable to prepare HTTP request: %s", err)
+		}
+
+		r.RemoteAddr = remoteAddr
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set(middleware.APIKeyHeader, "reporting-key")
+		r.Header.Set(testingTenantHeader, "acme")
+
+		router.ServeHTTP(w, r)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("unable to read response body: %s", err)
+		}
+
+		return resp.StatusCode, respBody
+	}
+
+	code, body := do(http.MethodPost, chartsURL, testingProxyAddr, verticalAndLineChartRequest(t))
+	if !assert.Equal(t, http.StatusCreated, code, string(body)) {
+		return
+	}
+
+	created := struct {
+		Chart struct {
+			ChartID string `json:"chart_id"`
+			Tenant  string `json:"tenant"`
+		} `json:"chart"`
+	}{}
+	if err := json.Unmarshal(body, &created); err != nil {
+		t.Fatalf("unable to unmarshal created chart: %s", err)
+	}
+
+	assert.Equal(t, "acme", created.Chart.Tenant)
+
+	chartURL := strings.Join([]string{chartsURL, "/", created.Chart.ChartID}, "")
+
+	// API key caller that isn't a trusted proxy can't choose the tenant by the header.
+	code, _ = do(http.MethodGet, chartURL, testingClientAddr, nil)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, _ = do(http.MethodDelete, chartURL, testingClientAddr, nil)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, body = do(http.MethodGet, chartsURL, testingClientAddr, nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.NotContains(t, string(body), created.Chart.ChartID)
+
+	code, _ = do(http.MethodGet, chartURL, testingProxyAddr, nil)
+	assert.Equal(t, http.StatusOK, code)
+}
@@ -11,6 +11,12 @@ const (
 
 	// ChartStatusError represents some error.
 	ChartStatusError ChartStatus = "ERROR"
+
+	// ChartStatusDeleted represents a deleted chart.
+	ChartStatusDeleted ChartStatus = "DELETED"
+
+	// ChartStatusPending represents a chart that is not rendered yet.
+	ChartStatusPending ChartStatus = "PENDING"
 )
 
 func (c ChartStatus) String() string {
@@ -43,7 +49,56 @@ type CreateChartRequest struct {
 		//
 		// required: true
 		Views []*ChartView `json:"views"`
+
+		// ExpiresIn represents number of seconds the chart should be kept in storage.
+		// Server default is used if it's not set.
+		ExpiresIn *int `json:"expires_in"`
+
+		// CallbackURL represents URL that receives the final chart representation once the chart is rendered or failed.
+		// Request is signed with HMAC-SHA256 signature in the X-Lc-Signature-256 header.
+		CallbackURL string `json:"callback_url"`
 	} `json:"chart"`
+
+	// Reply with a PENDING chart right away and render it in background.
+	// Chart status can be polled by chart ID until it's CREATED or ERROR.
+	//
+	// in: query
+	Async bool `json:"async"`
+}
+
+// CreateChartsRequest represents a request to create charts from the batch.
+// swagger:parameters createCharts
+type CreateChartsRequest struct {
+	// Create charts request body, every item is the same as the create chart request body.
+	//
+	// in: body
+	// required: true
+	Charts []*CreateChartRequest `json:"charts"`
+
+	// Reply with PENDING charts right away and render them in background.
+	//
+	// in: query
+	Async bool `json:"async"`
+}
+
+// CreateChartsReply represents a result of a single chart creation from the batch.
+// swagger:model createChartsReply
+type CreateChartsReply struct {
+	// ID of the request.
+	//
+	// swagger:strfmt uuid4
+	RequestID string `json:"request_id"`
+
+	// Index of the chart in the batch request.
+	Index int `json:"index"`
+
+	// Created chart.
+	// It's null if the chart creation failed.
+	Chart *ChartReply `json:"chart"`
+
+	// ErrorMessage contains reason of the chart creation failure.
+	// It's empty if the chart is created.
+	ErrorMessage string `json:"error_message"`
 }
 
 // GetChartRequest represents a request to get chart.
@@ -73,14 +128,131 @@ type ChartReply struct {
 	// Can be one of:
 	//  - CREATED
 	//  - ERROR
+	//  - DELETED
+	//  - PENDING
 	ChartStatus string `json:"chart_status"`
 
 	// CreatedAt contains chart creation timestamp.
 	CreatedAt *time.Time `json:"created_at"`
 
 	// DeletedAt contains chart deletion timestamp.
+	// It's null until the chart is deleted.
 	DeletedAt *time.Time `json:"deleted_at"`
 
 	// ChartData contains base64 chart representation.
 	ChartData string `json:"chart_data"`
+
+	// Title contains chart title.
+	Title string `json:"title"`
+
+	// ExpiresAt contains chart expiration timestamp.
+	// It's null if the chart never expires.
+	ExpiresAt *time.Time `json:"expires_at"`
+
+	// ErrorMessage contains reason of the chart rendering failure.
+	// It's set only for charts with ERROR status.
+	ErrorMessage string `json:"error_message"`
+
+	// Tenant that owns the chart.
+	// It's empty for charts of the default tenant.
+	Tenant string `json:"tenant"`
+
+	// Cost contains render cost score of the chart that is computed from its area, views and values.
+	Cost int64 `json:"cost"`
+}
+
+// ListChartsRequest represents a request to get charts list.
+// swagger:parameters listCharts
+type ListChartsRequest struct {
+	// Maximum number of charts in the reply.
+	// Default value is 20, max value is 100.
+	//
+	// in: query
+	PageSize int `json:"page_size"`
+
+	// Token from next_page_token of the previous reply.
+	//
+	// in: query
+	PageToken string `json:"page_token"`
+
+	// Return only charts that were created at or after this timestamp.
+	//
+	// in: query
+	// swagger:strfmt date-time
+	CreatedAfter string `json:"created_after"`
+
+	// Return only charts that were created before this timestamp.
+	//
+	// in: query
+	// swagger:strfmt date-time
+	CreatedBefore string `json:"created_before"`
+
+	// Return only charts with this status.
+	// Can be one of:
+	//  - CREATED
+	//  - ERROR
+	//  - DELETED
+	//  - PENDING
+	//
+	// in: query
+	ChartStatus string `json:"chart_status"`
+
+	// Return only charts with title that contains this substring.
+	//
+	// in: query
+	Title string `json:"title"`
+}
+
+// GetChartImageRequest represents a request to get raw chart image.
+// swagger:parameters getChartImage
+type GetChartImageRequest struct {
+	// Size variant of the image, the original image is returned if it's not set.
+	// Can be one of:
+	//  - small
+	//  - medium
+	//  - large
+	//
+	// in: query
+	Size string `json:"size"`
+
+	// Signed URL expiration unix timestamp.
+	// Signed URL parameters are added by signChartImage and don't need API credentials.
+	//
+	// in: query
+	Exp int64 `json:"exp"`
+
+	// Signed URL signature.
+	//
+	// in: query
+	Sig string `json:"sig"`
+
+	// Signed URL signing key ID.
+	//
+	// in: query
+	Kid string `json:"kid"`
+
+	// Signed URL chart tenant.
+	//
+	// in: query
+	Tenant string `json:"tenant"`
+}
+
+// SignChartImageRequest represents a request to sign chart image URL.
+// swagger:parameters signChartImage
+type SignChartImageRequest struct {
+	// Sign chart image URL request body.
+	//
+	// in: body
+	Image struct {
+		// ExpiresIn represents number of seconds the signed URL can be used.
+		// Server default is used if it's not set.
+		ExpiresIn *int `json:"expires_in"`
+
+		// Size represents size variant of the image, the original image is used if it's not set.
+		// Can be one of:
+		//  - small
+		//  - medium
+		//  - large
+		Size string `json:"size"`
+	} `json:"image"`
 }
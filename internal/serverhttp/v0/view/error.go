@@ -96,3 +96,57 @@ func (r *NotFoundError) MarshalJSON() ([]byte, error) {
 
 	return res, nil
 }
+
+// ForbiddenError represents error of the request that caller is not allowed to perform.
+//
+// swagger:response forbiddenError
+type ForbiddenError struct {
+	// Error message
+	//
+	// in: body
+	Body struct {
+		Error struct {
+			// Machine-readable reason of the error.
+			Reason string `json:"reason"`
+
+			// Scope that is required to perform the request.
+			Scope string `json:"scope"`
+
+			// Message of the error.
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+}
+
+// NewForbiddenError returns a ForbiddenError.
+func NewForbiddenError(reason, scope, message string) *ForbiddenError {
+	return &ForbiddenError{
+		Body: struct {
+			Error struct {
+				Reason  string `json:"reason"`
+				Scope   string `json:"scope"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}{
+			Error: struct {
+				Reason  string `json:"reason"`
+				Scope   string `json:"scope"`
+				Message string `json:"message"`
+			}{
+				Reason:  reason,
+				Scope:   scope,
+				Message: message,
+			},
+		},
+	}
+}
+
+// MarshalJSON implements the json.Marshaller interface.
+func (r *ForbiddenError) MarshalJSON() ([]byte, error) {
+	res, err := json.Marshal(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal forbidden error body into JSON: %w", err)
+	}
+
+	return res, nil
+}
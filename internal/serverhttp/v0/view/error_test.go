@@ -80,3 +80,15 @@ func TestNotFoundErrorMarshalJSON(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, actual)
 }
+
+func TestForbiddenErrorMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	expected := []byte(`{"error":{"reason":"MISSING_SCOPE","scope":"charts:create","message":"Forbidden"}}`)
+
+	e := view.NewForbiddenError("MISSING_SCOPE", "charts:create", "Forbidden")
+
+	actual, err := e.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
@@ -2,9 +2,32 @@ package view
 
 const ParamChartID = "chart_id"
 
+const (
+	// ParamPageSize represents charts list page size query parameter.
+	ParamPageSize = "page_size"
+
+	// ParamPageToken represents charts list page token query parameter.
+	ParamPageToken = "page_token"
+
+	// ParamCreatedAfter represents charts list created after filter query parameter.
+	ParamCreatedAfter = "created_after"
+
+	// ParamCreatedBefore represents charts list created before filter query parameter.
+	ParamCreatedBefore = "created_before"
+
+	// ParamChartStatus represents charts list chart status filter query parameter.
+	ParamChartStatus = "chart_status"
+
+	// ParamTitle represents charts list title substring filter query parameter.
+	ParamTitle = "title"
+
+	// ParamAsync represents create chart asynchronous mode query parameter.
+	ParamAsync = "async"
+)
+
 // ChartID represents chart ID from URL.
 //
-// swagger:parameters getChart
+// swagger:parameters getChart getChartImage signChartImage deleteChart
 type ChartID struct {
 	// Chart identifier.
 	//
@@ -0,0 +1,237 @@
+package signedurl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/limpidchart/lc-api/internal/config"
+)
+
+const (
+	// ParamExpires represents signed URL expiration unix timestamp query parameter.
+	ParamExpires = "exp"
+
+	// ParamSignature represents signed URL signature query parameter.
+	ParamSignature = "sig"
+
+	// ParamKeyID represents signed URL signing key ID query parameter.
+	ParamKeyID = "kid"
+
+	// ParamTenant represents signed URL chart tenant query parameter.
+	ParamTenant = "tenant"
+
+	// ParamSize represents signed URL image size variant query parameter.
+	ParamSize = "size"
+)
+
+const (
+	keysSeparator     = ","
+	keyIDSeparator    = ":"
+	payloadSeparator  = "\n"
+	payloadVersion    = "v0"
+	minKeySecretBytes = 16
+)
+
+var (
+	// ErrBadConfig contains error message about signed URLs configuration that can't be used.
+	ErrBadConfig = errors.New("bad signed URL configuration")
+
+	// ErrDisabled contains error message about signed URLs that are used without signing keys.
+	ErrDisabled = errors.New("signed URLs are not configured")
+
+	// ErrBadTTL contains error message about signed URL lifetime that can't be used.
+	ErrBadTTL = errors.New("bad signed URL TTL")
+
+	// ErrBadSignature contains error message about signed URL that can't be verified.
+	ErrBadSignature = errors.New("bad signed URL signature")
+
+	// ErrExpired contains error message about signed URL that is expired.
+	ErrExpired = errors.New("signed URL is expired")
+)
+
+// Claims represents signed URL values that are covered by its signature.
+type Claims struct {
+	// Tenant is empty for charts of the default tenant.
+	Tenant string
+
+	// Size is empty for the original image.
+	Size string
+}
+
+// Signer signs chart image URLs with HMAC-SHA256 and verifies them.
+// URLs are signed with the first key, all keys are accepted for verification,
+// so a new key can be added in front of the old one that is removed after its URLs are expired.
+type Signer struct {
+	keys       []key
+	defaultTTL time.Duration
+	maxTTL     time.Duration
+	baseURL    string
+}
+
+type key struct {
+	id     string
+	secret []byte
+}
+
+// NewSigner configures a new Signer.
+// Keys are provided as comma separated "id:secret" pairs, signed URLs are disabled if there are no keys
+// and the rest of the configuration isn't checked.
+func NewSigner(signedURLCfg config.SignedURLConfig) (*Signer, error) {
+	keys, err := parseKeys(signedURLCfg.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return &Signer{keys: keys}, nil
+	}
+
+	if signedURLCfg.DefaultTTLSeconds <= 0 || signedURLCfg.MaxTTLSeconds <= 0 {
+		return nil, fmt.Errorf("%w: TTL and max TTL should be positive", ErrBadConfig)
+	}
+
+	if signedURLCfg.DefaultTTLSeconds > signedURLCfg.MaxTTLSeconds {
+		return nil, fmt.Errorf("%w: TTL should not exceed max TTL", ErrBadConfig)
+	}
+
+	return &Signer{
+		keys:       keys,
+		defaultTTL: time.Duration(signedURLCfg.DefaultTTLSeconds) * time.Second,
+		maxTTL:     time.Duration(signedURLCfg.MaxTTLSeconds) * time.Second,
+		baseURL:    strings.TrimSuffix(signedURLCfg.BaseURL, "/"),
+	}, nil
+}
+
+// Enabled reports if signing keys are configured.
+func (s *Signer) Enabled() bool {
+	return len(s.keys) > 0
+}
+
+// BaseURL returns scheme and host that are prepended to signed URLs paths.
+// It's empty if signed URLs are relative.
+func (s *Signer) BaseURL() string {
+	return s.baseURL
+}
+
+// Sign returns query parameters of the chart image URL that is signed with the first key and its expiration timestamp.
+// Zero ttl means the default TTL.
+func (s *Signer) Sign(chartID string, claims Claims, ttl time.Duration, now time.Time) (url.Values, time.Time, error) {
+	if !s.Enabled() {
+		return nil, time.Time{}, ErrDisabled
+	}
+
+	if ttl == 0 {
+		ttl = s.defaultTTL
+	}
+
+	if ttl < time.Second || ttl > s.maxTTL {
+		return nil, time.Time{}, fmt.Errorf("%w: it should be from 1s to %s", ErrBadTTL, s.maxTTL)
+	}
+
+	expiresAt := now.Add(ttl).Truncate(time.Second)
+	exp := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	query := url.Values{}
+	query.Set(ParamExpires, exp)
+	query.Set(ParamKeyID, s.keys[0].id)
+	query.Set(ParamSignature, s.keys[0].sign(chartID, claims, exp))
+
+	if claims.Tenant != "" {
+		query.Set(ParamTenant, claims.Tenant)
+	}
+
+	if claims.Size != "" {
+		query.Set(ParamSize, claims.Size)
+	}
+
+	return query, expiresAt, nil
+}
+
+// Verify checks signature and expiration of the chart image URL query parameters and returns its claims.
+func (s *Signer) Verify(chartID string, query url.Values, now time.Time) (*Claims, error) {
+	if !s.Enabled() {
+		return nil, ErrDisabled
+	}
+
+	exp := query.Get(ParamExpires)
+
+	expiresAt, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to parse %s", ErrBadSignature, ParamExpires)
+	}
+
+	k, ok := s.key(query.Get(ParamKeyID))
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key %q", ErrBadSignature, query.Get(ParamKeyID))
+	}
+
+	claims := &Claims{
+		Tenant: query.Get(ParamTenant),
+		Size:   query.Get(ParamSize),
+	}
+
+	if !hmac.Equal([]byte(query.Get(ParamSignature)), []byte(k.sign(chartID, *claims, exp))) {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrBadSignature)
+	}
+
+	if now.Unix() >= expiresAt {
+		return nil, fmt.Errorf("%w: it's expired at %s", ErrExpired, time.Unix(expiresAt, 0).UTC().Format(time.RFC3339))
+	}
+
+	return claims, nil
+}
+
+func (s *Signer) key(id string) (key, bool) {
+	for _, k := range s.keys {
+		if k.id == id {
+			return k, true
+		}
+	}
+
+	return key{}, false
+}
+
+func (k key) sign(chartID string, claims Claims, exp string) string {
+	mac := hmac.New(sha256.New, k.secret)
+
+	// nolint: errcheck
+	mac.Write([]byte(strings.Join([]string{payloadVersion, chartID, claims.Tenant, claims.Size, exp}, payloadSeparator)))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func parseKeys(rawKeys string) ([]key, error) {
+	keys := []key{}
+
+	if strings.TrimSpace(rawKeys) == "" {
+		return keys, nil
+	}
+
+	for i, rawKey := range strings.Split(rawKeys, keysSeparator) {
+		parts := strings.SplitN(strings.TrimSpace(rawKey), keyIDSeparator, 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("%w: key #%d should look like id:secret", ErrBadConfig, i)
+		}
+
+		if len(parts[1]) < minKeySecretBytes {
+			return nil, fmt.Errorf("%w: key %q secret should have at least %d bytes", ErrBadConfig, parts[0], minKeySecretBytes)
+		}
+
+		for _, k := range keys {
+			if k.id == parts[0] {
+				return nil, fmt.Errorf("%w: key %q is duplicated", ErrBadConfig, parts[0])
+			}
+		}
+
+		keys = append(keys, key{id: parts[0], secret: []byte(parts[1])})
+	}
+
+	return keys, nil
+}
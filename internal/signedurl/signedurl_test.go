@@ -0,0 +1,177 @@
+package signedurl_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/signedurl"
+)
+
+const (
+	testingKeyV1 = "v1:0123456789abcdef"
+	testingKeyV2 = "v2:fedcba9876543210"
+	testingChart = "6b1d8a5e-3f0c-4d2b-9a57-0c1e2f3a4b5c"
+)
+
+func newSigner(t *testing.T, keys string) *signedurl.Signer {
+	t.Helper()
+
+	signer, err := signedurl.NewSigner(config.SignedURLConfig{
+		Keys:              keys,
+		DefaultTTLSeconds: 600,
+		MaxTTLSeconds:     3600,
+	})
+	if err != nil {
+		t.Fatalf("unable to configure signer: %s", err)
+	}
+
+	return signer
+}
+
+func TestNewSigner_Err(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name         string
+		signedURLCfg config.SignedURLConfig
+		expected     string
+	}{
+		{
+			"no_secret",
+			config.SignedURLConfig{Keys: "v1", DefaultTTLSeconds: 1, MaxTTLSeconds: 1},
+			"bad signed URL configuration: key #0 should look like id:secret",
+		},
+		{
+			"short_secret",
+			config.SignedURLConfig{Keys: "v1:secret", DefaultTTLSeconds: 1, MaxTTLSeconds: 1},
+			`bad signed URL configuration: key "v1" secret should have at least 16 bytes`,
+		},
+		{
+			"duplicated_key",
+			config.SignedURLConfig{Keys: testingKeyV1 + "," + testingKeyV1, DefaultTTLSeconds: 1, MaxTTLSeconds: 1},
+			`bad signed URL configuration: key "v1" is duplicated`,
+		},
+		{
+			"zero_ttl",
+			config.SignedURLConfig{Keys: testingKeyV1, DefaultTTLSeconds: 0, MaxTTLSeconds: 1},
+			"bad signed URL configuration: TTL and max TTL should be positive",
+		},
+		{
+			"ttl_exceeds_max_ttl",
+			config.SignedURLConfig{Keys: testingKeyV1, DefaultTTLSeconds: 2, MaxTTLSeconds: 1},
+			"bad signed URL configuration: TTL should not exceed max TTL",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			signer, err := signedurl.NewSigner(tc.signedURLCfg)
+			assert.Nil(t, signer)
+			assert.True(t, errors.Is(err, signedurl.ErrBadConfig))
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestSigner_Disabled(t *testing.T) {
+	t.Parallel()
+
+	signer, err := signedurl.NewSigner(config.SignedURLConfig{})
+	assert.NoError(t, err)
+	assert.False(t, signer.Enabled())
+
+	_, _, err = signer.Sign(testingChart, signedurl.Claims{}, 0, time.Now())
+	assert.True(t, errors.Is(err, signedurl.ErrDisabled))
+
+	_, err = signer.Verify(testingChart, nil, time.Now())
+	assert.True(t, errors.Is(err, signedurl.ErrDisabled))
+}
+
+func TestSigner_SignVerify(t *testing.T) {
+	t.Parallel()
+
+	signer := newSigner(t, testingKeyV1)
+	now := time.Unix(1600000000, 0)
+	claims := signedurl.Claims{Tenant: "acme", Size: "small"}
+
+	query, expiresAt, err := signer.Sign(testingChart, claims, 0, now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(time.Minute*10), expiresAt)
+	assert.Equal(t, "1600000600", query.Get(signedurl.ParamExpires))
+	assert.Equal(t, "v1", query.Get(signedurl.ParamKeyID))
+	assert.Equal(t, "acme", query.Get(signedurl.ParamTenant))
+	assert.Equal(t, "small", query.Get(signedurl.ParamSize))
+
+	verified, err := signer.Verify(testingChart, query, now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, &claims, verified)
+
+	// URL can't be used for other charts.
+	_, err = signer.Verify("3c0e5f0a-8a1e-4b8e-bf6c-2d3e4f5a6b7c", query, now)
+	assert.True(t, errors.Is(err, signedurl.ErrBadSignature))
+
+	// Signed values can't be changed.
+	for _, param := range []string{signedurl.ParamTenant, signedurl.ParamSize, signedurl.ParamExpires} {
+		tampered, _, _ := signer.Sign(testingChart, claims, 0, now)
+		tampered.Set(param, "1700000000")
+
+		_, err = signer.Verify(testingChart, tampered, now)
+		assert.True(t, errors.Is(err, signedurl.ErrBadSignature), param)
+	}
+
+	_, err = signer.Verify(testingChart, query, expiresAt)
+	assert.True(t, errors.Is(err, signedurl.ErrExpired))
+	assert.EqualError(t, err, "signed URL is expired: it's expired at 2020-09-13T12:36:40Z")
+}
+
+func TestSigner_Sign_TTL(t *testing.T) {
+	t.Parallel()
+
+	signer := newSigner(t, testingKeyV1)
+	now := time.Now()
+
+	_, expiresAt, err := signer.Sign(testingChart, signedurl.Claims{}, time.Hour, now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour).Truncate(time.Second), expiresAt)
+
+	_, _, err = signer.Sign(testingChart, signedurl.Claims{}, time.Hour+time.Second, now)
+	assert.True(t, errors.Is(err, signedurl.ErrBadTTL))
+	assert.EqualError(t, err, "bad signed URL TTL: it should be from 1s to 1h0m0s")
+
+	_, _, err = signer.Sign(testingChart, signedurl.Claims{}, -time.Second, now)
+	assert.True(t, errors.Is(err, signedurl.ErrBadTTL))
+}
+
+func TestSigner_KeyRotation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	oldQuery, _, err := newSigner(t, testingKeyV1).Sign(testingChart, signedurl.Claims{}, 0, now)
+	assert.NoError(t, err)
+
+	// New key signs URLs once it's added in front of the old one, URLs of the old key are still valid.
+	rotated := newSigner(t, testingKeyV2+","+testingKeyV1)
+
+	newQuery, _, err := rotated.Sign(testingChart, signedurl.Claims{}, 0, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", newQuery.Get(signedurl.ParamKeyID))
+
+	_, err = rotated.Verify(testingChart, oldQuery, now)
+	assert.NoError(t, err)
+
+	_, err = rotated.Verify(testingChart, newQuery, now)
+	assert.NoError(t, err)
+
+	// URLs of the removed key are rejected.
+	_, err = newSigner(t, testingKeyV2).Verify(testingChart, oldQuery, now)
+	assert.True(t, errors.Is(err, signedurl.ErrBadSignature))
+	assert.EqualError(t, err, `bad signed URL signature: unknown key "v1"`)
+}
@@ -0,0 +1,87 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/storage"
+)
+
+func TestDeleteAndPurgeCharts(t *testing.T) {
+	t.Parallel()
+
+	disk, err := storage.NewDisk(t.TempDir())
+	if err != nil {
+		t.Fatalf("unable to configure disk storage: %s", err)
+	}
+
+	tt := []struct {
+		name    string
+		storage storage.Storage
+	}{
+		{
+			"memory",
+			storage.NewMemory(),
+		},
+		{
+			"disk",
+			disk,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			deletedAt := time.Date(2021, 8, 23, 10, 20, 30, 0, time.UTC)
+
+			deleted, kept := testingChartReply(t), testingChartReply(t)
+			deleted.Title = "Chart title"
+
+			assert.NoError(t, tc.storage.SaveChart(ctx, deleted))
+			assert.NoError(t, tc.storage.SaveChart(ctx, kept))
+
+			_, err := tc.storage.DeleteChart(ctx, "", testingChartReply(t).ChartId, deletedAt)
+			assert.True(t, errors.Is(err, storage.ErrChartNotFound))
+
+			tombstone, err := tc.storage.DeleteChart(ctx, "", deleted.ChartId, deletedAt)
+			assert.NoError(t, err)
+			assert.Equal(t, render.ChartStatus_DELETED, tombstone.ChartStatus)
+			assert.Equal(t, deletedAt, tombstone.DeletedAt.AsTime())
+			assert.Equal(t, deleted.CreatedAt.AsTime(), tombstone.CreatedAt.AsTime())
+			assert.Empty(t, tombstone.ChartData)
+			assert.Empty(t, tombstone.Title)
+
+			// Repeated deletion should keep the original deletion timestamp.
+			tombstone, err = tc.storage.DeleteChart(ctx, "", deleted.ChartId, deletedAt.Add(time.Hour))
+			assert.NoError(t, err)
+			assert.Equal(t, deletedAt, tombstone.DeletedAt.AsTime())
+
+			saved, err := tc.storage.GetChart(ctx, "", deleted.ChartId)
+			assert.NoError(t, err)
+			assert.Equal(t, render.ChartStatus_DELETED, saved.ChartStatus)
+
+			// Tombstone is kept until the grace period is over.
+			purged, err := tc.storage.PurgeCharts(ctx, deletedAt)
+			assert.NoError(t, err)
+			assert.Equal(t, 0, purged)
+
+			purged, err = tc.storage.PurgeCharts(ctx, deletedAt.Add(time.Second))
+			assert.NoError(t, err)
+			assert.Equal(t, 1, purged)
+
+			_, err = tc.storage.GetChart(ctx, "", deleted.ChartId)
+			assert.True(t, errors.Is(err, storage.ErrChartNotFound))
+
+			_, err = tc.storage.GetChart(ctx, "", kept.ChartId)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+const (
+	diskDirPerm  = 0o750
+	diskFilePerm = 0o640
+
+	chartFileExt    = ".pb"
+	chartTmpFileExt = ".tmp"
+)
+
+// ErrBadChartID contains error message about chart ID that can't be used as a file name.
+var ErrBadChartID = errors.New("chart ID is not a valid UUID")
+
+// Disk implements Storage that keeps every chart in its own file inside of the configured directory.
+// Updates, deletions and removals are serialized to not lose tombstones of concurrently deleted charts.
+// Charts metadata is indexed in memory, so only files of the charts that can be listed are read.
+type Disk struct {
+	mu    sync.Mutex
+	dir   string
+	index *diskIndex
+}
+
+// NewDisk creates the provided directory if needed, indexes saved charts and returns a new Disk.
+func NewDisk(dir string) (*Disk, error) {
+	if err := os.MkdirAll(dir, diskDirPerm); err != nil {
+		return nil, fmt.Errorf("unable to create charts storage directory: %w", err)
+	}
+
+	d := &Disk{dir: dir}
+
+	charts, err := d.readCharts()
+	if err != nil {
+		return nil, fmt.Errorf("unable to index charts storage directory: %w", err)
+	}
+
+	d.index = newDiskIndex(charts)
+
+	return d, nil
+}
+
+// SaveChart marshals the provided chart and atomically writes it to disk.
+func (d *Disk) SaveChart(_ context.Context, chart *render.ChartReply) error {
+	path, err := d.chartPath(chart.ChartId)
+	if err != nil {
+		return err
+	}
+
+	data, err := proto.Marshal(chart)
+	if err != nil {
+		return fmt.Errorf("unable to marshal chart: %w", err)
+	}
+
+	tmpPath := path + chartTmpFileExt
+
+	if err := os.WriteFile(tmpPath, data, diskFilePerm); err != nil {
+		return fmt.Errorf("unable to write chart file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+
+		return fmt.Errorf("unable to rename chart file: %w", err)
+	}
+
+	d.index.put(chart)
+
+	return nil
+}
+
+// UpdateChart replaces the chart file with the provided chart.
+func (d *Disk) UpdateChart(ctx context.Context, chart *render.ChartReply) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	saved, err := d.readChart(chart.ChartId)
+	if err != nil {
+		return err
+	}
+
+	if err := checkUpdatable(saved); err != nil {
+		return err
+	}
+
+	return d.SaveChart(ctx, chart)
+}
+
+// GetChart reads the chart of the tenant from disk.
+func (d *Disk) GetChart(_ context.Context, tenant, chartID string) (*render.ChartReply, error) {
+	chart, err := d.readChart(chartID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isAvailable(chart, tenant, time.Now()) {
+		return nil, ErrChartNotFound
+	}
+
+	return chart, nil
+}
+
+// readChart reads the chart from disk regardless of its tenant.
+func (d *Disk) readChart(chartID string) (*render.ChartReply, error) {
+	path, err := d.chartPath(chartID)
+	if err != nil {
+		// Chart with a bad ID can't be saved so it can't be found.
+		return nil, ErrChartNotFound
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrChartNotFound
+		}
+
+		return nil, fmt.Errorf("unable to read chart file: %w", err)
+	}
+
+	chart := &render.ChartReply{}
+
+	if err := proto.Unmarshal(data, chart); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal chart: %w", err)
+	}
+
+	return chart, nil
+}
+
+// ListCharts returns a filtered page of charts.
+// Charts are picked from the index in the list order and files are read only until the page is filled.
+func (d *Disk) ListCharts(_ context.Context, opts ListOpts) (*ListResult, error) {
+	pageSize, err := validatePageSize(opts.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := decodePageToken(opts.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	charts := make([]*render.ChartReply, 0, pageSize+1)
+
+	for _, chartID := range d.index.candidates(opts, cursor, now.UnixNano()) {
+		// One more chart is read to know if there is a next page.
+		if len(charts) > pageSize {
+			break
+		}
+
+		chart, err := d.readChart(chartID)
+		if err != nil {
+			// Chart could be removed after the index was read.
+			if errors.Is(err, ErrChartNotFound) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		if matchesListOpts(chart, opts, now) {
+			charts = append(charts, chart)
+		}
+	}
+
+	return listCharts(charts, opts)
+}
+
+// DeleteChart replaces the chart file of the tenant with its tombstone and returns it.
+func (d *Disk) DeleteChart(ctx context.Context, tenant, chartID string, deletedAt time.Time) (*render.ChartReply, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	chart, err := d.GetChart(ctx, tenant, chartID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chart.ChartStatus == render.ChartStatus_DELETED {
+		return chart, nil
+	}
+
+	tombstone(chart, deletedAt)
+
+	if err := d.SaveChart(ctx, chart); err != nil {
+		return nil, err
+	}
+
+	return chart, nil
+}
+
+// PurgeCharts removes files of charts that were deleted before the provided timestamp.
+func (d *Disk) PurgeCharts(ctx context.Context, deletedBefore time.Time) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.removeCharts(ctx, d.index.purgeable(deletedBefore.UnixNano()), func(chart *render.ChartReply) bool {
+		return isPurgeable(chart, deletedBefore)
+	})
+}
+
+// ExpireCharts removes files of charts that expired before the provided timestamp.
+func (d *Disk) ExpireCharts(ctx context.Context, expiredBefore time.Time) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.removeCharts(ctx, d.index.expired(expiredBefore.UnixNano()), func(chart *render.ChartReply) bool {
+		return isExpired(chart, expiredBefore)
+	})
+}
+
+// Close does nothing since Disk doesn't keep any files open.
+func (d *Disk) Close() error {
+	return nil
+}
+
+// removeCharts removes files of the candidate charts that match the provided function and returns their count.
+// Candidates are picked from the index, so only their files are read. It should be called with the lock held.
+func (d *Disk) removeCharts(ctx context.Context, chartIDs []string, match func(chart *render.ChartReply) bool) (int, error) {
+	removed := 0
+
+	for _, chartID := range chartIDs {
+		if err := ctx.Err(); err != nil {
+			return removed, err
+		}
+
+		chart, err := d.readChart(chartID)
+		if err != nil {
+			// Chart could be removed after the index was read.
+			if errors.Is(err, ErrChartNotFound) {
+				d.index.delete(chartID)
+
+				continue
+			}
+
+			return removed, err
+		}
+
+		if !match(chart) {
+			continue
+		}
+
+		path, err := d.chartPath(chart.ChartId)
+		if err != nil {
+			return removed, err
+		}
+
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return removed, fmt.Errorf("unable to remove chart file: %w", err)
+		}
+
+		d.index.delete(chart.ChartId)
+
+		removed++
+	}
+
+	return removed, nil
+}
+
+// readCharts reads all charts from disk.
+func (d *Disk) readCharts() ([]*render.ChartReply, error) {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read charts storage directory: %w", err)
+	}
+
+	charts := make([]*render.ChartReply, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != chartFileExt {
+			continue
+		}
+
+		chart, err := d.readChart(strings.TrimSuffix(entry.Name(), chartFileExt))
+		if err != nil {
+			// Chart could be removed after the directory was read.
+			if errors.Is(err, ErrChartNotFound) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		charts = append(charts, chart)
+	}
+
+	return charts, nil
+}
+
+// chartPath returns a chart file path.
+// Chart ID is validated as UUID to be sure that it can't point outside of the storage directory.
+func (d *Disk) chartPath(chartID string) (string, error) {
+	id, err := uuid.Parse(chartID)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrBadChartID, chartID)
+	}
+
+	return filepath.Join(d.dir, id.String()+chartFileExt), nil
+}
@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+// diskIndex keeps metadata of the charts saved on disk sorted by tenant, creation timestamp and chart ID,
+// so charts can be listed without reading files of other tenants or charts that don't match the filters.
+type diskIndex struct {
+	mu      sync.RWMutex
+	entries []diskIndexEntry
+	byID    map[string]diskIndexEntry
+}
+
+// diskIndexEntry contains chart fields that can be matched without reading the chart file.
+type diskIndexEntry struct {
+	tenant      string
+	createdAt   int64
+	chartID     string
+	chartStatus render.ChartStatus
+	expiresAt   int64
+	deletedAt   int64
+}
+
+// newDiskIndex returns a new diskIndex of the provided charts.
+func newDiskIndex(charts []*render.ChartReply) *diskIndex {
+	idx := &diskIndex{
+		entries: make([]diskIndexEntry, 0, len(charts)),
+		byID:    make(map[string]diskIndexEntry, len(charts)),
+	}
+
+	for _, chart := range charts {
+		entry := newDiskIndexEntry(chart)
+		idx.entries = append(idx.entries, entry)
+		idx.byID[entry.chartID] = entry
+	}
+
+	sort.Slice(idx.entries, func(i, j int) bool {
+		a, b := idx.entries[i], idx.entries[j]
+
+		if a.tenant != b.tenant {
+			return a.tenant < b.tenant
+		}
+
+		if a.createdAt != b.createdAt {
+			return a.createdAt < b.createdAt
+		}
+
+		return a.chartID < b.chartID
+	})
+
+	return idx
+}
+
+func newDiskIndexEntry(chart *render.ChartReply) diskIndexEntry {
+	entry := diskIndexEntry{
+		tenant:      chart.Tenant,
+		createdAt:   chart.CreatedAt.AsTime().UnixNano(),
+		chartID:     chart.ChartId,
+		chartStatus: chart.ChartStatus,
+	}
+
+	if chart.ExpiresAt != nil {
+		entry.expiresAt = chart.ExpiresAt.AsTime().UnixNano()
+	}
+
+	if chart.DeletedAt != nil {
+		entry.deletedAt = chart.DeletedAt.AsTime().UnixNano()
+	}
+
+	return entry
+}
+
+// put adds the chart into the index or replaces its previous entry.
+func (idx *diskIndex) put(chart *render.ChartReply) {
+	entry := newDiskIndexEntry(chart)
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(entry.chartID)
+
+	i := idx.search(entry.tenant, entry.createdAt, entry.chartID)
+	idx.entries = append(idx.entries, diskIndexEntry{})
+	copy(idx.entries[i+1:], idx.entries[i:])
+	idx.entries[i] = entry
+	idx.byID[entry.chartID] = entry
+}
+
+// delete removes the chart from the index.
+func (idx *diskIndex) delete(chartID string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(chartID)
+}
+
+// candidates returns IDs of the charts that can match the list options in the list order.
+// Title can't be matched without reading the chart, so it's left to the caller.
+func (idx *diskIndex) candidates(opts ListOpts, cursor *pageCursor, now int64) []string {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	end := idx.search(opts.Tenant, math.MaxInt64, "")
+
+	if cursor != nil {
+		if i := idx.search(opts.Tenant, cursor.CreatedAt, cursor.ChartID); i < end {
+			end = i
+		}
+	}
+
+	if !opts.CreatedBefore.IsZero() {
+		if i := idx.search(opts.Tenant, opts.CreatedBefore.UnixNano(), ""); i < end {
+			end = i
+		}
+	}
+
+	var createdAfter int64 = math.MinInt64
+	if !opts.CreatedAfter.IsZero() {
+		createdAfter = opts.CreatedAfter.UnixNano()
+	}
+
+	chartIDs := []string{}
+
+	for i := end - 1; i >= 0; i-- {
+		entry := idx.entries[i]
+
+		if entry.tenant != opts.Tenant || entry.createdAt < createdAfter {
+			break
+		}
+
+		if opts.ChartStatus != render.ChartStatus_UNSPECIFIED_STATUS && entry.chartStatus != opts.ChartStatus {
+			continue
+		}
+
+		if entry.expiresAt != 0 && entry.expiresAt < now {
+			continue
+		}
+
+		chartIDs = append(chartIDs, entry.chartID)
+	}
+
+	return chartIDs
+}
+
+// expired returns IDs of the charts that expired before the provided timestamp.
+func (idx *diskIndex) expired(expiredBefore int64) []string {
+	return idx.filter(func(entry diskIndexEntry) bool {
+		return entry.expiresAt != 0 && entry.expiresAt < expiredBefore
+	})
+}
+
+// purgeable returns IDs of the chart tombstones that were deleted before the provided timestamp.
+func (idx *diskIndex) purgeable(deletedBefore int64) []string {
+	return idx.filter(func(entry diskIndexEntry) bool {
+		return entry.chartStatus == render.ChartStatus_DELETED && entry.deletedAt < deletedBefore
+	})
+}
+
+// filter returns IDs of the charts that match the provided function.
+func (idx *diskIndex) filter(match func(entry diskIndexEntry) bool) []string {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	chartIDs := []string{}
+
+	for _, entry := range idx.entries {
+		if match(entry) {
+			chartIDs = append(chartIDs, entry.chartID)
+		}
+	}
+
+	return chartIDs
+}
+
+// search returns position of the first entry that is not less than the provided key.
+func (idx *diskIndex) search(tenant string, createdAt int64, chartID string) int {
+	return sort.Search(len(idx.entries), func(i int) bool {
+		entry := idx.entries[i]
+
+		if entry.tenant != tenant {
+			return entry.tenant > tenant
+		}
+
+		if entry.createdAt != createdAt {
+			return entry.createdAt > createdAt
+		}
+
+		return entry.chartID >= chartID
+	})
+}
+
+// remove removes the chart from the index, it should be called with the lock held.
+func (idx *diskIndex) remove(chartID string) {
+	entry, ok := idx.byID[chartID]
+	if !ok {
+		return
+	}
+
+	i := idx.search(entry.tenant, entry.createdAt, entry.chartID)
+	idx.entries = append(idx.entries[:i], idx.entries[i+1:]...)
+	delete(idx.byID, chartID)
+}
@@ -0,0 +1,191 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/storage"
+)
+
+func TestDisk(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s, err := storage.NewDisk(dir)
+	if err != nil {
+		t.Fatalf("unable to configure disk storage: %s", err)
+	}
+
+	chart := testingChartReply(t)
+
+	_, err = s.GetChart(ctx, "", chart.ChartId)
+	assert.True(t, errors.Is(err, storage.ErrChartNotFound))
+
+	assert.NoError(t, s.SaveChart(ctx, chart))
+
+	saved, err := s.GetChart(ctx, "", chart.ChartId)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(chart, saved))
+
+	assert.NoError(t, s.Close())
+
+	// Charts should be available after storage is re-opened.
+	reopened, err := storage.NewDisk(dir)
+	if err != nil {
+		t.Fatalf("unable to re-open disk storage: %s", err)
+	}
+
+	saved, err = reopened.GetChart(ctx, "", chart.ChartId)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(chart, saved))
+}
+
+func TestDisk_ListCharts(t *testing.T) {
+	t.Parallel()
+
+	s, err := storage.NewDisk(t.TempDir())
+	if err != nil {
+		t.Fatalf("unable to configure disk storage: %s", err)
+	}
+
+	charts := saveTestingCharts(t, s)
+
+	res, err := s.ListCharts(context.Background(), storage.ListOpts{PageSize: 2, TitleContains: "2021"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{charts[4].ChartId, charts[3].ChartId}, chartIDs(res.Charts))
+	assert.Empty(t, res.NextPageToken)
+}
+
+func TestDisk_ListChartsIndex(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s, err := storage.NewDisk(dir)
+	if err != nil {
+		t.Fatalf("unable to configure disk storage: %s", err)
+	}
+
+	charts := saveTestingCharts(t, s)
+
+	acmeChart := testingChartReply(t)
+	acmeChart.Tenant = "acme"
+
+	assert.NoError(t, s.SaveChart(ctx, acmeChart))
+
+	// Charts should be indexed once storage is re-opened.
+	reopened, err := storage.NewDisk(dir)
+	if err != nil {
+		t.Fatalf("unable to re-open disk storage: %s", err)
+	}
+
+	res, err := reopened.ListCharts(ctx, storage.ListOpts{PageSize: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{charts[4].ChartId, charts[3].ChartId}, chartIDs(res.Charts))
+	assert.NotEmpty(t, res.NextPageToken)
+
+	// Files of other tenants and of the charts after the page are not read.
+	for _, chart := range []*render.ChartReply{charts[0], charts[1], charts[2], acmeChart} {
+		if err := os.WriteFile(filepath.Join(dir, chart.ChartId+".pb"), []byte("not a chart"), 0o600); err != nil {
+			t.Fatalf("unable to corrupt chart file: %s", err)
+		}
+	}
+
+	res, err = reopened.ListCharts(ctx, storage.ListOpts{PageSize: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{charts[4].ChartId}, chartIDs(res.Charts))
+
+	res, err = reopened.ListCharts(ctx, storage.ListOpts{ChartStatus: render.ChartStatus_CREATED, PageSize: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{charts[4].ChartId}, chartIDs(res.Charts))
+
+	_, err = reopened.ListCharts(ctx, storage.ListOpts{Tenant: "acme"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "unable to unmarshal chart")
+	}
+}
+
+func TestDisk_BadChartID(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	s, err := storage.NewDisk(t.TempDir())
+	if err != nil {
+		t.Fatalf("unable to configure disk storage: %s", err)
+	}
+
+	chart := testingChartReply(t)
+	chart.ChartId = "../../etc/passwd"
+
+	assert.True(t, errors.Is(s.SaveChart(ctx, chart), storage.ErrBadChartID))
+
+	_, err = s.GetChart(ctx, "", chart.ChartId)
+	assert.True(t, errors.Is(err, storage.ErrChartNotFound))
+}
+
+func TestDisk_RemoveChartsIndex(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	now := time.Now()
+
+	s, err := storage.NewDisk(dir)
+	if err != nil {
+		t.Fatalf("unable to configure disk storage: %s", err)
+	}
+
+	expired := testingChartReply(t)
+	expired.ExpiresAt = timestamppb.New(now.Add(-time.Second))
+
+	deleted := testingChartReply(t)
+
+	kept := testingChartReply(t)
+	kept.ExpiresAt = timestamppb.New(now.Add(time.Hour))
+
+	for _, chart := range []*render.ChartReply{expired, deleted, kept} {
+		assert.NoError(t, s.SaveChart(ctx, chart))
+	}
+
+	_, err = s.DeleteChart(ctx, "", deleted.ChartId, now.Add(-time.Second))
+	assert.NoError(t, err)
+
+	// Files of the charts that can't be removed are not read.
+	if err := os.WriteFile(filepath.Join(dir, kept.ChartId+".pb"), []byte("not a chart"), 0o600); err != nil {
+		t.Fatalf("unable to corrupt chart file: %s", err)
+	}
+
+	// Sweep stops once its context is cancelled.
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	removed, err := s.ExpireCharts(cancelledCtx, now)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 0, removed)
+
+	removed, err = s.ExpireCharts(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	removed, err = s.PurgeCharts(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	for _, chart := range []*render.ChartReply{expired, deleted} {
+		_, err = os.Stat(filepath.Join(dir, chart.ChartId+".pb"))
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+	}
+}
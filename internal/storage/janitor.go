@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/limpidchart/lc-api/internal/config"
+)
+
+const janitorName = "storage janitor"
+
+// Janitor periodically purges tombstones of deleted charts and removes expired charts from storage.
+type Janitor struct {
+	log         *zerolog.Logger
+	storage     Storage
+	interval    time.Duration
+	gracePeriod time.Duration
+}
+
+// NewJanitor configures a new Janitor.
+func NewJanitor(log *zerolog.Logger, storage Storage, storageCfg config.StorageConfig) *Janitor {
+	return &Janitor{
+		log:         log,
+		storage:     storage,
+		interval:    time.Duration(storageCfg.PurgeIntervalSeconds) * time.Second,
+		gracePeriod: time.Duration(storageCfg.PurgeGracePeriodSeconds) * time.Second,
+	}
+}
+
+// Serve sweeps storage until the provided context is done.
+// Sweep that is already started is finished before Serve returns.
+// It only waits for the context if the sweep interval is not positive.
+func (j *Janitor) Serve(ctx context.Context) error {
+	if j.interval <= 0 {
+		<-ctx.Done()
+
+		return nil
+	}
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.log.Info().
+				Time(zerolog.TimestampFieldName, time.Now().UTC()).
+				Msg("Stopping storage janitor")
+
+			return nil
+		case <-ticker.C:
+			j.sweep(ctx)
+		}
+	}
+}
+
+// Address returns an empty string since Janitor doesn't listen on any address.
+func (j *Janitor) Address() string {
+	return ""
+}
+
+// Name returns janitor name.
+func (j *Janitor) Name() string {
+	return janitorName
+}
+
+func (j *Janitor) sweep(ctx context.Context) {
+	now := time.Now().UTC()
+
+	purged, err := j.storage.PurgeCharts(ctx, now.Add(-j.gracePeriod))
+	if err != nil {
+		j.log.Error().Time(zerolog.TimestampFieldName, now).Err(err).Msg("Unable to purge deleted charts")
+	} else if purged > 0 {
+		j.log.Info().Time(zerolog.TimestampFieldName, now).Int("purged", purged).Msg("Purged deleted charts")
+	}
+
+	expired, err := j.storage.ExpireCharts(ctx, now)
+	if err != nil {
+		j.log.Error().Time(zerolog.TimestampFieldName, now).Err(err).Msg("Unable to remove expired charts")
+	} else if expired > 0 {
+		j.log.Info().Time(zerolog.TimestampFieldName, now).Int("expired", expired).Msg("Removed expired charts")
+	}
+}
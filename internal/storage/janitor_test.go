@@ -0,0 +1,188 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/storage"
+)
+
+func TestExpireCharts(t *testing.T) {
+	t.Parallel()
+
+	disk, err := storage.NewDisk(t.TempDir())
+	if err != nil {
+		t.Fatalf("unable to configure disk storage: %s", err)
+	}
+
+	tt := []struct {
+		name    string
+		storage storage.Storage
+	}{
+		{
+			"memory",
+			storage.NewMemory(),
+		},
+		{
+			"disk",
+			disk,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			expiresAt := time.Date(2021, 9, 22, 10, 20, 30, 0, time.UTC)
+
+			expiring, eternal := testingChartReply(t), testingChartReply(t)
+			expiring.ExpiresAt = timestamppb.New(expiresAt)
+
+			assert.NoError(t, tc.storage.SaveChart(ctx, expiring))
+			assert.NoError(t, tc.storage.SaveChart(ctx, eternal))
+
+			expired, err := tc.storage.ExpireCharts(ctx, expiresAt)
+			assert.NoError(t, err)
+			assert.Equal(t, 0, expired)
+
+			expired, err = tc.storage.ExpireCharts(ctx, expiresAt.Add(time.Second))
+			assert.NoError(t, err)
+			assert.Equal(t, 1, expired)
+
+			_, err = tc.storage.GetChart(ctx, "", expiring.ChartId)
+			assert.True(t, errors.Is(err, storage.ErrChartNotFound))
+
+			_, err = tc.storage.GetChart(ctx, "", eternal.ChartId)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestExpiredCharts_NotFound(t *testing.T) {
+	t.Parallel()
+
+	disk, err := storage.NewDisk(t.TempDir())
+	if err != nil {
+		t.Fatalf("unable to configure disk storage: %s", err)
+	}
+
+	tt := []struct {
+		name    string
+		storage storage.Storage
+	}{
+		{
+			"memory",
+			storage.NewMemory(),
+		},
+		{
+			"disk",
+			disk,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			expired, fresh := testingChartReply(t), testingChartReply(t)
+			expired.ExpiresAt = timestamppb.New(time.Now().Add(-time.Second))
+			fresh.ExpiresAt = timestamppb.New(time.Now().Add(time.Hour))
+
+			assert.NoError(t, tc.storage.SaveChart(ctx, expired))
+			assert.NoError(t, tc.storage.SaveChart(ctx, fresh))
+
+			// Expired chart is not found before it's removed.
+			_, err := tc.storage.GetChart(ctx, "", expired.ChartId)
+			assert.True(t, errors.Is(err, storage.ErrChartNotFound))
+
+			_, err = tc.storage.DeleteChart(ctx, "", expired.ChartId, time.Now().UTC())
+			assert.True(t, errors.Is(err, storage.ErrChartNotFound))
+
+			list, err := tc.storage.ListCharts(ctx, storage.ListOpts{})
+			assert.NoError(t, err)
+			assert.Len(t, list.Charts, 1)
+			assert.Equal(t, fresh.ChartId, list.Charts[0].ChartId)
+
+			_, err = tc.storage.GetChart(ctx, "", fresh.ChartId)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestJanitor(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := storage.NewMemory()
+	deleted, expiring, kept := testingChartReply(t), testingChartReply(t), testingChartReply(t)
+	expiring.ExpiresAt = timestamppb.New(time.Now().UTC().Add(-time.Second))
+	kept.ExpiresAt = timestamppb.New(time.Now().UTC().Add(time.Hour))
+
+	assert.NoError(t, s.SaveChart(ctx, deleted))
+	assert.NoError(t, s.SaveChart(ctx, expiring))
+	assert.NoError(t, s.SaveChart(ctx, kept))
+
+	_, err := s.DeleteChart(ctx, "", deleted.ChartId, time.Now().UTC().Add(-time.Minute))
+	assert.NoError(t, err)
+
+	log := zerolog.Nop()
+	janitor := storage.NewJanitor(&log, s, config.StorageConfig{
+		Kind:                    config.StorageKindMemory,
+		PurgeGracePeriodSeconds: 1,
+		PurgeIntervalSeconds:    1,
+	})
+
+	served := make(chan error)
+
+	go func() {
+		served <- janitor.Serve(ctx)
+	}()
+
+	assert.Eventually(t, func() bool {
+		_, deletedErr := s.GetChart(ctx, "", deleted.ChartId)
+		_, expiringErr := s.GetChart(ctx, "", expiring.ChartId)
+
+		return errors.Is(deletedErr, storage.ErrChartNotFound) && errors.Is(expiringErr, storage.ErrChartNotFound)
+	}, time.Second*5, time.Millisecond*100)
+
+	_, err = s.GetChart(ctx, "", kept.ChartId)
+	assert.NoError(t, err)
+
+	cancel()
+	assert.NoError(t, <-served)
+}
+
+func TestJanitor_Disabled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	log := zerolog.Nop()
+	janitor := storage.NewJanitor(&log, storage.NewMemory(), config.StorageConfig{
+		Kind:                 config.StorageKindMemory,
+		PurgeIntervalSeconds: 0,
+	})
+
+	served := make(chan error)
+
+	go func() {
+		served <- janitor.Serve(ctx)
+	}()
+
+	cancel()
+	assert.NoError(t, <-served)
+}
@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+const (
+	// PageSizeDefault represents charts list page size that is used if it's not specified.
+	PageSizeDefault = 20
+
+	// PageSizeMax represents the biggest charts list page size.
+	PageSizeMax = 100
+)
+
+var (
+	// ErrBadPageSize contains error message about page size that is out of the acceptable range.
+	ErrBadPageSize = fmt.Errorf("page size should be between 1 and %d", PageSizeMax)
+
+	// ErrBadPageToken contains error message about page token that can't be decoded.
+	ErrBadPageToken = errors.New("page token is not valid")
+)
+
+// ListOpts represents options to filter and paginate charts list.
+// Zero values mean that the corresponding filter is not used except Tenant
+// that is always matched since charts without tenant belong to the default tenant.
+type ListOpts struct {
+	Tenant        string
+	PageSize      int
+	PageToken     string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	ChartStatus   render.ChartStatus
+	TitleContains string
+}
+
+// ListResult represents a single page of the charts list.
+type ListResult struct {
+	Charts        []*render.ChartReply
+	NextPageToken string
+}
+
+// pageCursor represents a position of the last returned chart.
+type pageCursor struct {
+	CreatedAt int64  `json:"c"`
+	ChartID   string `json:"i"`
+}
+
+// listCharts filters, sorts and paginates the provided charts.
+// Expired charts are skipped, provided charts are not modified, returned charts are copies without chart data.
+func listCharts(charts []*render.ChartReply, opts ListOpts) (*ListResult, error) {
+	pageSize, err := validatePageSize(opts.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := decodePageToken(opts.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	filtered := make([]*render.ChartReply, 0, len(charts))
+
+	for _, chart := range charts {
+		if matchesListOpts(chart, opts, now) && isAfterCursor(chart, cursor) {
+			filtered = append(filtered, chart)
+		}
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return isNewer(filtered[i], filtered[j])
+	})
+
+	if len(filtered) <= pageSize {
+		return &ListResult{Charts: chartsMetadata(filtered), NextPageToken: ""}, nil
+	}
+
+	page := filtered[:pageSize]
+	last := page[len(page)-1]
+
+	return &ListResult{
+		Charts:        chartsMetadata(page),
+		NextPageToken: encodePageToken(pageCursor{CreatedAt: last.CreatedAt.AsTime().UnixNano(), ChartID: last.ChartId}),
+	}, nil
+}
+
+func validatePageSize(pageSize int) (int, error) {
+	if pageSize == 0 {
+		return PageSizeDefault, nil
+	}
+
+	if pageSize < 0 || pageSize > PageSizeMax {
+		return 0, ErrBadPageSize
+	}
+
+	return pageSize, nil
+}
+
+func matchesListOpts(chart *render.ChartReply, opts ListOpts, now time.Time) bool {
+	if !isAvailable(chart, opts.Tenant, now) {
+		return false
+	}
+
+	createdAt := chart.CreatedAt.AsTime()
+
+	if !opts.CreatedAfter.IsZero() && createdAt.Before(opts.CreatedAfter) {
+		return false
+	}
+
+	if !opts.CreatedBefore.IsZero() && !createdAt.Before(opts.CreatedBefore) {
+		return false
+	}
+
+	if opts.ChartStatus != render.ChartStatus_UNSPECIFIED_STATUS && chart.ChartStatus != opts.ChartStatus {
+		return false
+	}
+
+	if opts.TitleContains != "" && !strings.Contains(strings.ToLower(chart.Title), strings.ToLower(opts.TitleContains)) {
+		return false
+	}
+
+	return true
+}
+
+// isNewer reports if chart a goes before chart b in the list.
+// Charts are sorted by creation timestamp and chart ID in descending order.
+func isNewer(a, b *render.ChartReply) bool {
+	aCreatedAt, bCreatedAt := a.CreatedAt.AsTime().UnixNano(), b.CreatedAt.AsTime().UnixNano()
+	if aCreatedAt != bCreatedAt {
+		return aCreatedAt > bCreatedAt
+	}
+
+	return a.ChartId > b.ChartId
+}
+
+func isAfterCursor(chart *render.ChartReply, cursor *pageCursor) bool {
+	if cursor == nil {
+		return true
+	}
+
+	createdAt := chart.CreatedAt.AsTime().UnixNano()
+	if createdAt != cursor.CreatedAt {
+		return createdAt < cursor.CreatedAt
+	}
+
+	return chart.ChartId < cursor.ChartID
+}
+
+func chartsMetadata(charts []*render.ChartReply) []*render.ChartReply {
+	res := make([]*render.ChartReply, 0, len(charts))
+
+	for _, chart := range charts {
+		meta := cloneChart(chart)
+		meta.ChartData = nil
+		res = append(res, meta)
+	}
+
+	return res
+}
+
+func encodePageToken(cursor pageCursor) string {
+	// Marshalling of the struct with int and string fields can't fail.
+	data, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(token string) (*pageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrBadPageToken
+	}
+
+	cursor := &pageCursor{}
+
+	if err := json.Unmarshal(data, cursor); err != nil || cursor.ChartID == "" {
+		return nil, ErrBadPageToken
+	}
+
+	return cursor, nil
+}
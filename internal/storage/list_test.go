@@ -0,0 +1,210 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/storage"
+)
+
+func saveTestingCharts(t *testing.T, s storage.Storage) []*render.ChartReply {
+	t.Helper()
+
+	ts := time.Date(2021, 8, 22, 10, 0, 0, 0, time.UTC)
+	titles := []string{"Sales 2019", "Sales 2020", "Costs 2020", "Sales 2021", "Costs 2021"}
+	charts := make([]*render.ChartReply, 0, len(titles))
+
+	for i, title := range titles {
+		chart := testingChartReply(t)
+		chart.Title = title
+		chart.CreatedAt = timestamppb.New(ts.Add(time.Duration(i) * time.Hour))
+
+		if i == 2 {
+			chart.ChartStatus = render.ChartStatus_ERROR
+		}
+
+		if err := s.SaveChart(context.Background(), chart); err != nil {
+			t.Fatalf("unable to save testing chart: %s", err)
+		}
+
+		charts = append(charts, chart)
+	}
+
+	return charts
+}
+
+func chartIDs(charts []*render.ChartReply) []string {
+	ids := make([]string, 0, len(charts))
+
+	for _, chart := range charts {
+		ids = append(ids, chart.ChartId)
+	}
+
+	return ids
+}
+
+func TestListCharts_Filters(t *testing.T) {
+	t.Parallel()
+
+	disk, err := storage.NewDisk(t.TempDir())
+	if err != nil {
+		t.Fatalf("unable to configure disk storage: %s", err)
+	}
+
+	storages := []struct {
+		name    string
+		storage storage.Storage
+	}{
+		{
+			"memory",
+			storage.NewMemory(),
+		},
+		{
+			"disk",
+			disk,
+		},
+	}
+
+	for _, sc := range storages {
+		sc := sc
+		t.Run(sc.name, func(t *testing.T) {
+			t.Parallel()
+
+			charts := saveTestingCharts(t, sc.storage)
+
+			tt := []struct {
+				name     string
+				opts     storage.ListOpts
+				expected []string
+			}{
+				{
+					"no_filters",
+					storage.ListOpts{},
+					chartIDs([]*render.ChartReply{charts[4], charts[3], charts[2], charts[1], charts[0]}),
+				},
+				{
+					"created_range",
+					storage.ListOpts{
+						CreatedAfter:  charts[1].CreatedAt.AsTime(),
+						CreatedBefore: charts[3].CreatedAt.AsTime(),
+					},
+					chartIDs([]*render.ChartReply{charts[2], charts[1]}),
+				},
+				{
+					"chart_status",
+					storage.ListOpts{ChartStatus: render.ChartStatus_ERROR},
+					chartIDs([]*render.ChartReply{charts[2]}),
+				},
+				{
+					"title_contains",
+					storage.ListOpts{TitleContains: "sales 202"},
+					chartIDs([]*render.ChartReply{charts[3], charts[1]}),
+				},
+			}
+
+			for _, tc := range tt {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+
+					res, err := sc.storage.ListCharts(context.Background(), tc.opts)
+					assert.NoError(t, err)
+					assert.Equal(t, tc.expected, chartIDs(res.Charts))
+					assert.Empty(t, res.NextPageToken)
+
+					for _, chart := range res.Charts {
+						assert.Empty(t, chart.ChartData)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestListCharts_Pagination(t *testing.T) {
+	t.Parallel()
+
+	disk, err := storage.NewDisk(t.TempDir())
+	if err != nil {
+		t.Fatalf("unable to configure disk storage: %s", err)
+	}
+
+	tt := []struct {
+		name    string
+		storage storage.Storage
+	}{
+		{
+			"memory",
+			storage.NewMemory(),
+		},
+		{
+			"disk",
+			disk,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			charts := saveTestingCharts(t, tc.storage)
+
+			// Chart with the same creation timestamp should also be returned exactly once.
+			sameTSChart := testingChartReply(t)
+			sameTSChart.CreatedAt = charts[2].CreatedAt
+
+			if err := tc.storage.SaveChart(context.Background(), sameTSChart); err != nil {
+				t.Fatalf("unable to save testing chart: %s", err)
+			}
+
+			var (
+				listed    []string
+				pageToken string
+				pages     int
+			)
+
+			for {
+				res, err := tc.storage.ListCharts(context.Background(), storage.ListOpts{PageSize: 2, PageToken: pageToken})
+				if err != nil {
+					t.Fatalf("unable to list charts: %s", err)
+				}
+
+				assert.LessOrEqual(t, len(res.Charts), 2)
+
+				listed = append(listed, chartIDs(res.Charts)...)
+				pageToken = res.NextPageToken
+				pages++
+
+				if pageToken == "" {
+					break
+				}
+			}
+
+			assert.Equal(t, 3, pages)
+			assert.Len(t, listed, len(charts)+1)
+			assert.ElementsMatch(t, append(chartIDs(charts), sameTSChart.ChartId), listed)
+		})
+	}
+}
+
+func TestListCharts_Errs(t *testing.T) {
+	t.Parallel()
+
+	s := storage.NewMemory()
+
+	_, err := s.ListCharts(context.Background(), storage.ListOpts{PageSize: storage.PageSizeMax + 1})
+	assert.True(t, errors.Is(err, storage.ErrBadPageSize))
+
+	_, err = s.ListCharts(context.Background(), storage.ListOpts{PageSize: -1})
+	assert.True(t, errors.Is(err, storage.ErrBadPageSize))
+
+	_, err = s.ListCharts(context.Background(), storage.ListOpts{PageToken: "not a token"})
+	assert.True(t, errors.Is(err, storage.ErrBadPageToken))
+}
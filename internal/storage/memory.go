@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+// Memory implements Storage that keeps charts in memory.
+type Memory struct {
+	mu     sync.RWMutex
+	charts map[string]*render.ChartReply
+}
+
+// NewMemory returns a new Memory.
+func NewMemory() *Memory {
+	return &Memory{
+		charts: make(map[string]*render.ChartReply),
+	}
+}
+
+// SaveChart saves a copy of the provided chart.
+func (m *Memory) SaveChart(_ context.Context, chart *render.ChartReply) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.charts[chart.ChartId] = cloneChart(chart)
+
+	return nil
+}
+
+// UpdateChart replaces the saved chart with a copy of the provided one.
+func (m *Memory) UpdateChart(_ context.Context, chart *render.ChartReply) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	saved, ok := m.charts[chart.ChartId]
+	if !ok {
+		return ErrChartNotFound
+	}
+
+	if err := checkUpdatable(saved); err != nil {
+		return err
+	}
+
+	m.charts[chart.ChartId] = cloneChart(chart)
+
+	return nil
+}
+
+// GetChart returns a copy of the saved chart of the tenant.
+func (m *Memory) GetChart(_ context.Context, tenant, chartID string) (*render.ChartReply, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	chart, ok := m.charts[chartID]
+	if !ok || !isAvailable(chart, tenant, time.Now()) {
+		return nil, ErrChartNotFound
+	}
+
+	return cloneChart(chart), nil
+}
+
+// ListCharts returns a filtered page of saved charts.
+func (m *Memory) ListCharts(_ context.Context, opts ListOpts) (*ListResult, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	charts := make([]*render.ChartReply, 0, len(m.charts))
+	for _, chart := range m.charts {
+		charts = append(charts, chart)
+	}
+
+	return listCharts(charts, opts)
+}
+
+// DeleteChart replaces the saved chart of the tenant with its tombstone and returns a copy of it.
+func (m *Memory) DeleteChart(_ context.Context, tenant, chartID string, deletedAt time.Time) (*render.ChartReply, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	chart, ok := m.charts[chartID]
+	if !ok || !isAvailable(chart, tenant, time.Now()) {
+		return nil, ErrChartNotFound
+	}
+
+	tombstone(chart, deletedAt)
+
+	return cloneChart(chart), nil
+}
+
+// PurgeCharts removes tombstones of charts that were deleted before the provided timestamp.
+func (m *Memory) PurgeCharts(_ context.Context, deletedBefore time.Time) (int, error) {
+	return m.removeCharts(func(chart *render.ChartReply) bool {
+		return isPurgeable(chart, deletedBefore)
+	}), nil
+}
+
+// ExpireCharts removes charts that expired before the provided timestamp.
+func (m *Memory) ExpireCharts(_ context.Context, expiredBefore time.Time) (int, error) {
+	return m.removeCharts(func(chart *render.ChartReply) bool {
+		return isExpired(chart, expiredBefore)
+	}), nil
+}
+
+// Close does nothing since Memory doesn't hold any resources.
+func (m *Memory) Close() error {
+	return nil
+}
+
+// removeCharts removes charts that match the provided function and returns their count.
+func (m *Memory) removeCharts(match func(chart *render.ChartReply) bool) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	removed := 0
+
+	for chartID, chart := range m.charts {
+		if match(chart) {
+			delete(m.charts, chartID)
+
+			removed++
+		}
+	}
+
+	return removed
+}
+
+func cloneChart(chart *render.ChartReply) *render.ChartReply {
+	// nolint: forcetypeassert
+	return proto.Clone(chart).(*render.ChartReply)
+}
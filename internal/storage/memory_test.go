@@ -0,0 +1,56 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/storage"
+	"github.com/limpidchart/lc-api/internal/testutils"
+)
+
+func testingChartReply(t *testing.T) *render.ChartReply {
+	t.Helper()
+
+	ts := time.Date(2021, 8, 22, 10, 20, 30, 0, time.UTC)
+
+	return &render.ChartReply{
+		RequestId:   testutils.RandomUUID(t).String(),
+		ChartId:     testutils.RandomUUID(t).String(),
+		ChartStatus: render.ChartStatus_CREATED,
+		CreatedAt:   timestamppb.New(ts),
+		ChartData:   []byte("<svg>chart</svg>"),
+	}
+}
+
+func TestMemory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := storage.NewMemory()
+	chart := testingChartReply(t)
+
+	_, err := s.GetChart(ctx, "", chart.ChartId)
+	assert.True(t, errors.Is(err, storage.ErrChartNotFound))
+
+	assert.NoError(t, s.SaveChart(ctx, chart))
+
+	saved, err := s.GetChart(ctx, "", chart.ChartId)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(chart, saved))
+
+	// Saved chart should not be affected by changes of the returned one.
+	saved.ChartData = []byte("changed")
+
+	savedAgain, err := s.GetChart(ctx, "", chart.ChartId)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(chart, savedAgain))
+
+	assert.NoError(t, s.Close())
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+var (
+	// ErrChartNotFound contains error message about chart that is not found in storage.
+	ErrChartNotFound = errors.New("chart is not found")
+
+	// ErrUnknownStorageKind contains error message about unknown storage kind.
+	ErrUnknownStorageKind = errors.New("unknown storage kind")
+
+	// ErrChartDeleted contains error message about chart that is deleted and can't be updated.
+	ErrChartDeleted = errors.New("chart is deleted")
+)
+
+// Storage represents an entity that can save rendered charts and retrieve them by ID.
+// Deleted charts are kept as tombstones until they are purged, expired charts are removed right away
+// and are not found even if they are not removed yet.
+// Charts are only visible to their tenant, charts of other tenants are not found.
+type Storage interface {
+	SaveChart(ctx context.Context, chart *render.ChartReply) error
+	UpdateChart(ctx context.Context, chart *render.ChartReply) error
+	GetChart(ctx context.Context, tenant, chartID string) (*render.ChartReply, error)
+	ListCharts(ctx context.Context, opts ListOpts) (*ListResult, error)
+	DeleteChart(ctx context.Context, tenant, chartID string, deletedAt time.Time) (*render.ChartReply, error)
+	PurgeCharts(ctx context.Context, deletedBefore time.Time) (int, error)
+	ExpireCharts(ctx context.Context, expiredBefore time.Time) (int, error)
+	Close() error
+}
+
+// New configures a new Storage of the kind that is specified in config.
+func New(storageCfg config.StorageConfig) (Storage, error) {
+	switch storageCfg.Kind {
+	case config.StorageKindMemory:
+		return NewMemory(), nil
+	case config.StorageKindDisk:
+		return NewDisk(storageCfg.Dir)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStorageKind, storageCfg.Kind)
+	}
+}
+
+// tombstone marks the chart as deleted and removes its content.
+// Already deleted chart is not modified so its deletion timestamp is kept.
+func tombstone(chart *render.ChartReply, deletedAt time.Time) {
+	if chart.ChartStatus == render.ChartStatus_DELETED {
+		return
+	}
+
+	chart.ChartStatus = render.ChartStatus_DELETED
+	chart.DeletedAt = timestamppb.New(deletedAt)
+	chart.ChartData = nil
+	chart.Title = ""
+}
+
+// checkUpdatable checks if the saved chart can be replaced by its update.
+func checkUpdatable(saved *render.ChartReply) error {
+	if saved.ChartStatus == render.ChartStatus_DELETED {
+		return ErrChartDeleted
+	}
+
+	return nil
+}
+
+// belongsTo reports if the chart is owned by the tenant.
+func belongsTo(chart *render.ChartReply, tenant string) bool {
+	return chart.Tenant == tenant
+}
+
+// isAvailable reports if the chart is owned by the tenant and is not expired at the provided timestamp.
+func isAvailable(chart *render.ChartReply, tenant string, now time.Time) bool {
+	return belongsTo(chart, tenant) && !isExpired(chart, now)
+}
+
+// isExpired reports if the chart has an expiration timestamp that is before the provided one.
+func isExpired(chart *render.ChartReply, expiredBefore time.Time) bool {
+	return chart.ExpiresAt != nil && chart.ExpiresAt.AsTime().Before(expiredBefore)
+}
+
+// isPurgeable reports if the chart is a tombstone that was deleted before the provided timestamp.
+func isPurgeable(chart *render.ChartReply, deletedBefore time.Time) bool {
+	return chart.ChartStatus == render.ChartStatus_DELETED && chart.DeletedAt.AsTime().Before(deletedBefore)
+}
@@ -0,0 +1,26 @@
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/storage"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	memory, err := storage.New(config.StorageConfig{Kind: config.StorageKindMemory, Dir: ""})
+	assert.NoError(t, err)
+	assert.IsType(t, &storage.Memory{}, memory)
+
+	disk, err := storage.New(config.StorageConfig{Kind: config.StorageKindDisk, Dir: t.TempDir()})
+	assert.NoError(t, err)
+	assert.IsType(t, &storage.Disk{}, disk)
+
+	_, err = storage.New(config.StorageConfig{Kind: "redis", Dir: ""})
+	assert.True(t, errors.Is(err, storage.ErrUnknownStorageKind))
+}
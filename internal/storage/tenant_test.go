@@ -0,0 +1,80 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/storage"
+)
+
+func TestTenantIsolation(t *testing.T) {
+	t.Parallel()
+
+	disk, err := storage.NewDisk(t.TempDir())
+	if err != nil {
+		t.Fatalf("unable to configure disk storage: %s", err)
+	}
+
+	tt := []struct {
+		name    string
+		storage storage.Storage
+	}{
+		{
+			"memory",
+			storage.NewMemory(),
+		},
+		{
+			"disk",
+			disk,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			acmeChart, defaultChart := testingChartReply(t), testingChartReply(t)
+			acmeChart.Tenant = "acme"
+
+			assert.NoError(t, tc.storage.SaveChart(ctx, acmeChart))
+			assert.NoError(t, tc.storage.SaveChart(ctx, defaultChart))
+
+			saved, err := tc.storage.GetChart(ctx, "acme", acmeChart.ChartId)
+			assert.NoError(t, err)
+			assert.Equal(t, "acme", saved.Tenant)
+
+			_, err = tc.storage.GetChart(ctx, "globex", acmeChart.ChartId)
+			assert.True(t, errors.Is(err, storage.ErrChartNotFound))
+
+			_, err = tc.storage.GetChart(ctx, "", acmeChart.ChartId)
+			assert.True(t, errors.Is(err, storage.ErrChartNotFound))
+
+			_, err = tc.storage.GetChart(ctx, "acme", defaultChart.ChartId)
+			assert.True(t, errors.Is(err, storage.ErrChartNotFound))
+
+			_, err = tc.storage.DeleteChart(ctx, "globex", acmeChart.ChartId, time.Now().UTC())
+			assert.True(t, errors.Is(err, storage.ErrChartNotFound))
+
+			acmeList, err := tc.storage.ListCharts(ctx, storage.ListOpts{Tenant: "acme"})
+			assert.NoError(t, err)
+			assert.Len(t, acmeList.Charts, 1)
+			assert.Equal(t, acmeChart.ChartId, acmeList.Charts[0].ChartId)
+
+			defaultList, err := tc.storage.ListCharts(ctx, storage.ListOpts{})
+			assert.NoError(t, err)
+			assert.Len(t, defaultList.Charts, 1)
+			assert.Equal(t, defaultChart.ChartId, defaultList.Charts[0].ChartId)
+
+			tombstone, err := tc.storage.DeleteChart(ctx, "acme", acmeChart.ChartId, time.Now().UTC())
+			assert.NoError(t, err)
+			assert.Equal(t, "acme", tombstone.Tenant)
+		})
+	}
+}
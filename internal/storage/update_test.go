@@ -0,0 +1,71 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/storage"
+)
+
+func TestUpdateChart(t *testing.T) {
+	t.Parallel()
+
+	disk, err := storage.NewDisk(t.TempDir())
+	if err != nil {
+		t.Fatalf("unable to configure disk storage: %s", err)
+	}
+
+	tt := []struct {
+		name    string
+		storage storage.Storage
+	}{
+		{
+			"memory",
+			storage.NewMemory(),
+		},
+		{
+			"disk",
+			disk,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			pending := testingChartReply(t)
+			pending.ChartStatus = render.ChartStatus_PENDING
+			pending.ChartData = nil
+
+			assert.True(t, errors.Is(tc.storage.UpdateChart(ctx, pending), storage.ErrChartNotFound))
+			assert.NoError(t, tc.storage.SaveChart(ctx, pending))
+
+			created := testingChartReply(t)
+			created.ChartId = pending.ChartId
+
+			assert.NoError(t, tc.storage.UpdateChart(ctx, created))
+
+			saved, err := tc.storage.GetChart(ctx, "", pending.ChartId)
+			assert.NoError(t, err)
+			assert.Equal(t, render.ChartStatus_CREATED, saved.ChartStatus)
+			assert.Equal(t, created.ChartData, saved.ChartData)
+
+			// Tombstone of the deleted chart should not be replaced.
+			_, err = tc.storage.DeleteChart(ctx, "", pending.ChartId, time.Now().UTC())
+			assert.NoError(t, err)
+			assert.True(t, errors.Is(tc.storage.UpdateChart(ctx, created), storage.ErrChartDeleted))
+
+			saved, err = tc.storage.GetChart(ctx, "", pending.ChartId)
+			assert.NoError(t, err)
+			assert.Equal(t, render.ChartStatus_DELETED, saved.ChartStatus)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package tenant
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/cost"
+	"github.com/limpidchart/lc-api/internal/validate/apitorenderer"
+)
+
+var (
+	// ErrBadTenant contains error message about tenant header value that can't be used as a tenant.
+	ErrBadTenant = errors.New("tenant should contain from 1 to 64 letters, digits, dots, dashes or underscores")
+
+	// ErrTenantMismatch contains error message about tenant header value that disagrees with the caller identity tenant.
+	ErrTenantMismatch = errors.New("tenant doesn't match the tenant of the credentials")
+
+	// ErrBadOverridesFile contains error message about tenant overrides file that can't be parsed.
+	ErrBadOverridesFile = errors.New("bad tenant overrides file")
+)
+
+var tenantRe = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// Registry resolves tenants of the requests and keeps their overrides of chart limits and renderer timeout.
+type Registry struct {
+	trustedHeader string
+	overrides     map[string]override
+}
+
+type override struct {
+	limits          apitorenderer.Limits
+	rendererTimeout time.Duration
+	costLimits      cost.Limits
+}
+
+type overrideJSON struct {
+	MaxWidth               int32        `json:"max_width"`
+	MaxHeight              int32        `json:"max_height"`
+	RendererTimeoutSeconds int          `json:"renderer_timeout"`
+	DefaultMargins         *marginsJSON `json:"default_margins"`
+	MaxRequestCost         int64        `json:"max_request_cost"`
+	CostBudget             int64        `json:"cost_budget"`
+}
+
+type marginsJSON struct {
+	Top    *int32 `json:"top"`
+	Bottom *int32 `json:"bottom"`
+	Left   *int32 `json:"left"`
+	Right  *int32 `json:"right"`
+}
+
+// NewRegistry configures a new Registry.
+// Overrides are read from the JSON file that contains an object with overrides keyed by tenants.
+func NewRegistry(tenantCfg config.TenantConfig) (*Registry, error) {
+	r := &Registry{
+		trustedHeader: tenantCfg.TrustedHeader,
+		overrides:     make(map[string]override),
+	}
+
+	if tenantCfg.OverridesPath == "" {
+		return r, nil
+	}
+
+	raw, err := os.ReadFile(tenantCfg.OverridesPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read tenant overrides file: %w", err)
+	}
+
+	overridesJSON := make(map[string]overrideJSON)
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&overridesJSON); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrBadOverridesFile, err)
+	}
+
+	for tenant, overrideJSON := range overridesJSON {
+		o, err := newOverride(overrideJSON)
+		if err != nil {
+			return nil, fmt.Errorf("%w: tenant %q: %s", ErrBadOverridesFile, tenant, err)
+		}
+
+		r.overrides[tenant] = o
+	}
+
+	return r, nil
+}
+
+// TrustedHeader returns name of the header that contains tenant or an empty string if tenant is taken only from identity.
+func (r *Registry) TrustedHeader() string {
+	return r.trustedHeader
+}
+
+// Resolve returns tenant of the request.
+// Tenant of the identity takes precedence over the trusted header value that is rejected if it disagrees with it.
+// Trusted header value is used only for requests of the trusted proxies, other callers can't choose their tenant.
+// An empty string represents the default tenant.
+func (r *Registry) Resolve(identity *auth.Identity, headerValue string, fromTrustedProxy bool) (string, error) {
+	if r.trustedHeader == "" {
+		headerValue = ""
+	}
+
+	if identity != nil && identity.Tenant != "" {
+		if headerValue != "" && headerValue != identity.Tenant {
+			return "", ErrTenantMismatch
+		}
+
+		return identity.Tenant, nil
+	}
+
+	if headerValue == "" || !fromTrustedProxy {
+		return "", nil
+	}
+
+	if !tenantRe.MatchString(headerValue) {
+		return "", ErrBadTenant
+	}
+
+	return headerValue, nil
+}
+
+// Limits returns chart limits of the tenant.
+func (r *Registry) Limits(tenant string) apitorenderer.Limits {
+	return r.overrides[tenant].limits
+}
+
+// CostLimits returns render cost limits of the tenant, zero values mean that the configured ones are used.
+func (r *Registry) CostLimits(tenant string) cost.Limits {
+	return r.overrides[tenant].costLimits
+}
+
+// RendererTimeout returns lc-renderer request timeout of the tenant or the provided default one.
+func (r *Registry) RendererTimeout(tenant string, defaultTimeout time.Duration) time.Duration {
+	if timeout := r.overrides[tenant].rendererTimeout; timeout > 0 {
+		return timeout
+	}
+
+	return defaultTimeout
+}
+
+func newOverride(overrideJSON overrideJSON) (override, error) {
+	if overrideJSON.RendererTimeoutSeconds < 0 {
+		return override{}, errors.New("renderer_timeout should not be negative")
+	}
+
+	if overrideJSON.MaxRequestCost < 0 || overrideJSON.CostBudget < 0 {
+		return override{}, errors.New("max_request_cost and cost_budget should not be negative")
+	}
+
+	limits := apitorenderer.Limits{
+		MaxWidth:  overrideJSON.MaxWidth,
+		MaxHeight: overrideJSON.MaxHeight,
+	}
+
+	if margins := overrideJSON.DefaultMargins; margins != nil {
+		limits.MarginTopDefault = int32Value(margins.Top)
+		limits.MarginBottomDefault = int32Value(margins.Bottom)
+		limits.MarginLeftDefault = int32Value(margins.Left)
+		limits.MarginRightDefault = int32Value(margins.Right)
+	}
+
+	if err := limits.Validate(); err != nil {
+		return override{}, err
+	}
+
+	return override{
+		limits:          limits,
+		rendererTimeout: time.Duration(overrideJSON.RendererTimeoutSeconds) * time.Second,
+		costLimits: cost.Limits{
+			MaxRequestCost: overrideJSON.MaxRequestCost,
+			Budget:         overrideJSON.CostBudget,
+		},
+	}, nil
+}
+
+func int32Value(val *int32) *wrapperspb.Int32Value {
+	if val == nil {
+		return nil
+	}
+
+	return &wrapperspb.Int32Value{Value: *val}
+}
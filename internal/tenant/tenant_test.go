@@ -0,0 +1,160 @@
+package tenant_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/cost"
+	"github.com/limpidchart/lc-api/internal/tenant"
+	"github.com/limpidchart/lc-api/internal/validate/apitorenderer"
+)
+
+func writeOverrides(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "tenants.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("unable to write tenant overrides file: %s", err)
+	}
+
+	return path
+}
+
+func TestNewRegistry(t *testing.T) {
+	t.Parallel()
+
+	path := writeOverrides(t, `{
+  "acme": {"max_width": 1000, "max_height": 700, "renderer_timeout": 3, "default_margins": {"top": 10, "left": 20}, "max_request_cost": 100, "cost_budget": 1000},
+  "globex": {}
+}`)
+
+	registry, err := tenant.NewRegistry(config.TenantConfig{TrustedHeader: "X-Tenant-Id", OverridesPath: path})
+	if err != nil {
+		t.Fatalf("unable to configure tenant registry: %s", err)
+	}
+
+	assert.Equal(t, "X-Tenant-Id", registry.TrustedHeader())
+	assert.Equal(t, apitorenderer.Limits{
+		MaxWidth:          1000,
+		MaxHeight:         700,
+		MarginTopDefault:  &wrapperspb.Int32Value{Value: 10},
+		MarginLeftDefault: &wrapperspb.Int32Value{Value: 20},
+	}, registry.Limits("acme"))
+	assert.Equal(t, time.Second*3, registry.RendererTimeout("acme", time.Second*10))
+	assert.Equal(t, cost.Limits{MaxRequestCost: 100, Budget: 1000}, registry.CostLimits("acme"))
+
+	assert.Equal(t, apitorenderer.Limits{}, registry.Limits("globex"))
+	assert.Equal(t, time.Second*10, registry.RendererTimeout("globex", time.Second*10))
+	assert.Equal(t, cost.Limits{}, registry.CostLimits("globex"))
+
+	assert.Equal(t, apitorenderer.Limits{}, registry.Limits(""))
+	assert.Equal(t, time.Second*10, registry.RendererTimeout("", time.Second*10))
+}
+
+func TestNewRegistry_Err(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			"not_json",
+			`acme: {}`,
+			"bad tenant overrides file: invalid character 'a' looking for beginning of value",
+		},
+		{
+			"unknown_field",
+			`{"acme": {"max_depth": 10}}`,
+			`bad tenant overrides file: json: unknown field "max_depth"`,
+		},
+		{
+			"negative_timeout",
+			`{"acme": {"renderer_timeout": -1}}`,
+			`bad tenant overrides file: tenant "acme": renderer_timeout should not be negative`,
+		},
+		{
+			"negative_cost_budget",
+			`{"acme": {"cost_budget": -1}}`,
+			`bad tenant overrides file: tenant "acme": max_request_cost and cost_budget should not be negative`,
+		},
+		{
+			"too_big_width",
+			`{"acme": {"max_width": 200000}}`,
+			`bad tenant overrides file: tenant "acme": bad chart limits: max width should be from 800 to 100000`,
+		},
+		{
+			"negative_margin",
+			`{"acme": {"default_margins": {"bottom": -1}}}`,
+			`bad tenant overrides file: tenant "acme": bad chart limits: default margins should be from 0 to 100000`,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			registry, err := tenant.NewRegistry(config.TenantConfig{OverridesPath: writeOverrides(t, tc.content)})
+			assert.Nil(t, registry)
+			assert.True(t, errors.Is(err, tenant.ErrBadOverridesFile))
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestRegistry_Resolve(t *testing.T) {
+	t.Parallel()
+
+	withHeader, err := tenant.NewRegistry(config.TenantConfig{TrustedHeader: "X-Tenant-Id"})
+	if err != nil {
+		t.Fatalf("unable to configure tenant registry: %s", err)
+	}
+
+	withoutHeader, err := tenant.NewRegistry(config.TenantConfig{})
+	if err != nil {
+		t.Fatalf("unable to configure tenant registry: %s", err)
+	}
+
+	// nolint: govet
+	tt := []struct {
+		name             string
+		registry         *tenant.Registry
+		identity         *auth.Identity
+		headerValue      string
+		fromTrustedProxy bool
+		expectedTenant   string
+		expectedErr      error
+	}{
+		{"identity_tenant", withHeader, &auth.Identity{Subject: "reporting", Tenant: "acme"}, "", false, "acme", nil},
+		{"identity_tenant_same_header", withHeader, &auth.Identity{Subject: "reporting", Tenant: "acme"}, "acme", true, "acme", nil},
+		{"identity_tenant_other_header", withHeader, &auth.Identity{Subject: "reporting", Tenant: "acme"}, "globex", true, "", tenant.ErrTenantMismatch},
+		{"identity_without_tenant", withHeader, &auth.Identity{Subject: "reporting"}, "globex", true, "globex", nil},
+		{"identity_without_tenant_untrusted_proxy", withHeader, &auth.Identity{Subject: "reporting"}, "globex", false, "", nil},
+		{"header_tenant", withHeader, nil, "globex", true, "globex", nil},
+		{"header_tenant_untrusted_proxy", withHeader, nil, "globex", false, "", nil},
+		{"no_tenant", withHeader, nil, "", true, "", nil},
+		{"bad_header_tenant", withHeader, nil, "acme/../globex", true, "", tenant.ErrBadTenant},
+		{"untrusted_header", withoutHeader, nil, "globex", true, "", nil},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := tc.registry.Resolve(tc.identity, tc.headerValue, tc.fromTrustedProxy)
+			assert.Equal(t, tc.expectedTenant, actual)
+			assert.True(t, errors.Is(err, tc.expectedErr))
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package testutils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+)
+
+// APIKeysFile writes API keys file with hashes of the provided API keys keyed by identities and returns its path.
+func APIKeysFile(t *testing.T, apiKeys map[string]string) string {
+	t.Helper()
+
+	lines := make([]string, 0, len(apiKeys))
+	for identity, apiKey := range apiKeys {
+		lines = append(lines, fmt.Sprintf("%s %s", identity, auth.HashAPIKey(apiKey)))
+	}
+
+	path := filepath.Join(t.TempDir(), "api-keys")
+
+	// nolint: gomnd
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600); err != nil {
+		t.Fatalf("unable to write API keys file: %s", err)
+	}
+
+	return path
+}
@@ -1,6 +1,9 @@
 package testutils
 
 import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
@@ -24,6 +27,12 @@ func (req *CreateChartRequest) SetTitle() *CreateChartRequest {
 	return req
 }
 
+func (req *CreateChartRequest) SetExpiresIn(expiresIn time.Duration) *CreateChartRequest {
+	req.ExpiresIn = durationpb.New(expiresIn)
+
+	return req
+}
+
 func (req *CreateChartRequest) SetSizes() *CreateChartRequest {
 	// nolint: gomnd
 	req.Sizes = &render.ChartSizes{
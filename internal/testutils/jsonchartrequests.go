@@ -20,6 +20,12 @@ func (req *JSONCreateChartRequest) SetTitle() *JSONCreateChartRequest {
 	return req
 }
 
+func (req *JSONCreateChartRequest) SetExpiresIn(expiresInSecs int) *JSONCreateChartRequest {
+	req.Chart.ExpiresIn = &expiresInSecs
+
+	return req
+}
+
 func (req *JSONCreateChartRequest) SetSizes() *JSONCreateChartRequest {
 	// nolint: gomnd
 	req.Chart.Sizes = &view.ChartSizes{
@@ -0,0 +1,163 @@
+package testutils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/limpidchart/lc-api/internal/auth"
+)
+
+const (
+	jwtRSABits       = 2048
+	jwtES256CoordLen = 32
+)
+
+// JWTKeys contains private keys for every supported JWT signature algorithm.
+type JWTKeys struct {
+	rsa     *rsa.PrivateKey
+	ecdsa   *ecdsa.PrivateKey
+	ed25519 ed25519.PrivateKey
+}
+
+// NewJWTKeys generates new JWTKeys.
+func NewJWTKeys(t *testing.T) *JWTKeys {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, jwtRSABits)
+	if err != nil {
+		t.Fatalf("unable to generate RSA key: %s", err)
+	}
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate ECDSA key: %s", err)
+	}
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate Ed25519 key: %s", err)
+	}
+
+	return &JWTKeys{
+		rsa:     rsaKey,
+		ecdsa:   ecdsaKey,
+		ed25519: ed25519Key,
+	}
+}
+
+// JWKSFile writes JWKS file with public keys and returns its path.
+// Key IDs are equal to the algorithm names.
+func (k *JWTKeys) JWKSFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	k.WriteJWKSFile(t, path)
+
+	return path
+}
+
+// WriteJWKSFile writes JWKS file with public keys into the provided path.
+func (k *JWTKeys) WriteJWKSFile(t *testing.T, path string) {
+	t.Helper()
+
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": auth.AlgRS256,
+				"use": "sig",
+				"n":   b64(k.rsa.N.Bytes()),
+				"e":   b64(big.NewInt(int64(k.rsa.E)).Bytes()),
+			},
+			{
+				"kty": "EC",
+				"kid": auth.AlgES256,
+				"crv": "P-256",
+				"x":   b64(padBytes(k.ecdsa.X.Bytes(), jwtES256CoordLen)),
+				"y":   b64(padBytes(k.ecdsa.Y.Bytes(), jwtES256CoordLen)),
+			},
+			{
+				"kty": "OKP",
+				"kid": auth.AlgEdDSA,
+				"crv": "Ed25519",
+				"x":   b64(k.ed25519.Public().(ed25519.PublicKey)),
+			},
+		},
+	}
+
+	raw, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatalf("unable to marshal JWKS: %s", err)
+	}
+
+	// nolint: gomnd
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatalf("unable to write JWKS file: %s", err)
+	}
+}
+
+// Token returns JWT with the provided claims signed by the key of the provided algorithm.
+func (k *JWTKeys) Token(t *testing.T, alg string, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": alg, "typ": "JWT"})
+	if err != nil {
+		t.Fatalf("unable to marshal JWT header: %s", err)
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("unable to marshal JWT claims: %s", err)
+	}
+
+	signed := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+
+	switch alg {
+	case auth.AlgRS256:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k.rsa, crypto.SHA256, digest[:])
+	case auth.AlgES256:
+		var r, s *big.Int
+
+		r, s, err = ecdsa.Sign(rand.Reader, k.ecdsa, digest[:])
+		if err == nil {
+			signature = append(padBytes(r.Bytes(), jwtES256CoordLen), padBytes(s.Bytes(), jwtES256CoordLen)...)
+		}
+	case auth.AlgEdDSA:
+		signature = ed25519.Sign(k.ed25519, []byte(signed))
+	default:
+		t.Fatalf("unsupported JWT algorithm: %s", alg)
+	}
+
+	if err != nil {
+		t.Fatalf("unable to sign JWT: %s", err)
+	}
+
+	return strings.Join([]string{signed, b64(signature)}, ".")
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func padBytes(data []byte, size int) []byte {
+	if len(data) >= size {
+		return data
+	}
+
+	return append(make([]byte, size-len(data)), data...)
+}
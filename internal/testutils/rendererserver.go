@@ -2,13 +2,18 @@ package testutils
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 
 	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
@@ -29,18 +34,31 @@ var ErrRequestCancelled = errors.New("request to testing lc-renderer is cancelle
 // TestingChartRendererServer implements render.ChartRendererServer.
 type TestingChartRendererServer struct {
 	render.UnimplementedChartRendererServer
-	failMsg    string
-	grpcServer *grpc.Server
-	listener   *net.TCPListener
-	chartData  []byte
-	latency    time.Duration
+	failMsg     string
+	failCode    codes.Code
+	failFirst   int
+	grpcServer  *grpc.Server
+	health      *health.Server
+	listener    *net.TCPListener
+	chartData   []byte
+	latency     time.Duration
+	slowRequest int
+	slowLatency time.Duration
+	requests    int64
 }
 
 // Opts contains options to configure TestingChartRendererServer.
+// Requests fail with FailMsg and FailCode (InvalidArgument if it's not set), only the first FailFirst requests fail
+// if it's positive. Request number SlowRequest is rendered with SlowLatency instead of Latency.
 type Opts struct {
-	FailMsg   string
-	ChartData []byte
-	Latency   time.Duration
+	FailMsg     string
+	FailCode    codes.Code
+	FailFirst   int
+	ChartData   []byte
+	Latency     time.Duration
+	SlowRequest int
+	SlowLatency time.Duration
+	TLS         *tls.Config
 }
 
 // NewTestingChartRendererServer returns a new TestingChartRendererServer.
@@ -50,16 +68,33 @@ func NewTestingChartRendererServer(opts Opts) (*TestingChartRendererServer, erro
 		return nil, fmt.Errorf("unable to prepare local listener: %w", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	serverOpts := []grpc.ServerOption{}
+	if opts.TLS != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(opts.TLS)))
+	}
+
+	grpcServer := grpc.NewServer(serverOpts...)
+
+	failCode := opts.FailCode
+	if failCode == codes.OK {
+		failCode = codes.InvalidArgument
+	}
+
 	chartRendererServer := &TestingChartRendererServer{
-		failMsg:    opts.FailMsg,
-		grpcServer: grpcServer,
-		listener:   listener,
-		chartData:  opts.ChartData,
-		latency:    opts.Latency,
+		failMsg:     opts.FailMsg,
+		failCode:    failCode,
+		failFirst:   opts.FailFirst,
+		grpcServer:  grpcServer,
+		health:      health.NewServer(),
+		listener:    listener,
+		chartData:   opts.ChartData,
+		latency:     opts.Latency,
+		slowRequest: opts.SlowRequest,
+		slowLatency: opts.SlowLatency,
 	}
 
 	render.RegisterChartRendererServer(grpcServer, chartRendererServer)
+	grpc_health_v1.RegisterHealthServer(grpcServer, chartRendererServer.health)
 
 	return chartRendererServer, nil
 }
@@ -69,6 +104,21 @@ func (s *TestingChartRendererServer) Address() string {
 	return s.listener.Addr().String()
 }
 
+// Requests returns number of received render requests.
+func (s *TestingChartRendererServer) Requests() int {
+	return int(atomic.LoadInt64(&s.requests))
+}
+
+// SetServing sets status of the server grpc.health.v1 health service.
+func (s *TestingChartRendererServer) SetServing(serving bool) {
+	servingStatus := grpc_health_v1.HealthCheckResponse_SERVING
+	if !serving {
+		servingStatus = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+
+	s.health.SetServingStatus("", servingStatus)
+}
+
 // Serve gRPC server.
 func (s *TestingChartRendererServer) Serve(ctx context.Context) error {
 	serveErr := make(chan error)
@@ -94,15 +144,22 @@ func (s *TestingChartRendererServer) Serve(ctx context.Context) error {
 
 // RenderChart implements render.ChartRendererServer.RenderChart.
 func (s *TestingChartRendererServer) RenderChart(ctx context.Context, req *render.RenderChartRequest) (*render.RenderChartReply, error) {
+	request := int(atomic.AddInt64(&s.requests, 1))
+
+	latency := s.latency
+	if request == s.slowRequest {
+		latency = s.slowLatency
+	}
+
 	// Render chart with the provided latency.
-	renderTimer := time.NewTimer(s.latency)
+	renderTimer := time.NewTimer(latency)
 
 	select {
 	case <-ctx.Done():
 		return nil, ErrRequestCancelled
 	case <-renderTimer.C:
-		if s.failMsg != "" {
-			return nil, status.Errorf(codes.InvalidArgument, s.failMsg)
+		if s.failMsg != "" && (s.failFirst <= 0 || request <= s.failFirst) {
+			return nil, status.Errorf(s.failCode, s.failMsg)
 		}
 
 		return &render.RenderChartReply{
@@ -0,0 +1,115 @@
+package testutils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestingCA represents a certificate authority that issues testing certificates.
+type TestingCA struct {
+	cert   *x509.Certificate
+	key    *ecdsa.PrivateKey
+	serial int64
+
+	// CertPath contains path of the CA certificate PEM file.
+	CertPath string
+}
+
+// NewTestingCA creates a new TestingCA and writes its certificate to a temporary directory.
+func NewTestingCA(t *testing.T, name string) *TestingCA {
+	t.Helper()
+
+	key := newECDSAKey(t)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	raw, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unable to create CA certificate: %s", err)
+	}
+
+	cert, err := x509.ParseCertificate(raw)
+	if err != nil {
+		t.Fatalf("unable to parse CA certificate: %s", err)
+	}
+
+	return &TestingCA{
+		cert:     cert,
+		key:      key,
+		serial:   1,
+		CertPath: writePEM(t, name+"-ca.pem", "CERTIFICATE", raw),
+	}
+}
+
+// Issue issues a certificate that can be used by servers on localhost and by clients.
+// It returns paths of the certificate and its key PEM files.
+func (ca *TestingCA) Issue(t *testing.T, name string) (string, string) {
+	t.Helper()
+
+	ca.serial++
+
+	key := newECDSAKey(t)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(ca.serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+
+	raw, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %s", err)
+	}
+
+	rawKey, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("unable to marshal private key: %s", err)
+	}
+
+	return writePEM(t, name+".pem", "CERTIFICATE", raw), writePEM(t, name+"-key.pem", "EC PRIVATE KEY", rawKey)
+}
+
+func newECDSAKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate ECDSA key: %s", err)
+	}
+
+	return key
+}
+
+func writePEM(t *testing.T, name, blockType string, raw []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+
+	// nolint: gomnd
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: raw}), 0o600); err != nil {
+		t.Fatalf("unable to write PEM file: %s", err)
+	}
+
+	return path
+}
@@ -0,0 +1,247 @@
+package tlsutils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrBadCertificates contains error message about certificate, key or CA bundle files that can't be used.
+var ErrBadCertificates = errors.New("bad TLS certificates")
+
+// Certificates represents a certificate with its key and a CA bundle that are read from PEM files.
+// Files are re-read by Reload once any of them is changed, so connections use rotated certificates without a restart.
+type Certificates struct {
+	certPath string
+	keyPath  string
+	caPath   string
+
+	mu     sync.RWMutex
+	cert   *tls.Certificate
+	caPool *x509.CertPool
+	stamps map[string]fileStamp
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// LoadCertificates reads the certificate, its key and the CA bundle.
+// Certificate and key should be provided together, empty paths are skipped.
+func LoadCertificates(certPath, keyPath, caPath string) (*Certificates, error) {
+	if (certPath == "") != (keyPath == "") {
+		return nil, fmt.Errorf("%w: certificate and key should be provided together", ErrBadCertificates)
+	}
+
+	c := &Certificates{
+		certPath: certPath,
+		keyPath:  keyPath,
+		caPath:   caPath,
+	}
+
+	if _, err := c.Reload(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Reload re-reads the files if modification time or size of any of them is changed and reports if they are reloaded.
+// Previous certificates are kept if the files can't be read or parsed.
+func (c *Certificates) Reload() (bool, error) {
+	stamps, err := c.statFiles()
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.RLock()
+	unchanged := c.stamps != nil && equalStamps(stamps, c.stamps)
+	c.mu.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := loadKeyPair(c.certPath, c.keyPath)
+	if err != nil {
+		return false, err
+	}
+
+	caPool, err := loadCAPool(c.caPath)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	c.cert = cert
+	c.caPool = caPool
+	c.stamps = stamps
+	c.mu.Unlock()
+
+	return true, nil
+}
+
+// ServerConfig returns TLS configuration of a server that presents the certificate.
+// Client certificates are required and verified against the CA bundle if it's provided.
+func (c *Certificates) ServerConfig() *tls.Config {
+	cfg := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: c.getCertificate,
+	}
+
+	if c.caPath != "" {
+		// Chains are verified by VerifyPeerCertificate against the current CA bundle,
+		// tls.Config.ClientCAs can't be changed after the server is started.
+		cfg.ClientAuth = tls.RequireAnyClientCert
+		cfg.VerifyPeerCertificate = c.verifyClient
+	}
+
+	return cfg
+}
+
+// ClientConfig returns TLS configuration of a client that connects to the server with the provided name.
+// Server certificate is verified against the CA bundle if it's provided or against the system roots otherwise.
+// Certificate is presented to the server if it's provided.
+func (c *Certificates) ClientConfig(serverName string) *tls.Config {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+		// Chains are verified by VerifyPeerCertificate against the current CA bundle,
+		// tls.Config.RootCAs can't be changed after the connection is configured.
+		// nolint: gosec
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return c.verify(rawCerts, serverName, x509.ExtKeyUsageServerAuth)
+		},
+	}
+
+	if c.certPath != "" {
+		cfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return c.getCertificate(nil)
+		}
+	}
+
+	return cfg
+}
+
+func (c *Certificates) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.cert == nil {
+		return nil, fmt.Errorf("%w: certificate is not provided", ErrBadCertificates)
+	}
+
+	return c.cert, nil
+}
+
+func (c *Certificates) verifyClient(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	return c.verify(rawCerts, "", x509.ExtKeyUsageClientAuth)
+}
+
+func (c *Certificates) verify(rawCerts [][]byte, dnsName string, keyUsage x509.ExtKeyUsage) error {
+	if len(rawCerts) == 0 {
+		return errors.New("certificate is not provided")
+	}
+
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+
+	for _, rawCert := range rawCerts {
+		cert, err := x509.ParseCertificate(rawCert)
+		if err != nil {
+			return fmt.Errorf("unable to parse certificate: %w", err)
+		}
+
+		certs = append(certs, cert)
+	}
+
+	c.mu.RLock()
+	roots := c.caPool
+	c.mu.RUnlock()
+
+	opts := x509.VerifyOptions{
+		DNSName:       dnsName,
+		Roots:         roots,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{keyUsage},
+	}
+
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+
+	if _, err := certs[0].Verify(opts); err != nil {
+		return fmt.Errorf("unable to verify certificate: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Certificates) statFiles() (map[string]fileStamp, error) {
+	stamps := make(map[string]fileStamp)
+
+	for _, path := range []string{c.certPath, c.keyPath, c.caPath} {
+		if path == "" {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to stat file: %s", ErrBadCertificates, err)
+		}
+
+		stamps[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+	}
+
+	return stamps, nil
+}
+
+func equalStamps(first, second map[string]fileStamp) bool {
+	if len(first) != len(second) {
+		return false
+	}
+
+	for path, stamp := range first {
+		if other, ok := second[path]; !ok || !stamp.modTime.Equal(other.modTime) || stamp.size != other.size {
+			return false
+		}
+	}
+
+	return true
+}
+
+func loadKeyPair(certPath, keyPath string) (*tls.Certificate, error) {
+	if certPath == "" {
+		return nil, nil
+	}
+
+	keyPair, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to load key pair: %s", ErrBadCertificates, err)
+	}
+
+	return &keyPair, nil
+}
+
+func loadCAPool(caPath string) (*x509.CertPool, error) {
+	if caPath == "" {
+		return nil, nil
+	}
+
+	raw, err := os.ReadFile(caPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to read CA bundle: %s", ErrBadCertificates, err)
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(raw) {
+		return nil, fmt.Errorf("%w: CA bundle doesn't contain PEM certificates", ErrBadCertificates)
+	}
+
+	return caPool, nil
+}
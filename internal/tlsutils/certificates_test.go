@@ -0,0 +1,240 @@
+package tlsutils_test
+
+import (
+	"crypto/tls"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/tcputils"
+	"github.com/limpidchart/lc-api/internal/testutils"
+	"github.com/limpidchart/lc-api/internal/tlsutils"
+)
+
+// handshake performs TLS handshake between the server and client configurations over a local TCP connection.
+// It returns handshake errors of both sides.
+func handshake(t *testing.T, serverCfg, clientCfg *tls.Config) (error, error) {
+	t.Helper()
+
+	listener, err := tcputils.LocalListenerWithRandomPort()
+	if err != nil {
+		t.Fatalf("unable to prepare local listener: %s", err)
+	}
+
+	defer listener.Close()
+
+	serverErrs := make(chan error, 1)
+
+	go func() {
+		conn, acceptErr := listener.Accept()
+		if acceptErr != nil {
+			serverErrs <- acceptErr
+
+			return
+		}
+
+		defer conn.Close()
+
+		serverErrs <- tls.Server(conn, serverCfg).Handshake()
+	}()
+
+	clientConn, clientErr := tls.Dial("tcp", listener.Addr().String(), clientCfg)
+	serverErr := <-serverErrs
+
+	if clientErr == nil {
+		clientConn.Close()
+	}
+
+	return serverErr, clientErr
+}
+
+func loadCertificates(t *testing.T, certPath, keyPath, caPath string) *tlsutils.Certificates {
+	t.Helper()
+
+	certs, err := tlsutils.LoadCertificates(certPath, keyPath, caPath)
+	if err != nil {
+		t.Fatalf("unable to load certificates: %s", err)
+	}
+
+	return certs
+}
+
+func TestLoadCertificates_Err(t *testing.T) {
+	t.Parallel()
+
+	ca := testutils.NewTestingCA(t, "lc")
+	certPath, keyPath := ca.Issue(t, "server")
+
+	notPEMPath := filepath.Join(t.TempDir(), "not-pem")
+	if err := os.WriteFile(notPEMPath, []byte("certificate"), 0o600); err != nil {
+		t.Fatalf("unable to write file: %s", err)
+	}
+
+	tt := []struct {
+		name     string
+		certPath string
+		keyPath  string
+		caPath   string
+		expected string
+	}{
+		{
+			"no_key",
+			certPath,
+			"",
+			"",
+			"bad TLS certificates: certificate and key should be provided together",
+		},
+		{
+			"missing_cert",
+			filepath.Join(t.TempDir(), "missing.pem"),
+			keyPath,
+			"",
+			"bad TLS certificates: unable to stat file",
+		},
+		{
+			"bad_key_pair",
+			notPEMPath,
+			keyPath,
+			"",
+			"bad TLS certificates: unable to load key pair",
+		},
+		{
+			"bad_ca",
+			certPath,
+			keyPath,
+			notPEMPath,
+			"bad TLS certificates: CA bundle doesn't contain PEM certificates",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			certs, err := tlsutils.LoadCertificates(tc.certPath, tc.keyPath, tc.caPath)
+			assert.Nil(t, certs)
+			assert.True(t, errors.Is(err, tlsutils.ErrBadCertificates))
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestCertificates_TLS(t *testing.T) {
+	t.Parallel()
+
+	ca := testutils.NewTestingCA(t, "lc")
+	serverCertPath, serverKeyPath := ca.Issue(t, "server")
+
+	serverCerts := loadCertificates(t, serverCertPath, serverKeyPath, "")
+	clientCerts := loadCertificates(t, "", "", ca.CertPath)
+
+	serverErr, clientErr := handshake(t, serverCerts.ServerConfig(), clientCerts.ClientConfig("localhost"))
+	assert.NoError(t, serverErr)
+	assert.NoError(t, clientErr)
+
+	// Server certificate is not issued for other names.
+	_, clientErr = handshake(t, serverCerts.ServerConfig(), clientCerts.ClientConfig("lc-renderer"))
+	assert.Error(t, clientErr)
+
+	// Server certificate is not trusted by other CAs.
+	otherCA := testutils.NewTestingCA(t, "other")
+	_, clientErr = handshake(t, serverCerts.ServerConfig(), loadCertificates(t, "", "", otherCA.CertPath).ClientConfig("localhost"))
+	assert.Error(t, clientErr)
+}
+
+func TestCertificates_MutualTLS(t *testing.T) {
+	t.Parallel()
+
+	ca := testutils.NewTestingCA(t, "lc")
+	serverCertPath, serverKeyPath := ca.Issue(t, "server")
+	clientCertPath, clientKeyPath := ca.Issue(t, "client")
+
+	serverCerts := loadCertificates(t, serverCertPath, serverKeyPath, ca.CertPath)
+	clientCerts := loadCertificates(t, clientCertPath, clientKeyPath, ca.CertPath)
+
+	serverErr, clientErr := handshake(t, serverCerts.ServerConfig(), clientCerts.ClientConfig("127.0.0.1"))
+	assert.NoError(t, serverErr)
+	assert.NoError(t, clientErr)
+
+	// Clients without certificates are rejected.
+	serverErr, _ = handshake(t, serverCerts.ServerConfig(), loadCertificates(t, "", "", ca.CertPath).ClientConfig("127.0.0.1"))
+	assert.Error(t, serverErr)
+
+	// Clients with certificates of other CAs are rejected.
+	otherCA := testutils.NewTestingCA(t, "other")
+	otherCertPath, otherKeyPath := otherCA.Issue(t, "client")
+	serverErr, _ = handshake(t, serverCerts.ServerConfig(), loadCertificates(t, otherCertPath, otherKeyPath, ca.CertPath).ClientConfig("127.0.0.1"))
+	assert.Error(t, serverErr)
+}
+
+func TestCertificates_Reload(t *testing.T) {
+	t.Parallel()
+
+	ca := testutils.NewTestingCA(t, "lc")
+	serverCertPath, serverKeyPath := ca.Issue(t, "server")
+
+	// Server trusts only clients of the rotated CA once its bundle is reloaded.
+	rotatedCA := testutils.NewTestingCA(t, "rotated")
+	clientCertPath, clientKeyPath := rotatedCA.Issue(t, "client")
+
+	clientCAPath := filepath.Join(t.TempDir(), "client-ca.pem")
+	copyFile(t, ca.CertPath, clientCAPath)
+
+	serverCerts := loadCertificates(t, serverCertPath, serverKeyPath, clientCAPath)
+	clientCerts := loadCertificates(t, clientCertPath, clientKeyPath, ca.CertPath)
+	serverCfg := serverCerts.ServerConfig()
+
+	reloaded, err := serverCerts.Reload()
+	assert.NoError(t, err)
+	assert.False(t, reloaded)
+
+	serverErr, _ := handshake(t, serverCfg, clientCerts.ClientConfig("localhost"))
+	assert.Error(t, serverErr)
+
+	copyFile(t, rotatedCA.CertPath, clientCAPath)
+
+	reloaded, err = serverCerts.Reload()
+	assert.NoError(t, err)
+	assert.True(t, reloaded)
+
+	serverErr, clientErr := handshake(t, serverCfg, clientCerts.ClientConfig("localhost"))
+	assert.NoError(t, serverErr)
+	assert.NoError(t, clientErr)
+
+	// Previous certificates are kept if files can't be parsed.
+	if err = os.WriteFile(clientCAPath, []byte("rotating"), 0o600); err != nil {
+		t.Fatalf("unable to write file: %s", err)
+	}
+
+	reloaded, err = serverCerts.Reload()
+	assert.True(t, errors.Is(err, tlsutils.ErrBadCertificates))
+	assert.False(t, reloaded)
+
+	serverErr, clientErr = handshake(t, serverCfg, clientCerts.ClientConfig("localhost"))
+	assert.NoError(t, serverErr)
+	assert.NoError(t, clientErr)
+}
+
+// copyFile copies the file and moves its modification time forward, so it's reloaded even if its size is unchanged.
+func copyFile(t *testing.T, src, dst string) {
+	t.Helper()
+
+	raw, err := os.ReadFile(src)
+	if err != nil {
+		t.Fatalf("unable to read file: %s", err)
+	}
+
+	if err = os.WriteFile(dst, raw, 0o600); err != nil {
+		t.Fatalf("unable to write file: %s", err)
+	}
+
+	modTime := time.Now().Add(time.Minute)
+	if err = os.Chtimes(dst, modTime, modTime); err != nil {
+		t.Fatalf("unable to change file times: %s", err)
+	}
+}
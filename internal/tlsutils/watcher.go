@@ -0,0 +1,88 @@
+package tlsutils
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/limpidchart/lc-api/internal/config"
+)
+
+const watcherName = "TLS certificates watcher"
+
+// Watcher periodically re-reads the certificates files.
+type Watcher struct {
+	log          *zerolog.Logger
+	certificates map[string]*Certificates
+	interval     time.Duration
+}
+
+// NewWatcher configures a new Watcher of the certificates keyed by their names.
+// Nil certificates are not watched.
+func NewWatcher(log *zerolog.Logger, tlsCfg config.TLSConfig, certificates map[string]*Certificates) *Watcher {
+	watched := make(map[string]*Certificates, len(certificates))
+
+	for name, certs := range certificates {
+		if certs != nil {
+			watched[name] = certs
+		}
+	}
+
+	return &Watcher{
+		log:          log,
+		certificates: watched,
+		interval:     time.Duration(tlsCfg.ReloadIntervalSeconds) * time.Second,
+	}
+}
+
+// Serve reloads certificates until the provided context is done.
+// It only waits for the context if there are no certificates to watch.
+func (w *Watcher) Serve(ctx context.Context) error {
+	if len(w.certificates) == 0 || w.interval <= 0 {
+		<-ctx.Done()
+
+		return nil
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info().
+				Time(zerolog.TimestampFieldName, time.Now().UTC()).
+				Msg("Stopping TLS certificates watcher")
+
+			return nil
+		case <-ticker.C:
+			w.reload()
+		}
+	}
+}
+
+// Address returns an empty string since Watcher doesn't listen on any address.
+func (w *Watcher) Address() string {
+	return ""
+}
+
+// Name returns watcher name.
+func (w *Watcher) Name() string {
+	return watcherName
+}
+
+func (w *Watcher) reload() {
+	for name, certs := range w.certificates {
+		reloaded, err := certs.Reload()
+		if err != nil {
+			w.log.Error().Time(zerolog.TimestampFieldName, time.Now().UTC()).Str("certificates", name).Err(err).Msg("Unable to reload TLS certificates")
+
+			continue
+		}
+
+		if reloaded {
+			w.log.Info().Time(zerolog.TimestampFieldName, time.Now().UTC()).Str("certificates", name).Msg("Reloaded TLS certificates")
+		}
+	}
+}
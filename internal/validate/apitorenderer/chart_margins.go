@@ -46,31 +46,36 @@ var (
 
 // ValidateChartMargins check if every chart margin value is specified and in acceptable range.
 func ValidateChartMargins(chartMargins *render.ChartMargins) (*render.ChartMargins, error) {
+	return ValidateChartMarginsWithLimits(chartMargins, Limits{})
+}
+
+// ValidateChartMarginsWithLimits is like ValidateChartMargins but uses default margins from the provided limits.
+func ValidateChartMarginsWithLimits(chartMargins *render.ChartMargins, limits Limits) (*render.ChartMargins, error) {
 	if chartMargins == nil {
 		return &render.ChartMargins{
-			MarginTop:    &wrapperspb.Int32Value{Value: marginTopDefault},
-			MarginBottom: &wrapperspb.Int32Value{Value: marginBottomDefault},
-			MarginLeft:   &wrapperspb.Int32Value{Value: marginLeftDefault},
-			MarginRight:  &wrapperspb.Int32Value{Value: marginRightDefault},
+			MarginTop:    &wrapperspb.Int32Value{Value: limits.marginTopDefault()},
+			MarginBottom: &wrapperspb.Int32Value{Value: limits.marginBottomDefault()},
+			MarginLeft:   &wrapperspb.Int32Value{Value: limits.marginLeftDefault()},
+			MarginRight:  &wrapperspb.Int32Value{Value: limits.marginRightDefault()},
 		}, nil
 	}
 
-	chartMargins, err := validateTopMargin(chartMargins)
+	chartMargins, err := validateTopMargin(chartMargins, limits)
 	if err != nil {
 		return nil, err
 	}
 
-	chartMargins, err = validateBottomMargin(chartMargins)
+	chartMargins, err = validateBottomMargin(chartMargins, limits)
 	if err != nil {
 		return nil, err
 	}
 
-	chartMargins, err = validateLeftMargin(chartMargins)
+	chartMargins, err = validateLeftMargin(chartMargins, limits)
 	if err != nil {
 		return nil, err
 	}
 
-	chartMargins, err = validateRightMargin(chartMargins)
+	chartMargins, err = validateRightMargin(chartMargins, limits)
 	if err != nil {
 		return nil, err
 	}
@@ -78,9 +83,9 @@ func ValidateChartMargins(chartMargins *render.ChartMargins) (*render.ChartMargi
 	return chartMargins, nil
 }
 
-func validateTopMargin(chartMargins *render.ChartMargins) (*render.ChartMargins, error) {
+func validateTopMargin(chartMargins *render.ChartMargins, limits Limits) (*render.ChartMargins, error) {
 	if chartMargins.MarginTop == nil {
-		chartMargins.MarginTop = &wrapperspb.Int32Value{Value: marginTopDefault}
+		chartMargins.MarginTop = &wrapperspb.Int32Value{Value: limits.marginTopDefault()}
 
 		return chartMargins, nil
 	}
@@ -96,9 +101,9 @@ func validateTopMargin(chartMargins *render.ChartMargins) (*render.ChartMargins,
 	return chartMargins, nil
 }
 
-func validateBottomMargin(chartMargins *render.ChartMargins) (*render.ChartMargins, error) {
+func validateBottomMargin(chartMargins *render.ChartMargins, limits Limits) (*render.ChartMargins, error) {
 	if chartMargins.MarginBottom == nil {
-		chartMargins.MarginBottom = &wrapperspb.Int32Value{Value: marginBottomDefault}
+		chartMargins.MarginBottom = &wrapperspb.Int32Value{Value: limits.marginBottomDefault()}
 
 		return chartMargins, nil
 	}
@@ -114,9 +119,9 @@ func validateBottomMargin(chartMargins *render.ChartMargins) (*render.ChartMargi
 	return chartMargins, nil
 }
 
-func validateLeftMargin(chartMargins *render.ChartMargins) (*render.ChartMargins, error) {
+func validateLeftMargin(chartMargins *render.ChartMargins, limits Limits) (*render.ChartMargins, error) {
 	if chartMargins.MarginLeft == nil {
-		chartMargins.MarginLeft = &wrapperspb.Int32Value{Value: marginLeftDefault}
+		chartMargins.MarginLeft = &wrapperspb.Int32Value{Value: limits.marginLeftDefault()}
 
 		return chartMargins, nil
 	}
@@ -132,9 +137,9 @@ func validateLeftMargin(chartMargins *render.ChartMargins) (*render.ChartMargins
 	return chartMargins, nil
 }
 
-func validateRightMargin(chartMargins *render.ChartMargins) (*render.ChartMargins, error) {
+func validateRightMargin(chartMargins *render.ChartMargins, limits Limits) (*render.ChartMargins, error) {
 	if chartMargins.MarginRight == nil {
-		chartMargins.MarginRight = &wrapperspb.Int32Value{Value: marginRightDefault}
+		chartMargins.MarginRight = &wrapperspb.Int32Value{Value: limits.marginRightDefault()}
 
 		return chartMargins, nil
 	}
@@ -35,26 +35,27 @@ var (
 
 // ValidateChartSizes check if every chart size value is specified and in acceptable range.
 func ValidateChartSizes(chartSizes *render.ChartSizes) (*render.ChartSizes, error) {
+	return ValidateChartSizesWithLimits(chartSizes, Limits{})
+}
+
+// ValidateChartSizesWithLimits is like ValidateChartSizes but also checks sizes against the provided limits.
+// Default sizes of the omitted values are checked against the limits too.
+func ValidateChartSizesWithLimits(chartSizes *render.ChartSizes, limits Limits) (*render.ChartSizes, error) {
 	if chartSizes == nil {
-		return &render.ChartSizes{
-			Width:  &wrapperspb.Int32Value{Value: widthDefault},
-			Height: &wrapperspb.Int32Value{Value: heightDefault},
-		}, nil
+		chartSizes = &render.ChartSizes{}
 	}
 
-	chartSizes, err := validateChartWidth(chartSizes)
+	chartSizes, err := validateChartWidth(chartSizes, limits)
 	if err != nil {
 		return nil, err
 	}
 
-	return validateChartHeight(chartSizes)
+	return validateChartHeight(chartSizes, limits)
 }
 
-func validateChartWidth(chartSizes *render.ChartSizes) (*render.ChartSizes, error) {
+func validateChartWidth(chartSizes *render.ChartSizes, limits Limits) (*render.ChartSizes, error) {
 	if chartSizes.Width == nil {
 		chartSizes.Width = &wrapperspb.Int32Value{Value: widthDefault}
-
-		return chartSizes, nil
 	}
 
 	if chartSizes.Width.Value > chartSizeMaxWidth {
@@ -65,14 +66,16 @@ func validateChartWidth(chartSizes *render.ChartSizes) (*render.ChartSizes, erro
 		return nil, ErrChartSizeWidthIsTooSmall
 	}
 
+	if limits.MaxWidth != 0 && chartSizes.Width.Value > limits.MaxWidth {
+		return nil, fmt.Errorf("%w: max width is %d", ErrChartSizeExceedsLimit, limits.MaxWidth)
+	}
+
 	return chartSizes, nil
 }
 
-func validateChartHeight(chartSizes *render.ChartSizes) (*render.ChartSizes, error) {
+func validateChartHeight(chartSizes *render.ChartSizes, limits Limits) (*render.ChartSizes, error) {
 	if chartSizes.Height == nil {
 		chartSizes.Height = &wrapperspb.Int32Value{Value: heightDefault}
-
-		return chartSizes, nil
 	}
 
 	if chartSizes.Height.Value > chartSizeMaxHeight {
@@ -83,5 +86,9 @@ func validateChartHeight(chartSizes *render.ChartSizes) (*render.ChartSizes, err
 		return nil, ErrChartSizeHeightIsTooSmall
 	}
 
+	if limits.MaxHeight != 0 && chartSizes.Height.Value > limits.MaxHeight {
+		return nil, fmt.Errorf("%w: max height is %d", ErrChartSizeExceedsLimit, limits.MaxHeight)
+	}
+
 	return chartSizes, nil
 }
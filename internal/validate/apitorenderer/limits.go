@@ -0,0 +1,72 @@
+package apitorenderer
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	// ErrChartSizeExceedsLimit contains error message about chart size that is acceptable in general
+	// but is bigger than the limit of the caller.
+	ErrChartSizeExceedsLimit = errors.New("chart size exceeds the limit")
+
+	// ErrBadLimits contains error message about limits that are out of the acceptable ranges.
+	ErrBadLimits = errors.New("bad chart limits")
+)
+
+// Limits represents chart limits and defaults that are layered on top of the package constants.
+// Zero max sizes and nil default margins mean that the constants are used.
+type Limits struct {
+	MaxWidth  int32
+	MaxHeight int32
+
+	MarginTopDefault    *wrapperspb.Int32Value
+	MarginBottomDefault *wrapperspb.Int32Value
+	MarginLeftDefault   *wrapperspb.Int32Value
+	MarginRightDefault  *wrapperspb.Int32Value
+}
+
+// Validate checks that limits don't loosen the package constants and don't reject charts with the default sizes.
+func (l Limits) Validate() error {
+	if l.MaxWidth != 0 && (l.MaxWidth < widthDefault || l.MaxWidth > chartSizeMaxWidth) {
+		return fmt.Errorf("%w: max width should be from %d to %d", ErrBadLimits, widthDefault, chartSizeMaxWidth)
+	}
+
+	if l.MaxHeight != 0 && (l.MaxHeight < heightDefault || l.MaxHeight > chartSizeMaxHeight) {
+		return fmt.Errorf("%w: max height should be from %d to %d", ErrBadLimits, heightDefault, chartSizeMaxHeight)
+	}
+
+	for _, margin := range []*wrapperspb.Int32Value{l.MarginTopDefault, l.MarginBottomDefault, l.MarginLeftDefault, l.MarginRightDefault} {
+		if margin != nil && (margin.Value < chartMarginMin || margin.Value > chartMarginMax) {
+			return fmt.Errorf("%w: default margins should be from %d to %d", ErrBadLimits, chartMarginMin, chartMarginMax)
+		}
+	}
+
+	return nil
+}
+
+func (l Limits) marginTopDefault() int32 {
+	return int32ValueOrDefault(l.MarginTopDefault, marginTopDefault)
+}
+
+func (l Limits) marginBottomDefault() int32 {
+	return int32ValueOrDefault(l.MarginBottomDefault, marginBottomDefault)
+}
+
+func (l Limits) marginLeftDefault() int32 {
+	return int32ValueOrDefault(l.MarginLeftDefault, marginLeftDefault)
+}
+
+func (l Limits) marginRightDefault() int32 {
+	return int32ValueOrDefault(l.MarginRightDefault, marginRightDefault)
+}
+
+func int32ValueOrDefault(val *wrapperspb.Int32Value, defaultVal int32) int32 {
+	if val == nil {
+		return defaultVal
+	}
+
+	return val.Value
+}
@@ -0,0 +1,134 @@
+package apitorenderer_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/validate/apitorenderer"
+)
+
+func TestLimitsValidate(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name        string
+		limits      apitorenderer.Limits
+		expectedErr error
+	}{
+		{"no_limits", apitorenderer.Limits{}, nil},
+		{"max_sizes", apitorenderer.Limits{MaxWidth: 1000, MaxHeight: 1000}, nil},
+		{"width_is_too_big", apitorenderer.Limits{MaxWidth: 100_001}, apitorenderer.ErrBadLimits},
+		{"height_is_too_small", apitorenderer.Limits{MaxHeight: 5}, apitorenderer.ErrBadLimits},
+		{"width_is_below_default", apitorenderer.Limits{MaxWidth: 400}, apitorenderer.ErrBadLimits},
+		{"height_is_below_default", apitorenderer.Limits{MaxHeight: 599}, apitorenderer.ErrBadLimits},
+		{"margin_is_negative", apitorenderer.Limits{MarginRightDefault: &wrapperspb.Int32Value{Value: -1}}, apitorenderer.ErrBadLimits},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.True(t, errors.Is(tc.limits.Validate(), tc.expectedErr))
+		})
+	}
+}
+
+func TestValidateChartSizesWithLimits(t *testing.T) {
+	t.Parallel()
+
+	limits := apitorenderer.Limits{MaxWidth: 500, MaxHeight: 400}
+
+	// nolint: govet
+	tt := []struct {
+		name               string
+		chartSizes         *render.ChartSizes
+		expectedChartSizes *render.ChartSizes
+		expectedErr        string
+	}{
+		{
+			"within_limits",
+			&render.ChartSizes{
+				Width:  &wrapperspb.Int32Value{Value: 500},
+				Height: &wrapperspb.Int32Value{Value: 400},
+			},
+			&render.ChartSizes{
+				Width:  &wrapperspb.Int32Value{Value: 500},
+				Height: &wrapperspb.Int32Value{Value: 400},
+			},
+			"",
+		},
+		{
+			"width_exceeds_limit",
+			&render.ChartSizes{
+				Width:  &wrapperspb.Int32Value{Value: 501},
+				Height: &wrapperspb.Int32Value{Value: 400},
+			},
+			nil,
+			"chart size exceeds the limit: max width is 500",
+		},
+		{
+			"height_exceeds_limit",
+			&render.ChartSizes{
+				Width:  &wrapperspb.Int32Value{Value: 500},
+				Height: &wrapperspb.Int32Value{Value: 401},
+			},
+			nil,
+			"chart size exceeds the limit: max height is 400",
+		},
+		{
+			"default_height_exceeds_limit",
+			&render.ChartSizes{
+				Width: &wrapperspb.Int32Value{Value: 500},
+			},
+			nil,
+			"chart size exceeds the limit: max height is 400",
+		},
+		{
+			"default_sizes_exceed_limits",
+			nil,
+			nil,
+			"chart size exceeds the limit: max width is 500",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := apitorenderer.ValidateChartSizesWithLimits(tc.chartSizes, limits)
+			if tc.expectedErr != "" {
+				assert.True(t, errors.Is(err, apitorenderer.ErrChartSizeExceedsLimit))
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expectedChartSizes, actual)
+		})
+	}
+}
+
+func TestValidateChartMarginsWithLimits(t *testing.T) {
+	t.Parallel()
+
+	actual, err := apitorenderer.ValidateChartMarginsWithLimits(&render.ChartMargins{
+		MarginLeft: &wrapperspb.Int32Value{Value: 5},
+	}, apitorenderer.Limits{
+		MarginTopDefault:    &wrapperspb.Int32Value{Value: 10},
+		MarginBottomDefault: &wrapperspb.Int32Value{Value: 20},
+		MarginLeftDefault:   &wrapperspb.Int32Value{Value: 30},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &render.ChartMargins{
+		MarginTop:    &wrapperspb.Int32Value{Value: 10},
+		MarginBottom: &wrapperspb.Int32Value{Value: 20},
+		MarginLeft:   &wrapperspb.Int32Value{Value: 5},
+		MarginRight:  &wrapperspb.Int32Value{Value: 40},
+	}, actual)
+}
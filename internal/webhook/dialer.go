@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const (
+	dialTimeout   = 30 * time.Second
+	dialKeepAlive = 30 * time.Second
+)
+
+// ErrCallbackAddressDenied contains error message about callback URL that resolves to a denied IP.
+var ErrCallbackAddressDenied = errors.New("callback address is denied")
+
+// newClient returns an HTTP client that doesn't follow redirects and refuses to connect to the denied IPs.
+// IPs are checked after DNS resolution right before the connection is made, so callback hosts can't be resolved
+// into the internal addresses.
+func newClient(deny []*net.IPNet) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: dialKeepAlive,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			return checkAddress(deny, address)
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone() // nolint: forcetypeassert
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func checkAddress(deny []*net.IPNet, address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrCallbackAddressDenied, err)
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("%w: %s is not an IP", ErrCallbackAddressDenied, host)
+	}
+
+	for _, ipNet := range deny {
+		if ipNet.Contains(ip) {
+			return fmt.Errorf("%w: %s", ErrCallbackAddressDenied, ip)
+		}
+	}
+
+	return nil
+}
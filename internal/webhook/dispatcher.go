@@ -0,0 +1,329 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/limpidchart/lc-api/internal/clientip"
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/convert"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/serverhttp/v0/view"
+)
+
+const (
+	// SignatureHeader represents a header with HMAC-SHA256 signature of the callback body.
+	SignatureHeader = "X-Lc-Signature-256"
+
+	signaturePrefix = "sha256="
+
+	dispatcherName = "webhook dispatcher"
+
+	deadLetterFilePerm = 0o640
+
+	transportErrStatusCode = "error"
+)
+
+var (
+	// ErrBadConfig contains error message about webhooks configuration that can't be used.
+	ErrBadConfig = errors.New("bad webhooks configuration")
+
+	// ErrUnexpectedStatusCode contains error message about callback receiver reply with non-2xx status code.
+	ErrUnexpectedStatusCode = errors.New("callback receiver replied with unexpected status code")
+
+	// ErrDispatcherStopped contains error message about callback that is not delivered before shutdown.
+	ErrDispatcherStopped = errors.New("webhook dispatcher is stopped before the callback is delivered")
+)
+
+// Dispatcher represents a pool of workers that deliver callbacks from Queue.
+// Callbacks are retried with exponential backoff and written into the dead-letter log once all attempts fail.
+type Dispatcher struct {
+	log            *zerolog.Logger
+	queue          *Queue
+	client         *http.Client
+	secret         []byte
+	workers        int
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	requestTimeout time.Duration
+	deadLetterPath string
+	deadLetterMu   sync.Mutex
+	deadLetter     io.Writer
+	pRec           metric.PromRecorder
+}
+
+type payload struct {
+	Chart *view.ChartReply `json:"chart"`
+}
+
+type deadLetterRecord struct {
+	Time        time.Time       `json:"time"`
+	ChartID     string          `json:"chart_id"`
+	CallbackURL string          `json:"callback_url"`
+	Attempts    int             `json:"attempts"`
+	Error       string          `json:"error"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// NewDispatcher configures a new Dispatcher.
+// Callbacks are not sent to the IPs from the deny list that is provided as comma separated CIDRs or single IPs.
+func NewDispatcher(log *zerolog.Logger, queue *Queue, webhookCfg config.WebhookConfig, pRec metric.PromRecorder) (*Dispatcher, error) {
+	deny, err := clientip.ParseCIDRs(webhookCfg.Deny)
+	if err != nil {
+		return nil, fmt.Errorf("%w: deny list: %s", ErrBadConfig, err)
+	}
+
+	return &Dispatcher{
+		log:            log,
+		queue:          queue,
+		client:         newClient(deny),
+		secret:         []byte(webhookCfg.Secret),
+		workers:        webhookCfg.Workers,
+		maxAttempts:    webhookCfg.MaxAttempts,
+		initialBackoff: time.Duration(webhookCfg.InitialBackoffSeconds) * time.Second,
+		maxBackoff:     time.Duration(webhookCfg.MaxBackoffSeconds) * time.Second,
+		requestTimeout: time.Duration(webhookCfg.RequestTimeoutSeconds) * time.Second,
+		deadLetterPath: webhookCfg.DeadLetterPath,
+		pRec:           pRec,
+	}, nil
+}
+
+// Serve delivers queued callbacks until the provided context is done.
+// Callbacks that are dropped because the queue is full and callbacks that are still queued after the context is done
+// are written into the dead-letter log, so Serve should be stopped after everything that pushes callbacks.
+// Dead-letter log isn't opened if callbacks are disabled.
+func (d *Dispatcher) Serve(ctx context.Context) error {
+	if !d.queue.enabled {
+		<-ctx.Done()
+
+		return nil
+	}
+
+	deadLetter, err := os.OpenFile(d.deadLetterPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, deadLetterFilePerm)
+	if err != nil {
+		return fmt.Errorf("unable to open webhooks dead-letter log: %w", err)
+	}
+
+	defer deadLetter.Close()
+
+	d.deadLetter = deadLetter
+
+	d.queue.setDropHandler(d.writeDropped)
+	defer d.queue.setDropHandler(nil)
+
+	done := make(chan struct{}, d.workers)
+
+	for i := 0; i < d.workers; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+
+			for {
+				// Queued callbacks are not taken after the context is done even if both are ready.
+				if ctx.Err() != nil {
+					return
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case del := <-d.queue.deliveries:
+					d.deliver(ctx, del)
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < d.workers; i++ {
+		<-done
+	}
+
+	d.log.Info().
+		Time(zerolog.TimestampFieldName, time.Now().UTC()).
+		Msg("Stopping webhook dispatcher")
+
+	d.drain()
+
+	return nil
+}
+
+// Address returns an empty string since Dispatcher doesn't listen on any address.
+func (d *Dispatcher) Address() string {
+	return ""
+}
+
+// Name returns dispatcher name.
+func (d *Dispatcher) Name() string {
+	return dispatcherName
+}
+
+// Sign returns HMAC-SHA256 signature of the callback body that is sent in SignatureHeader.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, del delivery) {
+	body, err := marshalPayload(del)
+	if err != nil {
+		d.writeDeadLetter(del, body, 0, err)
+
+		return
+	}
+
+	backoff := d.initialBackoff
+	attempts := 0
+
+	for {
+		attempts++
+
+		err = d.send(ctx, del.callbackURL, body)
+		if err == nil {
+			d.pRec.WebhookDeliveries().WithLabelValues(metric.WebhookDelivered).Inc()
+
+			return
+		}
+
+		if attempts >= d.maxAttempts {
+			break
+		}
+
+		backoffTimer := time.NewTimer(backoff)
+
+		select {
+		case <-ctx.Done():
+			backoffTimer.Stop()
+			d.writeDeadLetter(del, body, attempts, ErrDispatcherStopped)
+
+			return
+		case <-backoffTimer.C:
+		}
+
+		if backoff *= 2; backoff > d.maxBackoff {
+			backoff = d.maxBackoff
+		}
+	}
+
+	d.writeDeadLetter(del, body, attempts, err)
+}
+
+func (d *Dispatcher) send(ctx context.Context, callbackURL string, body []byte) error {
+	reqCtx, reqCancel := context.WithTimeout(ctx, d.requestTimeout)
+	defer reqCancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to prepare callback request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(d.secret, body))
+
+	started := time.Now()
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		d.pRec.WebhookDeliveryDuration().WithLabelValues(transportErrStatusCode).Observe(time.Since(started).Seconds())
+
+		return fmt.Errorf("unable to send callback request: %w", err)
+	}
+
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	d.pRec.WebhookDeliveryDuration().WithLabelValues(strconv.Itoa(resp.StatusCode)).Observe(time.Since(started).Seconds())
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// marshalPayload returns callback body with the chart representation.
+// JSON null is returned with error so the body can still be written into the dead-letter log.
+func marshalPayload(del delivery) ([]byte, error) {
+	body, err := json.Marshal(payload{Chart: convert.ChartReplyToJSON(del.chart)})
+	if err != nil {
+		return []byte("null"), fmt.Errorf("unable to marshal callback body: %w", err)
+	}
+
+	return body, nil
+}
+
+func (d *Dispatcher) drain() {
+	for {
+		select {
+		case del := <-d.queue.deliveries:
+			body, _ := marshalPayload(del)
+			d.writeDeadLetter(del, body, 0, ErrDispatcherStopped)
+		default:
+			return
+		}
+	}
+}
+
+// writeDropped writes the delivery dropped by the full queue into the dead-letter log.
+// It's counted by the queue as dropped, not as dead-lettered.
+func (d *Dispatcher) writeDropped(del delivery) {
+	body, _ := marshalPayload(del)
+	d.appendDeadLetter(del, body, 0, ErrWebhookQueueFull)
+}
+
+func (d *Dispatcher) writeDeadLetter(del delivery, body []byte, attempts int, deliveryErr error) {
+	// Delivery is counted once the record is written.
+	defer d.pRec.WebhookDeliveries().WithLabelValues(metric.WebhookDeadLetter).Inc()
+
+	d.appendDeadLetter(del, body, attempts, deliveryErr)
+}
+
+func (d *Dispatcher) appendDeadLetter(del delivery, body []byte, attempts int, deliveryErr error) {
+	now := time.Now().UTC()
+
+	d.log.Warn().
+		Time(zerolog.TimestampFieldName, now).
+		Str("chart_id", del.chart.ChartId).
+		Str("callback_url", del.callbackURL).
+		Int("attempts", attempts).
+		Err(deliveryErr).
+		Msg("Unable to deliver chart callback, writing it into dead-letter log")
+
+	record, err := json.Marshal(deadLetterRecord{
+		Time:        now,
+		ChartID:     del.chart.ChartId,
+		CallbackURL: del.callbackURL,
+		Attempts:    attempts,
+		Error:       deliveryErr.Error(),
+		Payload:     body,
+	})
+	if err != nil {
+		d.log.Error().Time(zerolog.TimestampFieldName, now).Err(err).Msg("Unable to marshal dead-letter record")
+
+		return
+	}
+
+	d.deadLetterMu.Lock()
+	defer d.deadLetterMu.Unlock()
+
+	if _, err := d.deadLetter.Write(append(record, '\n')); err != nil {
+		d.log.Error().Time(zerolog.TimestampFieldName, now).Err(err).Msg("Unable to write dead-letter record")
+	}
+}
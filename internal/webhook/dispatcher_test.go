@@ -0,0 +1,344 @@
+package webhook_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/webhook"
+)
+
+const testingWebhookSecret = "webhook-secret"
+
+func testingWebhookConfig(t *testing.T) config.WebhookConfig {
+	t.Helper()
+
+	return config.WebhookConfig{
+		Secret:                testingWebhookSecret,
+		Workers:               1,
+		QueueSize:             10,
+		MaxAttempts:           3,
+		InitialBackoffSeconds: 0,
+		MaxBackoffSeconds:     0,
+		RequestTimeoutSeconds: 1,
+		DeadLetterPath:        filepath.Join(t.TempDir(), "dead-letter.ndjson"),
+	}
+}
+
+func testingChart() *render.ChartReply {
+	return &render.ChartReply{
+		RequestId:   "ea9e6a3b-c1a6-4b1c-9fa4-1e01a19a5de4",
+		ChartId:     "f2b5ad1b-84f4-4b2f-9c5b-1b5c1d4a1a6a",
+		ChartStatus: render.ChartStatus_CREATED,
+		CreatedAt:   timestamppb.New(time.Date(2021, 8, 22, 10, 20, 30, 0, time.UTC)),
+		ChartData:   []byte("<svg>chart</svg>"),
+	}
+}
+
+func serveDispatcher(ctx context.Context, t *testing.T, log *zerolog.Logger, queue *webhook.Queue, webhookCfg config.WebhookConfig, pRec metric.PromRecorder) {
+	t.Helper()
+
+	dispatcher, err := webhook.NewDispatcher(log, queue, webhookCfg, pRec)
+	if err != nil {
+		t.Fatalf("unable to configure webhook dispatcher: %s", err)
+	}
+
+	go func() {
+		if err := dispatcher.Serve(ctx); err != nil {
+			t.Errorf("unable to serve webhook dispatcher: %s", err)
+		}
+	}()
+}
+
+type deadLetterRecord struct {
+	ChartID     string          `json:"chart_id"`
+	CallbackURL string          `json:"callback_url"`
+	Attempts    int             `json:"attempts"`
+	Error       string          `json:"error"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// readDeadLetter returns the only record of the dead-letter log.
+func readDeadLetter(t *testing.T, deadLetterPath string) deadLetterRecord {
+	t.Helper()
+
+	deadLetter, err := os.Open(deadLetterPath)
+	if err != nil {
+		t.Fatalf("unable to open dead-letter log: %s", err)
+	}
+	defer deadLetter.Close()
+
+	scanner := bufio.NewScanner(deadLetter)
+	assert.True(t, scanner.Scan())
+
+	record := deadLetterRecord{}
+
+	assert.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+	assert.False(t, scanner.Scan())
+
+	return record
+}
+
+func TestDispatcher_Delivered(t *testing.T) {
+	t.Parallel()
+
+	var attempts int64
+
+	bodies := make(chan []byte, 1)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first attempt to check that the callback is retried.
+		if atomic.AddInt64(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		if r.Header.Get(webhook.SignatureHeader) != webhook.Sign([]byte(testingWebhookSecret), body) {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		bodies <- body
+	}))
+	defer receiver.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	log := zerolog.New(os.Stderr)
+	pRec := metric.NewEmptyRecorder()
+	webhookCfg := testingWebhookConfig(t)
+	queue := webhook.NewQueue(webhookCfg, pRec)
+
+	serveDispatcher(ctx, t, &log, queue, webhookCfg, pRec)
+
+	queue.Push(receiver.URL, testingChart())
+
+	select {
+	case body := <-bodies:
+		assert.JSONEq(t, `{"chart":{"request_id":"ea9e6a3b-c1a6-4b1c-9fa4-1e01a19a5de4","chart_id":"f2b5ad1b-84f4-4b2f-9c5b-1b5c1d4a1a6a","chart_status":"CREATED","created_at":"2021-08-22T10:20:30Z","deleted_at":null,"chart_data":"PHN2Zz5jaGFydDwvc3ZnPg==","title":"","expires_at":null,"error_message":"","tenant":"","cost":0}}`, string(body))
+	case <-ctx.Done():
+		t.Fatal("callback is not delivered")
+	}
+
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(pRec.WebhookDeliveries().WithLabelValues(metric.WebhookDelivered)) == 1
+	}, time.Second, time.Millisecond*10)
+	assert.Equal(t, int64(2), atomic.LoadInt64(&attempts))
+}
+
+func TestDispatcher_DeadLetter(t *testing.T) {
+	t.Parallel()
+
+	var attempts int64
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	log := zerolog.New(os.Stderr)
+	pRec := metric.NewEmptyRecorder()
+	webhookCfg := testingWebhookConfig(t)
+	queue := webhook.NewQueue(webhookCfg, pRec)
+
+	serveDispatcher(ctx, t, &log, queue, webhookCfg, pRec)
+
+	queue.Push(receiver.URL, testingChart())
+
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(pRec.WebhookDeliveries().WithLabelValues(metric.WebhookDeadLetter)) == 1
+	}, time.Second*2, time.Millisecond*10)
+	assert.Equal(t, int64(webhookCfg.MaxAttempts), atomic.LoadInt64(&attempts))
+
+	record := readDeadLetter(t, webhookCfg.DeadLetterPath)
+	assert.Equal(t, testingChart().ChartId, record.ChartID)
+	assert.Equal(t, receiver.URL, record.CallbackURL)
+	assert.Equal(t, webhookCfg.MaxAttempts, record.Attempts)
+	assert.Equal(t, "callback receiver replied with unexpected status code: 500", record.Error)
+	assert.Contains(t, string(record.Payload), testingChart().ChartId)
+}
+
+func TestDispatcher_QueueFull(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan struct{}, 1)
+	release := make(chan struct{})
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		<-release
+	}))
+	defer receiver.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	log := zerolog.New(os.Stderr)
+	pRec := metric.NewEmptyRecorder()
+	webhookCfg := testingWebhookConfig(t)
+	webhookCfg.QueueSize = 1
+	webhookCfg.RequestTimeoutSeconds = 5
+	queue := webhook.NewQueue(webhookCfg, pRec)
+
+	serveDispatcher(ctx, t, &log, queue, webhookCfg, pRec)
+
+	// The only worker is blocked by the first callback and the second one takes the only queue slot.
+	queue.Push(receiver.URL, testingChart())
+	<-received
+	queue.Push(receiver.URL, testingChart())
+
+	droppedChart := testingChart()
+	droppedChart.ChartId = "0b6a7c1e-4d0e-4d8b-a1b4-3f3c8d0e2a51"
+
+	queue.Push(receiver.URL, droppedChart)
+
+	record := readDeadLetter(t, webhookCfg.DeadLetterPath)
+	assert.Equal(t, droppedChart.ChartId, record.ChartID)
+	assert.Equal(t, receiver.URL, record.CallbackURL)
+	assert.Equal(t, 0, record.Attempts)
+	assert.Equal(t, "webhook queue is full", record.Error)
+	assert.Contains(t, string(record.Payload), droppedChart.ChartId)
+	assert.Equal(t, float64(1), testutil.ToFloat64(pRec.WebhookDeliveries().WithLabelValues(metric.WebhookDropped)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(pRec.WebhookDeliveries().WithLabelValues(metric.WebhookDeadLetter)))
+}
+
+func TestDispatcher_DeniedAddress(t *testing.T) {
+	t.Parallel()
+
+	var attempts int64
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&attempts, 1)
+	}))
+	defer receiver.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	log := zerolog.New(os.Stderr)
+	pRec := metric.NewEmptyRecorder()
+	webhookCfg := testingWebhookConfig(t)
+	webhookCfg.Deny = "127.0.0.0/8,::1"
+	queue := webhook.NewQueue(webhookCfg, pRec)
+
+	serveDispatcher(ctx, t, &log, queue, webhookCfg, pRec)
+
+	// Host name is resolved into the denied loopback address.
+	callbackURL := strings.Replace(receiver.URL, "127.0.0.1", "localhost", 1)
+	queue.Push(callbackURL, testingChart())
+
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(pRec.WebhookDeliveries().WithLabelValues(metric.WebhookDeadLetter)) == 1
+	}, time.Second*2, time.Millisecond*10)
+	assert.Equal(t, int64(0), atomic.LoadInt64(&attempts))
+
+	record := readDeadLetter(t, webhookCfg.DeadLetterPath)
+	assert.Equal(t, callbackURL, record.CallbackURL)
+	assert.Contains(t, record.Error, "callback address is denied")
+}
+
+func TestDispatcher_Redirect(t *testing.T) {
+	t.Parallel()
+
+	var redirected int64
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&redirected, 1)
+	}))
+	defer target.Close()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer receiver.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	log := zerolog.New(os.Stderr)
+	pRec := metric.NewEmptyRecorder()
+	webhookCfg := testingWebhookConfig(t)
+	queue := webhook.NewQueue(webhookCfg, pRec)
+
+	serveDispatcher(ctx, t, &log, queue, webhookCfg, pRec)
+
+	queue.Push(receiver.URL, testingChart())
+
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(pRec.WebhookDeliveries().WithLabelValues(metric.WebhookDeadLetter)) == 1
+	}, time.Second*2, time.Millisecond*10)
+	assert.Equal(t, int64(0), atomic.LoadInt64(&redirected))
+
+	record := readDeadLetter(t, webhookCfg.DeadLetterPath)
+	assert.Equal(t, "callback receiver replied with unexpected status code: 307", record.Error)
+}
+
+func TestNewDispatcher_BadConfig(t *testing.T) {
+	t.Parallel()
+
+	log := zerolog.New(os.Stderr)
+	pRec := metric.NewEmptyRecorder()
+	webhookCfg := testingWebhookConfig(t)
+	webhookCfg.Deny = "10.0.0.0/8,internal"
+
+	dispatcher, err := webhook.NewDispatcher(&log, webhook.NewQueue(webhookCfg, pRec), webhookCfg, pRec)
+	assert.Nil(t, dispatcher)
+	assert.True(t, errors.Is(err, webhook.ErrBadConfig))
+	assert.EqualError(t, err, `bad webhooks configuration: deny list: unable to parse "internal" as IP or CIDR`)
+}
+
+func TestDispatcher_Disabled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	log := zerolog.New(os.Stderr)
+	pRec := metric.NewEmptyRecorder()
+	webhookCfg := testingWebhookConfig(t)
+	webhookCfg.Secret = ""
+
+	dispatcher, err := webhook.NewDispatcher(&log, webhook.NewQueue(webhookCfg, pRec), webhookCfg, pRec)
+	if err != nil {
+		t.Fatalf("unable to configure webhook dispatcher: %s", err)
+	}
+
+	cancel()
+
+	assert.NoError(t, dispatcher.Serve(ctx))
+
+	_, err = os.Stat(webhookCfg.DeadLetterPath)
+	assert.True(t, os.IsNotExist(err))
+}
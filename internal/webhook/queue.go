@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+)
+
+var (
+	// ErrBadCallbackURL contains error message about callback URL that is not an absolute HTTP or HTTPS URL.
+	ErrBadCallbackURL = errors.New("callback_url should be an absolute HTTP or HTTPS URL")
+
+	// ErrCallbacksDisabled contains error message about callback URL that is provided while webhooks secret is not configured.
+	ErrCallbacksDisabled = errors.New("chart callbacks are not configured")
+
+	// ErrWebhookQueueFull contains error message about callback that is dropped because the queue has no free slots.
+	ErrWebhookQueueFull = errors.New("webhook queue is full")
+)
+
+// Queue represents a bounded queue of chart callbacks deliveries.
+type Queue struct {
+	enabled    bool
+	deliveries chan delivery
+	pRec       metric.PromRecorder
+
+	dropMu sync.RWMutex
+	drop   func(delivery)
+}
+
+type delivery struct {
+	callbackURL string
+	chart       *render.ChartReply
+}
+
+// NewQueue returns a new Queue that can keep up to the configured number of deliveries.
+// Callbacks are enabled only if webhooks secret is configured.
+func NewQueue(webhookCfg config.WebhookConfig, pRec metric.PromRecorder) *Queue {
+	return &Queue{
+		enabled:    webhookCfg.Secret != "",
+		deliveries: make(chan delivery, webhookCfg.QueueSize),
+		pRec:       pRec,
+	}
+}
+
+// ValidateCallbackURL checks if the provided callback URL can be used.
+func (q *Queue) ValidateCallbackURL(callbackURL string) error {
+	if !q.enabled {
+		return ErrCallbacksDisabled
+	}
+
+	u, err := url.Parse(callbackURL)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBadCallbackURL, err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrBadCallbackURL
+	}
+
+	return nil
+}
+
+// Push queues delivery of a chart copy to the callback URL without blocking.
+// Delivery is dropped if the queue is full, dropped deliveries are written into the dead-letter log while
+// Dispatcher is serving.
+func (q *Queue) Push(callbackURL string, chart *render.ChartReply) {
+	// nolint: forcetypeassert
+	d := delivery{
+		callbackURL: callbackURL,
+		chart:       proto.Clone(chart).(*render.ChartReply),
+	}
+
+	select {
+	case q.deliveries <- d:
+	default:
+		q.pRec.WebhookDeliveries().WithLabelValues(metric.WebhookDropped).Inc()
+
+		q.dropMu.RLock()
+		defer q.dropMu.RUnlock()
+
+		if q.drop != nil {
+			q.drop(d)
+		}
+	}
+}
+
+// setDropHandler sets a function that is called with every dropped delivery.
+// It waits for the running calls of the previous function, so nil can be set before the dead-letter log is closed.
+func (q *Queue) setDropHandler(drop func(delivery)) {
+	q.dropMu.Lock()
+	defer q.dropMu.Unlock()
+
+	q.drop = drop
+}
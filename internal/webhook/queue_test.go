@@ -0,0 +1,90 @@
+package webhook_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/limpidchart/lc-api/internal/config"
+	"github.com/limpidchart/lc-api/internal/metric"
+	"github.com/limpidchart/lc-api/internal/render/github.com/limpidchart/lc-proto/render/v0"
+	"github.com/limpidchart/lc-api/internal/webhook"
+)
+
+func TestQueue_ValidateCallbackURL(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name        string
+		secret      string
+		callbackURL string
+		expectedErr error
+	}{
+		{
+			"https",
+			"secret",
+			"https://example.com/charts?report=1",
+			nil,
+		},
+		{
+			"http",
+			"secret",
+			"http://127.0.0.1:8080/charts",
+			nil,
+		},
+		{
+			"relative",
+			"secret",
+			"/charts",
+			webhook.ErrBadCallbackURL,
+		},
+		{
+			"bad_scheme",
+			"secret",
+			"ftp://example.com/charts",
+			webhook.ErrBadCallbackURL,
+		},
+		{
+			"bad_url",
+			"secret",
+			"http://exa mple.com",
+			webhook.ErrBadCallbackURL,
+		},
+		{
+			"disabled",
+			"",
+			"https://example.com/charts",
+			webhook.ErrCallbacksDisabled,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			queue := webhook.NewQueue(config.WebhookConfig{Secret: tc.secret, QueueSize: 1}, metric.NewEmptyRecorder())
+
+			err := queue.ValidateCallbackURL(tc.callbackURL)
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, tc.expectedErr))
+			}
+		})
+	}
+}
+
+func TestQueue_PushDropped(t *testing.T) {
+	t.Parallel()
+
+	pRec := metric.NewEmptyRecorder()
+	queue := webhook.NewQueue(config.WebhookConfig{Secret: "secret", QueueSize: 1}, pRec)
+
+	queue.Push("https://example.com/charts", &render.ChartReply{})
+	queue.Push("https://example.com/charts", &render.ChartReply{})
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(pRec.WebhookDeliveries().WithLabelValues(metric.WebhookDropped)))
+}